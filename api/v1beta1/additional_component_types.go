@@ -0,0 +1,98 @@
+package v1beta1
+
+import (
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+)
+
+// AdditionalComponentConfig configures a component that is not built into the operator.
+// +kubebuilder:validation:XValidation:rule="!has(self.source) || !(has(self.source.git) || has(self.source.oci)) || !(has(self.values) || has(self.valuesFrom))",message="values can only be set for components with a Helm chart"
+// +kubebuilder:validation:XValidation:rule="has(self.targetNamespace) || has(self.definition)",message="targetNamespace must be set for components without a definition"
+type AdditionalComponentConfig struct {
+	// Name of the component. It is the name of the component in the ReleaseChannels and of the Helm release.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=53
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// The Version of the component to install.
+	// May be a version constraint, e.g. `~1.16` or `latest`. The component is disabled if no version is set.
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+
+	// Policy for automatic upgrades within the maintenance windows of the ControlPlane. Defaults to manual.
+	// +kubebuilder:validation:Optional
+	UpgradePolicy UpgradePolicy `json:"upgradePolicy,omitempty"`
+
+	// Name of the ComponentDefinition with the defaults of the component, i.e. its namespace, chart, values,
+	// dependencies, policy rules and protected resources. The fields of the component take precedence.
+	// +kubebuilder:validation:Optional
+	Definition string `json:"definition,omitempty"`
+
+	// Source of the component. Defaults to the chart of the definition or the Helm chart of the component in the ReleaseChannels.
+	// +kubebuilder:validation:Optional
+	Source *AdditionalComponentSource `json:"source,omitempty"`
+
+	// Namespace in the target cluster the component is installed in. Defaults to the namespace of the definition.
+	// Manifests from Git or OCI sources must contain the namespace, it is only created for Helm charts.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	TargetNamespace string `json:"targetNamespace,omitempty"`
+
+	// Optional additional values that should be passed to the Helm chart.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Values *apiextensionsv1.JSON `json:"values,omitempty"`
+
+	// References to ConfigMaps and Secrets with values, merged in the given order. The inline values take precedence.
+	// +kubebuilder:validation:Optional
+	ValuesFrom []ValuesReference `json:"valuesFrom,omitempty"`
+
+	// Names of the components that must be ready before the component is installed,
+	// e.g. `CertManager` or the name of another additional component.
+	// +kubebuilder:validation:Optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Rules which are added to the aggregated ClusterRoles of the ControlPlane.
+	// +kubebuilder:validation:Optional
+	PolicyRules *ComponentPolicyRules `json:"policyRules,omitempty"`
+}
+
+// AdditionalComponentSource is the source of an additional component. Exactly one source must be set.
+// +kubebuilder:validation:XValidation:rule="[has(self.chart), has(self.git), has(self.oci)].filter(x, x).size() == 1",message="exactly one of chart, git and oci must be set"
+type AdditionalComponentSource struct {
+	// Helm chart in a Helm repository or an OCI registry.
+	// The version of the chart defaults to the version of the component.
+	// +kubebuilder:validation:Optional
+	Chart *ChartSpec `json:"chart,omitempty"`
+
+	// Git repository with plain manifests. The version of the component is used as tag.
+	// +kubebuilder:validation:Optional
+	Git *ManifestsSource `json:"git,omitempty"`
+
+	// OCI artifact with plain manifests. The version of the component is used as tag.
+	// +kubebuilder:validation:Optional
+	OCI *ManifestsSource `json:"oci,omitempty"`
+}
+
+// ManifestsSource is a repository with plain manifests, which are applied by a Flux Kustomization.
+type ManifestsSource struct {
+	// URL of the repository, e.g. `https://github.com/org/repo` or `oci://ghcr.io/org/manifests`.
+	// +kubebuilder:validation:MinLength=1
+	URL string `json:"url"`
+
+	// Path to the directory with the manifests. Defaults to the root of the repository.
+	// +kubebuilder:validation:Optional
+	Path string `json:"path,omitempty"`
+}
+
+// ComponentPolicyRules are the rules a component adds to the aggregated ClusterRoles of the ControlPlane.
+type ComponentPolicyRules struct {
+	// Rules for the admin ClusterRole.
+	// +kubebuilder:validation:Optional
+	Admin []rbacv1.PolicyRule `json:"admin,omitempty"`
+
+	// Rules for the view ClusterRole.
+	// +kubebuilder:validation:Optional
+	View []rbacv1.PolicyRule `json:"view,omitempty"`
+}
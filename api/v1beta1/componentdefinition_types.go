@@ -0,0 +1,79 @@
+package v1beta1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ComponentDefinitionSpec describes how a component is installed from a Helm chart.
+// A ComponentDefinition that is named like the ReleaseChannel name of a built-in component (e.g. `kyverno`)
+// adjusts the built-in definition, fields that are not set keep their built-in defaults.
+type ComponentDefinitionSpec struct {
+	// Name of the component in the ReleaseChannels. Defaults to the name of the ComponentDefinition.
+	// +kubebuilder:validation:Optional
+	ReleaseChannelName string `json:"releaseChannelName,omitempty"`
+
+	// Name of the Helm release. Defaults to the name of the ComponentDefinition.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=53
+	ReleaseName string `json:"releaseName,omitempty"`
+
+	// Namespace in the target cluster the component is installed in.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace,omitempty"`
+
+	// Default chart of the component. The version of the chart defaults to the version of the component.
+	// Defaults to the Helm chart of the component in the ReleaseChannels.
+	// +kubebuilder:validation:Optional
+	Chart *ChartSpec `json:"chart,omitempty"`
+
+	// Default values of the Helm chart. The values of the ControlPlane take precedence.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Values *apiextensionsv1.JSON `json:"values,omitempty"`
+
+	// Names of the components that must be ready before the component is installed,
+	// e.g. `CertManager` or the name of an additional component.
+	// +kubebuilder:validation:Optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Rules which are added to the aggregated ClusterRoles of the ControlPlane.
+	// +kubebuilder:validation:Optional
+	PolicyRules *ComponentPolicyRules `json:"policyRules,omitempty"`
+
+	// Kinds of resources which prevent the uninstallation of the component as long as they exist in the target cluster.
+	// +kubebuilder:validation:Optional
+	ProtectedResources []metav1.GroupVersionKind `json:"protectedResources,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Namespace",type="string",JSONPath=".spec.namespace"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ComponentDefinition describes a Helm-based component, so components can be added or adjusted
+// without rebuilding the operator.
+type ComponentDefinition struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ComponentDefinitionSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ComponentDefinitionList contains a list of ComponentDefinition
+type ComponentDefinitionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComponentDefinition `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(GroupVersion, &ComponentDefinition{}, &ComponentDefinitionList{})
+		return nil
+	})
+}
@@ -0,0 +1,204 @@
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// RolloutComponent names a component of a ControlPlane that can be rolled out.
+// It matches the name of the component in the status of the ControlPlane.
+// +kubebuilder:validation:Enum=Crossplane;CertManager;BTPServiceOperator;ExternalSecretsOperator;Kyverno;Flux
+type RolloutComponent string
+
+const (
+	RolloutComponentCrossplane              RolloutComponent = "Crossplane"
+	RolloutComponentCertManager             RolloutComponent = "CertManager"
+	RolloutComponentBTPServiceOperator      RolloutComponent = "BTPServiceOperator"
+	RolloutComponentExternalSecretsOperator RolloutComponent = "ExternalSecretsOperator"
+	RolloutComponentKyverno                 RolloutComponent = "Kyverno"
+	RolloutComponentFlux                    RolloutComponent = "Flux"
+)
+
+// ComponentVersion returns a pointer to the version of the component in the spec of the ControlPlane
+// or nil if the component is not configured.
+func (c RolloutComponent) ComponentVersion(cp *ControlPlane) *string {
+	switch c {
+	case RolloutComponentCrossplane:
+		if cp.Spec.Crossplane != nil {
+			return &cp.Spec.Crossplane.Version
+		}
+	case RolloutComponentCertManager:
+		if cp.Spec.CertManager != nil {
+			return &cp.Spec.CertManager.Version
+		}
+	case RolloutComponentBTPServiceOperator:
+		if cp.Spec.BTPServiceOperator != nil {
+			return &cp.Spec.BTPServiceOperator.Version
+		}
+	case RolloutComponentExternalSecretsOperator:
+		if cp.Spec.ExternalSecretsOperator != nil {
+			return &cp.Spec.ExternalSecretsOperator.Version
+		}
+	case RolloutComponentKyverno:
+		if cp.Spec.Kyverno != nil {
+			return &cp.Spec.Kyverno.Version
+		}
+	case RolloutComponentFlux:
+		if cp.Spec.Flux != nil {
+			return &cp.Spec.Flux.Version
+		}
+	}
+	return nil
+}
+
+// ComponentRolloutSpec defines the desired state of ComponentRollout
+type ComponentRolloutSpec struct {
+	// Selector for the ControlPlanes the component is rolled out to.
+	// ControlPlanes which do not configure the component are ignored, also if they get it from their ControlPlaneProfile.
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// Component that is rolled out.
+	Component RolloutComponent `json:"component"`
+
+	// Version the component is updated to.
+	// +kubebuilder:validation:MinLength=1
+	Version string `json:"version"`
+
+	// Percentage of the ControlPlanes that are updated in the first (canary) wave. At least one ControlPlane is updated.
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	CanaryPercentage int32 `json:"canaryPercentage,omitempty"`
+
+	// Number of ControlPlanes that are updated in every wave after the canary wave.
+	// +kubebuilder:default=10
+	// +kubebuilder:validation:Minimum=1
+	WaveSize int32 `json:"waveSize,omitempty"`
+
+	// Time after which a wave is considered failed if the component is not healthy on all its ControlPlanes.
+	// +kubebuilder:default="30m"
+	ProgressDeadline metav1.Duration `json:"progressDeadline,omitempty"`
+
+	// Paused stops the rollout before the next wave. It is set automatically if a wave fails
+	// and has to be reset to continue the rollout.
+	// +kubebuilder:validation:Optional
+	Paused bool `json:"paused,omitempty"`
+}
+
+// RolloutPhase is the state of a ComponentRollout.
+// +kubebuilder:validation:Enum=Progressing;Paused;Completed
+type RolloutPhase string
+
+const (
+	// RolloutPhaseProgressing states that the component is being rolled out.
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+	// RolloutPhasePaused states that the rollout has been paused manually or due to a failed wave.
+	RolloutPhasePaused RolloutPhase = "Paused"
+	// RolloutPhaseCompleted states that the component is healthy in the new version on all ControlPlanes.
+	RolloutPhaseCompleted RolloutPhase = "Completed"
+)
+
+// RolloutTargetPhase is the state of the rollout on a single ControlPlane.
+// +kubebuilder:validation:Enum=Pending;Updating;Healthy;Failed
+type RolloutTargetPhase string
+
+const (
+	// RolloutTargetPhasePending states that the ControlPlane belongs to a later wave.
+	RolloutTargetPhasePending RolloutTargetPhase = "Pending"
+	// RolloutTargetPhaseUpdating states that the version has been set, but the component is not healthy yet.
+	RolloutTargetPhaseUpdating RolloutTargetPhase = "Updating"
+	// RolloutTargetPhaseHealthy states that the component is healthy in the new version.
+	RolloutTargetPhaseHealthy RolloutTargetPhase = "Healthy"
+	// RolloutTargetPhaseFailed states that the component failed to be reconciled in the new version.
+	RolloutTargetPhaseFailed RolloutTargetPhase = "Failed"
+)
+
+// RolloutTarget is a ControlPlane the component is rolled out to.
+type RolloutTarget struct {
+	// Name of the ControlPlane.
+	Name string `json:"name"`
+
+	// Wave in which the ControlPlane is updated, starting with 0 for the canary wave.
+	Wave int32 `json:"wave"`
+
+	// Phase of the rollout on the ControlPlane.
+	Phase RolloutTargetPhase `json:"phase"`
+
+	// Message with details about the component, e.g. why it failed.
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// ComponentRolloutStatus defines the observed state of ComponentRollout
+type ComponentRolloutStatus struct {
+	// Phase of the rollout.
+	// +optional
+	Phase RolloutPhase `json:"phase,omitempty"`
+
+	// Wave that is currently rolled out.
+	// +optional
+	CurrentWave int32 `json:"currentWave,omitempty"`
+
+	// Total number of waves.
+	// +optional
+	Waves int32 `json:"waves,omitempty"`
+
+	// Point in time the current wave has been started.
+	// +optional
+	CurrentWaveStartTime *metav1.Time `json:"currentWaveStartTime,omitempty"`
+
+	// Number of ControlPlanes the component is rolled out to.
+	// +optional
+	Total int32 `json:"total,omitempty"`
+
+	// Number of ControlPlanes on which the component is healthy in the new version.
+	// +optional
+	Healthy int32 `json:"healthy,omitempty"`
+
+	// Targets lists the ControlPlanes the component is rolled out to.
+	// The waves are assigned when the rollout starts, ControlPlanes that are selected later are added to the last wave.
+	// +optional
+	Targets []RolloutTarget `json:"targets,omitempty"`
+
+	// Message with details about the rollout, e.g. why it has been paused.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// ObservedGeneration is the generation of the ComponentRollout the status refers to.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+// ComponentRollout updates a component on a fleet of ControlPlanes in waves.
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Component",type="string",JSONPath=".spec.component"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.version"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Healthy",type="integer",JSONPath=".status.healthy"
+// +kubebuilder:printcolumn:name="Total",type="integer",JSONPath=".status.total"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+type ComponentRollout struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ComponentRolloutSpec   `json:"spec,omitempty"`
+	Status ComponentRolloutStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ComponentRolloutList contains a list of ComponentRollout
+type ComponentRolloutList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ComponentRollout `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(GroupVersion, &ComponentRollout{}, &ComponentRolloutList{})
+		return nil
+	})
+}
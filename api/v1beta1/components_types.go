@@ -30,4 +30,10 @@ type ComponentsConfig struct {
 	// https://fluxcd.io/
 	// +kubebuilder:validation:Optional
 	Flux *FluxConfig `json:"flux,omitempty"`
+
+	// Components that are not built into the operator, installed from a Helm chart or plain manifests.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	AdditionalComponents []AdditionalComponentConfig `json:"additionalComponents,omitempty"`
 }
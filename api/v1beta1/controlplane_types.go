@@ -19,6 +19,7 @@ package v1beta1
 import (
 	"github.com/openmcp-project/controller-utils/pkg/api"
 	v1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -33,7 +34,8 @@ const (
 
 // ControlPlaneSpec defines the desired state of ControlPlane
 type ControlPlaneSpec struct {
-	// Reference to a core configuration
+	// Reference to the ControlPlaneProfile whose defaults apply to the ControlPlane.
+	// The ControlPlane is reconciled without defaults if the profile does not exist.
 	// +kubebuilder:default:={name:"default"}
 	CoreReference v1.LocalObjectReference `json:"coreRef,omitempty"`
 
@@ -48,6 +50,11 @@ type ControlPlaneSpec struct {
 	// +kubebuilder:validation:Optional
 	PullSecrets []v1.LocalObjectReference `json:"pullSecrets,omitempty"`
 
+	// Maintenance windows in which components are upgraded according to their upgrade policy.
+	// If no maintenance windows are configured, components are upgraded at any time.
+	// +kubebuilder:validation:Optional
+	MaintenanceWindows []MaintenanceWindow `json:"maintenanceWindows,omitempty"`
+
 	ComponentsConfig `json:",inline"`
 }
 
@@ -81,16 +88,43 @@ type KubeconfigOverrides struct {
 
 // TelemetryConfig allows the toggling of telemetry data
 type TelemetryConfig struct {
-	// Enables or disables telemetry.
+	// Enables or disables telemetry. If enabled, an OpenTelemetry Collector is installed on the target
+	// cluster, which forwards the metrics of the components to the exporter endpoint.
 	Enabled bool `json:"enabled,omitempty"`
+
+	// The Version of the OpenTelemetry Collector to install.
+	// May be a version constraint, e.g. `~0.110` or `latest`. Telemetry is disabled if no version is set.
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+
+	// Policy for automatic upgrades within the maintenance windows of the ControlPlane. Defaults to manual.
+	// +kubebuilder:validation:Optional
+	UpgradePolicy UpgradePolicy `json:"upgradePolicy,omitempty"`
+
+	// OTLP/HTTP endpoint the metrics are exported to, e.g. `https://otlp.example.com:4318`.
+	// Defaults to the `--telemetry-exporter-endpoint` flag of the operator. Telemetry stays disabled if neither is set.
+	// +kubebuilder:validation:Optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// Optional custom chart configuration.
+	Chart *ChartSpec `json:"chart,omitempty"`
+
+	// Optional additional values that should be passed to the OpenTelemetry Collector Helm chart.
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Values *apiextensionsv1.JSON `json:"values,omitempty"`
+
+	// References to ConfigMaps and Secrets with values, merged in the given order. The inline values take precedence.
+	// +kubebuilder:validation:Optional
+	ValuesFrom []ValuesReference `json:"valuesFrom,omitempty"`
 }
 
 // ChartSpec identifies a Helm chart.
 type ChartSpec struct {
-	// Repository is the URL to a Helm repository
+	// Repository is the URL to a Helm repository, which may also be an `oci://` URL.
 	Repository string `json:"repository,omitempty"`
 
-	// URL is the URL to an OCI registry where the Helm chart is stored.
+	// URL is the OCI URL of the Helm chart, e.g. `oci://ghcr.io/org/charts/crossplane`.
+	// Takes precedence over the repository, the chart is pulled via an OCIRepository then.
 	URL string `json:"url,omitempty"`
 
 	// Name of the Helm chart
@@ -100,6 +134,69 @@ type ChartSpec struct {
 	Version string `json:"version,omitempty"`
 }
 
+// ValuesReference references a ConfigMap or Secret in the core cluster that contains Helm values.
+// The referent is copied into the namespace of the ControlPlane.
+type ValuesReference struct {
+	// Kind of the referent.
+	// +kubebuilder:validation:Enum=Secret;ConfigMap
+	Kind string `json:"kind"`
+
+	// Name of the referent.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=189
+	Name string `json:"name"`
+
+	// Namespace of the referent.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	Namespace string `json:"namespace"`
+
+	// Data key of the values.yaml or of a single value. Defaults to `values.yaml`.
+	// +kubebuilder:validation:Optional
+	ValuesKey string `json:"valuesKey,omitempty"`
+
+	// YAML dot notation path the value is merged at, the ValuesKey must contain a single value then.
+	// Defaults to the root of the values.
+	// +kubebuilder:validation:Optional
+	TargetPath string `json:"targetPath,omitempty"`
+
+	// Ignore the reference if the referent does not exist.
+	// +kubebuilder:validation:Optional
+	Optional bool `json:"optional,omitempty"`
+}
+
+// UpgradePolicy defines to which versions a component is upgraded automatically.
+// +kubebuilder:validation:Enum=manual;patch;minor
+type UpgradePolicy string
+
+const (
+	// UpgradePolicyManual disables automatic upgrades, the version is only changed in the spec.
+	UpgradePolicyManual UpgradePolicy = "manual"
+	// UpgradePolicyPatch upgrades to the newest patch version of the current minor version.
+	UpgradePolicyPatch UpgradePolicy = "patch"
+	// UpgradePolicyMinor upgrades to the newest minor or patch version of the current major version.
+	UpgradePolicyMinor UpgradePolicy = "minor"
+)
+
+// MaintenanceWindow is a weekly time range in which components may be upgraded.
+type MaintenanceWindow struct {
+	// Day of the week on which the window starts.
+	// +kubebuilder:validation:Enum=Monday;Tuesday;Wednesday;Thursday;Friday;Saturday;Sunday
+	Weekday string `json:"weekday"`
+
+	// Start of the window in 24-hour format (HH:MM).
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	Start string `json:"start"`
+
+	// End of the window in 24-hour format (HH:MM). If it is not after the start, the window ends on the next day.
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	End string `json:"end"`
+
+	// IANA name of the time zone of start and end, e.g. Europe/Berlin. Defaults to UTC.
+	// +kubebuilder:validation:Optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
 // ControlPlaneStatus defines the observed state of ControlPlane
 type ControlPlaneStatus struct {
 	// Current service state of the ControlPlane.
@@ -113,6 +210,107 @@ type ControlPlaneStatus struct {
 
 	// Number of healthy components.
 	ComponentsHealthy int `json:"componentsHealthy"`
+
+	// Components lists the state of every component of the ControlPlane.
+	// +optional
+	Components []ComponentStatus `json:"components,omitempty"`
+
+	// Retries lists components that failed in consecutive reconciliations and are retried with exponential backoff.
+	// +optional
+	Retries []ComponentRetryStatus `json:"retries,omitempty"`
+
+	// Plan lists the changes that would be applied to the components of the ControlPlane.
+	// It is only set while the ControlPlane is annotated with "core.orchestrate.cloud.sap/dry-run: true".
+	// +optional
+	Plan []ComponentPlan `json:"plan,omitempty"`
+}
+
+// ComponentVisibility states whether a component is relevant for end users.
+// +kubebuilder:validation:Enum=Internal;External
+type ComponentVisibility string
+
+const (
+	// ComponentVisibilityInternal is used for components that are managed by the operator itself, e.g. secrets or RBAC.
+	ComponentVisibilityInternal ComponentVisibility = "Internal"
+	// ComponentVisibilityExternal is used for components that are configured by the user, e.g. Crossplane.
+	ComponentVisibilityExternal ComponentVisibility = "External"
+)
+
+// ComponentStatus describes the observed state of a single component.
+type ComponentStatus struct {
+	// Name of the component.
+	Name string `json:"name"`
+
+	// Kind of the component, e.g. Crossplane or CrossplaneProvider.
+	Kind string `json:"kind"`
+
+	// Version of the component as configured in the spec. This may be a version constraint, e.g. `~1.16`.
+	// +optional
+	DesiredVersion string `json:"desiredVersion,omitempty"`
+
+	// Version of the ReleaseChannels the desired version resolves to.
+	// +optional
+	ResolvedVersion string `json:"resolvedVersion,omitempty"`
+
+	// Version of the component that is currently installed.
+	// +optional
+	ObservedVersion string `json:"observedVersion,omitempty"`
+
+	// Phase is the result of the last reconciliation of the component, e.g. Healthy or InstallFailed.
+	Phase string `json:"phase"`
+
+	// Last time the phase of the component changed.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+
+	// Message with details about the last reconciliation.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Visibility of the component.
+	Visibility ComponentVisibility `json:"visibility"`
+}
+
+// ComponentRetryStatus describes the consecutive failures of a single component.
+type ComponentRetryStatus struct {
+	// Name of the component.
+	Name string `json:"name"`
+
+	// Number of consecutive failed reconciliations.
+	RetryCount int `json:"retryCount"`
+
+	// Point in time before which the component is not reconciled again.
+	NextRetryAt metav1.Time `json:"nextRetryAt"`
+
+	// Message of the last failed reconciliation.
+	// +optional
+	LastError string `json:"lastError,omitempty"`
+
+	// Desired version of the component at the last failed reconciliation.
+	// The backoff is skipped once the desired version changes.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Whether the component was enabled at the last failed reconciliation.
+	// The backoff is skipped once the component is enabled or disabled.
+	// +optional
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// ComponentPlan describes the change that would be applied to a single component.
+type ComponentPlan struct {
+	// Name of the component.
+	Name string `json:"name"`
+
+	// Action that would be performed, e.g. Install, Update, Uninstall, None or Blocked.
+	Action string `json:"action"`
+
+	// Message with details about the planned action.
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Diff between the current and the desired state of the component (only for updates, possibly truncated).
+	// +optional
+	Diff string `json:"diff,omitempty"`
 }
 
 // ControlPlane is the Schema for the ControlPlane API
@@ -0,0 +1,58 @@
+package v1beta1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ControlPlaneProfileSpec defines the defaults of all ControlPlanes that reference the profile in `spec.coreRef`.
+// Fields that are set in the spec of a ControlPlane take precedence over the profile.
+type ControlPlaneProfileSpec struct {
+	// Pull secrets which are used by the ControlPlanes in addition to their own pull secrets.
+	// +kubebuilder:validation:Optional
+	PullSecrets []v1.LocalObjectReference `json:"pullSecrets,omitempty"`
+
+	// Name of the CrossplanePackageRestriction that restricts the Crossplane packages of the ControlPlanes.
+	// Defaults to "default".
+	// +kubebuilder:validation:Optional
+	CrossplanePackageRestriction string `json:"crossplanePackageRestriction,omitempty"`
+
+	// Enables the protection of Crossplane DeploymentRuntimeConfigs.
+	// Defaults to the `--enable-deploymentruntimeconfig-protection` flag of the operator.
+	// +kubebuilder:validation:Optional
+	DeploymentRuntimeConfigProtection *bool `json:"deploymentRuntimeConfigProtection,omitempty"`
+
+	// Default configuration of the components. A component that is configured in the profile is installed
+	// on every ControlPlane. Its values are merged with the values of the ControlPlane, the ControlPlane
+	// takes precedence. Crossplane providers of the profile are added unless the ControlPlane configures
+	// a provider with the same name.
+	ComponentsConfig `json:",inline"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+
+// ControlPlaneProfile holds defaults for ControlPlanes, so they can be changed in one place.
+type ControlPlaneProfile struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ControlPlaneProfileSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ControlPlaneProfileList contains a list of ControlPlaneProfile
+type ControlPlaneProfileList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ControlPlaneProfile `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(func(s *runtime.Scheme) error {
+		s.AddKnownTypes(GroupVersion, &ControlPlaneProfile{}, &ControlPlaneProfileList{})
+		return nil
+	})
+}
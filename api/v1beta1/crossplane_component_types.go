@@ -9,7 +9,14 @@ import (
 // CrossplaneConfig configures the Crossplane component.
 type CrossplaneConfig struct {
 	// The Version of Crossplane to install.
-	Version string `json:"version"`
+	// May be a version constraint, e.g. `~1.16` or `latest`. Defaults to the version of the ControlPlaneProfile,
+	// the component is disabled if no version is set.
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+
+	// Policy for automatic upgrades within the maintenance windows of the ControlPlane. Defaults to manual.
+	// +kubebuilder:validation:Optional
+	UpgradePolicy UpgradePolicy `json:"upgradePolicy,omitempty"`
 
 	// Optional custom Helm chart configuration.
 	Chart *ChartSpec `json:"chart,omitempty"`
@@ -18,9 +25,50 @@ type CrossplaneConfig struct {
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Values *apiextensionsv1.JSON `json:"values,omitempty"`
 
+	// References to ConfigMaps and Secrets with values, merged in the given order. The inline values take precedence.
+	// +kubebuilder:validation:Optional
+	ValuesFrom []ValuesReference `json:"valuesFrom,omitempty"`
+
 	// List of Crossplane providers to be installed.
 	// +kubebuilder:validation:Optional
 	Providers []*CrossplaneProviderConfig `json:"providers,omitempty"`
+
+	// List of Crossplane configurations to be installed.
+	// +kubebuilder:validation:Optional
+	Configurations []*CrossplanePackageConfig `json:"configurations,omitempty"`
+
+	// List of Crossplane composition functions to be installed.
+	// +kubebuilder:validation:Optional
+	Functions []*CrossplanePackageConfig `json:"functions,omitempty"`
+
+	// ImageConfigs which rewrite the registry of package images or attach pull secrets to them by prefix.
+	// They also apply to the dependencies of the packages.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	ImageConfigs []CrossplaneImageConfig `json:"imageConfigs,omitempty"`
+}
+
+// CrossplaneImageConfig describes an ImageConfig for the package images that match one of its prefixes.
+// +kubebuilder:validation:XValidation:rule="has(self.rewritePrefix) || has(self.pullSecretRef)",message="Either 'rewritePrefix' or 'pullSecretRef' must be set."
+type CrossplaneImageConfig struct {
+	// Name of the ImageConfig.
+	Name string `json:"name"`
+
+	// Prefixes of the package images, e.g. `xpkg.upbound.io/crossplane-contrib`.
+	// The longest matching prefix of all ImageConfigs takes precedence.
+	// +kubebuilder:validation:MinItems=1
+	MatchPrefixes []string `json:"matchPrefixes"`
+
+	// Prefix which replaces the matched prefix, e.g. the path of a registry mirror.
+	// +kubebuilder:validation:Optional
+	RewritePrefix string `json:"rewritePrefix,omitempty"`
+
+	// Secret in the namespace of the ControlPlane with the credentials of the registry.
+	// It is copied into the crossplane-system namespace of the target cluster
+	// and is not added to the PackagePullSecrets of every package.
+	// +kubebuilder:validation:Optional
+	PullSecretRef *corev1.LocalObjectReference `json:"pullSecretRef,omitempty"`
 }
 
 // CrossplaneProviderConfig represents configuration for Crossplane providers in a ControlPlane.
@@ -31,8 +79,13 @@ type CrossplaneProviderConfig struct {
 	Name string `json:"name"`
 
 	// Version of the provider to install.
+	// May be a version constraint, e.g. `~1.16` or `latest`.
 	Version string `json:"version"`
 
+	// Policy for automatic upgrades within the maintenance windows of the ControlPlane. Defaults to manual.
+	// +kubebuilder:validation:Optional
+	UpgradePolicy UpgradePolicy `json:"upgradePolicy,omitempty"`
+
 	// Provider package to be installed.
 	// If "name" is set to a well-known value, this field will be configured automatically.
 	// +kubebuilder:validation:Optional
@@ -47,5 +100,113 @@ type CrossplaneProviderConfig struct {
 	// PackagePullSecrets are named secrets in the same namespace that can be used to fetch packages from private registries.
 	PackagePullSecrets []corev1.LocalObjectReference `json:"packagePullSecrets,omitempty"`
 
+	// Customization of the DeploymentRuntimeConfig of the provider.
+	// +kubebuilder:validation:Optional
+	RuntimeConfig *CrossplaneProviderRuntimeConfig `json:"runtimeConfig,omitempty"`
+
+	// ProviderConfigs which are created once the provider is healthy.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	ProviderConfigs []CrossplaneProviderConfigTemplate `json:"providerConfigs,omitempty"`
+
 	crossplanev1.PackageRuntimeSpec `json:",inline"`
 }
+
+// CrossplaneProviderConfigTemplate describes a ProviderConfig of a Crossplane provider and its credentials.
+type CrossplaneProviderConfigTemplate struct {
+	// Name of the ProviderConfig, e.g. `default`.
+	Name string `json:"name"`
+
+	// API version of the ProviderConfig, e.g. `aws.upbound.io/v1beta1`.
+	APIVersion string `json:"apiVersion"`
+
+	// Kind of the ProviderConfig.
+	// +kubebuilder:default=ProviderConfig
+	// +kubebuilder:validation:Optional
+	Kind string `json:"kind,omitempty"`
+
+	// Secret in the namespace of the ControlPlane with the credentials of the ProviderConfig.
+	// It is copied into the crossplane-system namespace of the target cluster.
+	SecretRef ProviderConfigSecretReference `json:"secretRef"`
+
+	// Spec of the ProviderConfig.
+	// Unless set, `credentials` refer to the key of the copied Secret (`source: Secret`).
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	Spec *apiextensionsv1.JSON `json:"spec,omitempty"`
+}
+
+// ProviderConfigSecretReference references a key of a Secret with the credentials of a ProviderConfig.
+type ProviderConfigSecretReference struct {
+	// Name of the Secret.
+	Name string `json:"name"`
+
+	// Key of the credentials in the Secret.
+	Key string `json:"key"`
+}
+
+// CrossplaneProviderRuntimeConfig customizes the deployment of a Crossplane provider.
+// The operator owns all of its fields in the DeploymentRuntimeConfig of the provider, fields that are not set
+// are reset to the defaults of Crossplane. Only the args are kept if they have never been set.
+type CrossplaneProviderRuntimeConfig struct {
+	// Number of replicas of the provider.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	Replicas *int32 `json:"replicas,omitempty"`
+
+	// Compute resources of the provider container.
+	// +kubebuilder:validation:Optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// Arguments of the provider container, e.g. `--poll=5m`.
+	// +kubebuilder:validation:Optional
+	Args []string `json:"args,omitempty"`
+
+	// Environment variables of the provider container, e.g. from Secrets in the crossplane-system namespace.
+	// +kubebuilder:validation:Optional
+	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// Node selector of the provider pods.
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations of the provider pods.
+	// +kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Annotations of the service account of the provider,
+	// e.g. `eks.amazonaws.com/role-arn` or `iam.gke.io/gcp-service-account` for workload identity.
+	// +kubebuilder:validation:Optional
+	ServiceAccountAnnotations map[string]string `json:"serviceAccountAnnotations,omitempty"`
+}
+
+// CrossplanePackageConfig represents configuration for Crossplane configurations and functions in a ControlPlane.
+// Primarily based on the Crossplane open source API.
+type CrossplanePackageConfig struct {
+	// Name of the package.
+	// Using a well-known name will automatically configure the "package" field.
+	Name string `json:"name"`
+
+	// Version of the package to install.
+	// May be a version constraint, e.g. `~1.16` or `latest`.
+	Version string `json:"version"`
+
+	// Policy for automatic upgrades within the maintenance windows of the ControlPlane. Defaults to manual.
+	// +kubebuilder:validation:Optional
+	UpgradePolicy UpgradePolicy `json:"upgradePolicy,omitempty"`
+
+	// Package to be installed.
+	// If "name" is set to a well-known value, this field will be configured automatically.
+	// +kubebuilder:validation:Optional
+	Package string `json:"package,omitempty"`
+
+	// Pull policy for the package.
+	// One of Always, Never, IfNotPresent.
+	// +kubebuilder:default=IfNotPresent
+	// +kubebuilder:validation:Enum=Always;Never;IfNotPresent
+	PackagePullPolicy *corev1.PullPolicy `json:"packagePullPolicy,omitempty"`
+
+	// PackagePullSecrets are named secrets in the same namespace that can be used to fetch packages from private registries.
+	PackagePullSecrets []corev1.LocalObjectReference `json:"packagePullSecrets,omitempty"`
+}
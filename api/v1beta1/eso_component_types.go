@@ -5,7 +5,14 @@ import apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1
 // ExternalSecretsOperatorConfig configures the ExternalSecrets Operator component.
 type ExternalSecretsOperatorConfig struct {
 	// The Version of External Secrets Operator to install.
-	Version string `json:"version"`
+	// May be a version constraint, e.g. `~1.16` or `latest`. Defaults to the version of the ControlPlaneProfile,
+	// the component is disabled if no version is set.
+	// +kubebuilder:validation:Optional
+	Version string `json:"version,omitempty"`
+
+	// Policy for automatic upgrades within the maintenance windows of the ControlPlane. Defaults to manual.
+	// +kubebuilder:validation:Optional
+	UpgradePolicy UpgradePolicy `json:"upgradePolicy,omitempty"`
 
 	// Optional custom chart configuration.
 	Chart *ChartSpec `json:"chart,omitempty"`
@@ -13,4 +20,8 @@ type ExternalSecretsOperatorConfig struct {
 	// Optional additional values that should be passed to the External Secrets Operator Helm chart.
 	// +kubebuilder:pruning:PreserveUnknownFields
 	Values *apiextensionsv1.JSON `json:"values,omitempty"`
+
+	// References to ConfigMaps and Secrets with values, merged in the given order. The inline values take precedence.
+	// +kubebuilder:validation:Optional
+	ValuesFrom []ValuesReference `json:"valuesFrom,omitempty"`
 }
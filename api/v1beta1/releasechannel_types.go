@@ -75,7 +75,8 @@ type ComponentVersion struct {
 	HelmRepo string `json:"helmRepo,omitempty"`
 	// if it's a helm chart, this specifies the chart name
 	HelmChart string `json:"helmChart,omitempty"`
-	// if the Helm chart is stored in an OCI registry, this specifies the OCI URL
+	// if the Helm chart is stored in an OCI registry, this specifies the OCI URL of the chart without tag,
+	// e.g. `oci://ghcr.io/org/charts/crossplane`
 	OCIURL string `json:"ociUrl,omitempty"`
 }
 
@@ -6,11 +6,82 @@ package v1beta1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	v1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalComponentConfig) DeepCopyInto(out *AdditionalComponentConfig) {
+	*out = *in
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(AdditionalComponentSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]ValuesReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PolicyRules != nil {
+		in, out := &in.PolicyRules, &out.PolicyRules
+		*out = new(ComponentPolicyRules)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalComponentConfig.
+func (in *AdditionalComponentConfig) DeepCopy() *AdditionalComponentConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalComponentConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalComponentSource) DeepCopyInto(out *AdditionalComponentSource) {
+	*out = *in
+	if in.Chart != nil {
+		in, out := &in.Chart, &out.Chart
+		*out = new(ChartSpec)
+		**out = **in
+	}
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(ManifestsSource)
+		**out = **in
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(ManifestsSource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalComponentSource.
+func (in *AdditionalComponentSource) DeepCopy() *AdditionalComponentSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalComponentSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BTPServiceOperatorConfig) DeepCopyInto(out *BTPServiceOperatorConfig) {
 	*out = *in
@@ -24,6 +95,11 @@ func (in *BTPServiceOperatorConfig) DeepCopyInto(out *BTPServiceOperatorConfig)
 		*out = new(v1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]ValuesReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BTPServiceOperatorConfig.
@@ -49,6 +125,11 @@ func (in *CertManagerConfig) DeepCopyInto(out *CertManagerConfig) {
 		*out = new(v1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]ValuesReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertManagerConfig.
@@ -96,6 +177,280 @@ func (in *Component) DeepCopy() *Component {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentDefinition) DeepCopyInto(out *ComponentDefinition) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentDefinition.
+func (in *ComponentDefinition) DeepCopy() *ComponentDefinition {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentDefinition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComponentDefinition) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentDefinitionList) DeepCopyInto(out *ComponentDefinitionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ComponentDefinition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentDefinitionList.
+func (in *ComponentDefinitionList) DeepCopy() *ComponentDefinitionList {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentDefinitionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComponentDefinitionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentDefinitionSpec) DeepCopyInto(out *ComponentDefinitionSpec) {
+	*out = *in
+	if in.Chart != nil {
+		in, out := &in.Chart, &out.Chart
+		*out = new(ChartSpec)
+		**out = **in
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PolicyRules != nil {
+		in, out := &in.PolicyRules, &out.PolicyRules
+		*out = new(ComponentPolicyRules)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProtectedResources != nil {
+		in, out := &in.ProtectedResources, &out.ProtectedResources
+		*out = make([]metav1.GroupVersionKind, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentDefinitionSpec.
+func (in *ComponentDefinitionSpec) DeepCopy() *ComponentDefinitionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentDefinitionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentPlan) DeepCopyInto(out *ComponentPlan) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentPlan.
+func (in *ComponentPlan) DeepCopy() *ComponentPlan {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentPlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentPolicyRules) DeepCopyInto(out *ComponentPolicyRules) {
+	*out = *in
+	if in.Admin != nil {
+		in, out := &in.Admin, &out.Admin
+		*out = make([]rbacv1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.View != nil {
+		in, out := &in.View, &out.View
+		*out = make([]rbacv1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentPolicyRules.
+func (in *ComponentPolicyRules) DeepCopy() *ComponentPolicyRules {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentPolicyRules)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentRetryStatus) DeepCopyInto(out *ComponentRetryStatus) {
+	*out = *in
+	in.NextRetryAt.DeepCopyInto(&out.NextRetryAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentRetryStatus.
+func (in *ComponentRetryStatus) DeepCopy() *ComponentRetryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentRetryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentRollout) DeepCopyInto(out *ComponentRollout) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentRollout.
+func (in *ComponentRollout) DeepCopy() *ComponentRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComponentRollout) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentRolloutList) DeepCopyInto(out *ComponentRolloutList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ComponentRollout, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentRolloutList.
+func (in *ComponentRolloutList) DeepCopy() *ComponentRolloutList {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentRolloutList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ComponentRolloutList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentRolloutSpec) DeepCopyInto(out *ComponentRolloutSpec) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	out.ProgressDeadline = in.ProgressDeadline
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentRolloutSpec.
+func (in *ComponentRolloutSpec) DeepCopy() *ComponentRolloutSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentRolloutSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentRolloutStatus) DeepCopyInto(out *ComponentRolloutStatus) {
+	*out = *in
+	if in.CurrentWaveStartTime != nil {
+		in, out := &in.CurrentWaveStartTime, &out.CurrentWaveStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]RolloutTarget, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentRolloutStatus.
+func (in *ComponentRolloutStatus) DeepCopy() *ComponentRolloutStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentRolloutStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ComponentStatus) DeepCopyInto(out *ComponentStatus) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentStatus.
+func (in *ComponentStatus) DeepCopy() *ComponentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ComponentVersion) DeepCopyInto(out *ComponentVersion) {
 	*out = *in
@@ -144,39 +499,104 @@ func (in *ComponentsConfig) DeepCopyInto(out *ComponentsConfig) {
 		*out = new(FluxConfig)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AdditionalComponents != nil {
+		in, out := &in.AdditionalComponents, &out.AdditionalComponents
+		*out = make([]AdditionalComponentConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentsConfig.
+func (in *ComponentsConfig) DeepCopy() *ComponentsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ComponentsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlane) DeepCopyInto(out *ControlPlane) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlane.
+func (in *ControlPlane) DeepCopy() *ControlPlane {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlane)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ControlPlane) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ComponentsConfig.
-func (in *ComponentsConfig) DeepCopy() *ComponentsConfig {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneList) DeepCopyInto(out *ControlPlaneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ControlPlane, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneList.
+func (in *ControlPlaneList) DeepCopy() *ControlPlaneList {
 	if in == nil {
 		return nil
 	}
-	out := new(ComponentsConfig)
+	out := new(ControlPlaneList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ControlPlaneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlane) DeepCopyInto(out *ControlPlane) {
+func (in *ControlPlaneProfile) DeepCopyInto(out *ControlPlaneProfile) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlane.
-func (in *ControlPlane) DeepCopy() *ControlPlane {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneProfile.
+func (in *ControlPlaneProfile) DeepCopy() *ControlPlaneProfile {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlane)
+	out := new(ControlPlaneProfile)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ControlPlane) DeepCopyObject() runtime.Object {
+func (in *ControlPlaneProfile) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -184,37 +604,63 @@ func (in *ControlPlane) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ControlPlaneList) DeepCopyInto(out *ControlPlaneList) {
+func (in *ControlPlaneProfileList) DeepCopyInto(out *ControlPlaneProfileList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]ControlPlane, len(*in))
+		*out = make([]ControlPlaneProfile, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneList.
-func (in *ControlPlaneList) DeepCopy() *ControlPlaneList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneProfileList.
+func (in *ControlPlaneProfileList) DeepCopy() *ControlPlaneProfileList {
 	if in == nil {
 		return nil
 	}
-	out := new(ControlPlaneList)
+	out := new(ControlPlaneProfileList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ControlPlaneList) DeepCopyObject() runtime.Object {
+func (in *ControlPlaneProfileList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ControlPlaneProfileSpec) DeepCopyInto(out *ControlPlaneProfileSpec) {
+	*out = *in
+	if in.PullSecrets != nil {
+		in, out := &in.PullSecrets, &out.PullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeploymentRuntimeConfigProtection != nil {
+		in, out := &in.DeploymentRuntimeConfigProtection, &out.DeploymentRuntimeConfigProtection
+		*out = new(bool)
+		**out = **in
+	}
+	in.ComponentsConfig.DeepCopyInto(&out.ComponentsConfig)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneProfileSpec.
+func (in *ControlPlaneProfileSpec) DeepCopy() *ControlPlaneProfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ControlPlaneProfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ControlPlaneSpec) DeepCopyInto(out *ControlPlaneSpec) {
 	*out = *in
@@ -223,13 +669,18 @@ func (in *ControlPlaneSpec) DeepCopyInto(out *ControlPlaneSpec) {
 	if in.Telemetry != nil {
 		in, out := &in.Telemetry, &out.Telemetry
 		*out = new(TelemetryConfig)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.PullSecrets != nil {
 		in, out := &in.PullSecrets, &out.PullSecrets
 		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.MaintenanceWindows != nil {
+		in, out := &in.MaintenanceWindows, &out.MaintenanceWindows
+		*out = make([]MaintenanceWindow, len(*in))
+		copy(*out, *in)
+	}
 	in.ComponentsConfig.DeepCopyInto(&out.ComponentsConfig)
 }
 
@@ -253,6 +704,25 @@ func (in *ControlPlaneStatus) DeepCopyInto(out *ControlPlaneStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Components != nil {
+		in, out := &in.Components, &out.Components
+		*out = make([]ComponentStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = make([]ComponentRetryStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Plan != nil {
+		in, out := &in.Plan, &out.Plan
+		*out = make([]ComponentPlan, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControlPlaneStatus.
@@ -278,6 +748,11 @@ func (in *CrossplaneConfig) DeepCopyInto(out *CrossplaneConfig) {
 		*out = new(v1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]ValuesReference, len(*in))
+		copy(*out, *in)
+	}
 	if in.Providers != nil {
 		in, out := &in.Providers, &out.Providers
 		*out = make([]*CrossplaneProviderConfig, len(*in))
@@ -289,6 +764,35 @@ func (in *CrossplaneConfig) DeepCopyInto(out *CrossplaneConfig) {
 			}
 		}
 	}
+	if in.Configurations != nil {
+		in, out := &in.Configurations, &out.Configurations
+		*out = make([]*CrossplanePackageConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(CrossplanePackageConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Functions != nil {
+		in, out := &in.Functions, &out.Functions
+		*out = make([]*CrossplanePackageConfig, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(CrossplanePackageConfig)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ImageConfigs != nil {
+		in, out := &in.ImageConfigs, &out.ImageConfigs
+		*out = make([]CrossplaneImageConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossplaneConfig.
@@ -301,6 +805,56 @@ func (in *CrossplaneConfig) DeepCopy() *CrossplaneConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrossplaneImageConfig) DeepCopyInto(out *CrossplaneImageConfig) {
+	*out = *in
+	if in.MatchPrefixes != nil {
+		in, out := &in.MatchPrefixes, &out.MatchPrefixes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PullSecretRef != nil {
+		in, out := &in.PullSecretRef, &out.PullSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossplaneImageConfig.
+func (in *CrossplaneImageConfig) DeepCopy() *CrossplaneImageConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CrossplaneImageConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrossplanePackageConfig) DeepCopyInto(out *CrossplanePackageConfig) {
+	*out = *in
+	if in.PackagePullPolicy != nil {
+		in, out := &in.PackagePullPolicy, &out.PackagePullPolicy
+		*out = new(corev1.PullPolicy)
+		**out = **in
+	}
+	if in.PackagePullSecrets != nil {
+		in, out := &in.PackagePullSecrets, &out.PackagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossplanePackageConfig.
+func (in *CrossplanePackageConfig) DeepCopy() *CrossplanePackageConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CrossplanePackageConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CrossplanePackageRestriction) DeepCopyInto(out *CrossplanePackageRestriction) {
 	*out = *in
@@ -406,6 +960,18 @@ func (in *CrossplaneProviderConfig) DeepCopyInto(out *CrossplaneProviderConfig)
 		*out = make([]corev1.LocalObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.RuntimeConfig != nil {
+		in, out := &in.RuntimeConfig, &out.RuntimeConfig
+		*out = new(CrossplaneProviderRuntimeConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProviderConfigs != nil {
+		in, out := &in.ProviderConfigs, &out.ProviderConfigs
+		*out = make([]CrossplaneProviderConfigTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	in.PackageRuntimeSpec.DeepCopyInto(&out.PackageRuntimeSpec)
 }
 
@@ -419,6 +985,85 @@ func (in *CrossplaneProviderConfig) DeepCopy() *CrossplaneProviderConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrossplaneProviderConfigTemplate) DeepCopyInto(out *CrossplaneProviderConfigTemplate) {
+	*out = *in
+	out.SecretRef = in.SecretRef
+	if in.Spec != nil {
+		in, out := &in.Spec, &out.Spec
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossplaneProviderConfigTemplate.
+func (in *CrossplaneProviderConfigTemplate) DeepCopy() *CrossplaneProviderConfigTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(CrossplaneProviderConfigTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CrossplaneProviderRuntimeConfig) DeepCopyInto(out *CrossplaneProviderRuntimeConfig) {
+	*out = *in
+	if in.Replicas != nil {
+		in, out := &in.Replicas, &out.Replicas
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServiceAccountAnnotations != nil {
+		in, out := &in.ServiceAccountAnnotations, &out.ServiceAccountAnnotations
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CrossplaneProviderRuntimeConfig.
+func (in *CrossplaneProviderRuntimeConfig) DeepCopy() *CrossplaneProviderRuntimeConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CrossplaneProviderRuntimeConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExternalSecretsOperatorConfig) DeepCopyInto(out *ExternalSecretsOperatorConfig) {
 	*out = *in
@@ -432,6 +1077,11 @@ func (in *ExternalSecretsOperatorConfig) DeepCopyInto(out *ExternalSecretsOperat
 		*out = new(v1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]ValuesReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExternalSecretsOperatorConfig.
@@ -457,6 +1107,11 @@ func (in *FluxConfig) DeepCopyInto(out *FluxConfig) {
 		*out = new(v1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]ValuesReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FluxConfig.
@@ -497,6 +1152,11 @@ func (in *KyvernoConfig) DeepCopyInto(out *KyvernoConfig) {
 		*out = new(v1.JSON)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]ValuesReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KyvernoConfig.
@@ -509,6 +1169,36 @@ func (in *KyvernoConfig) DeepCopy() *KyvernoConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MaintenanceWindow) DeepCopyInto(out *MaintenanceWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceWindow.
+func (in *MaintenanceWindow) DeepCopy() *MaintenanceWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MaintenanceWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ManifestsSource) DeepCopyInto(out *ManifestsSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManifestsSource.
+func (in *ManifestsSource) DeepCopy() *ManifestsSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ManifestsSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PackageRestriction) DeepCopyInto(out *PackageRestriction) {
 	*out = *in
@@ -534,6 +1224,21 @@ func (in *PackageRestriction) DeepCopy() *PackageRestriction {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderConfigSecretReference) DeepCopyInto(out *ProviderConfigSecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigSecretReference.
+func (in *ProviderConfigSecretReference) DeepCopy() *ProviderConfigSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderConfigSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ReleaseChannel) DeepCopyInto(out *ReleaseChannel) {
 	*out = *in
@@ -633,6 +1338,21 @@ func (in *ReleaseChannelStatus) DeepCopy() *ReleaseChannelStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolloutTarget) DeepCopyInto(out *RolloutTarget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolloutTarget.
+func (in *RolloutTarget) DeepCopy() *RolloutTarget {
+	if in == nil {
+		return nil
+	}
+	out := new(RolloutTarget)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceAccountReference) DeepCopyInto(out *ServiceAccountReference) {
 	*out = *in
@@ -669,6 +1389,21 @@ func (in *Target) DeepCopy() *Target {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TelemetryConfig) DeepCopyInto(out *TelemetryConfig) {
 	*out = *in
+	if in.Chart != nil {
+		in, out := &in.Chart, &out.Chart
+		*out = new(ChartSpec)
+		**out = **in
+	}
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = new(v1.JSON)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = make([]ValuesReference, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TelemetryConfig.
@@ -680,3 +1415,18 @@ func (in *TelemetryConfig) DeepCopy() *TelemetryConfig {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ValuesReference) DeepCopyInto(out *ValuesReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ValuesReference.
+func (in *ValuesReference) DeepCopy() *ValuesReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ValuesReference)
+	in.DeepCopyInto(out)
+	return out
+}
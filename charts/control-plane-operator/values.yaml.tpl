@@ -121,6 +121,11 @@ rbac:
           - crossplanepackagerestrictions
           - crossplanepackagerestrictions/status
           - crossplanepackagerestrictions/finalizers
+          - componentrollouts
+          - componentrollouts/status
+          - componentrollouts/finalizers
+          - controlplaneprofiles
+          - componentdefinitions
         verbs:
           - "*"
       - apiGroups:
@@ -128,6 +133,7 @@ rbac:
         resources:
           - namespaces
           - secrets
+          - configmaps
           - serviceaccounts # local deployment
           - events
         verbs:
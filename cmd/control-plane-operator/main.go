@@ -21,6 +21,7 @@ import (
 	"embed"
 	"flag"
 	"os"
+	"strings"
 	"time"
 
 	"k8s.io/apimachinery/pkg/types"
@@ -38,6 +39,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/openmcp-project/controller-utils/pkg/init/crds"
 	"github.com/openmcp-project/controller-utils/pkg/init/webhooks"
@@ -45,6 +47,8 @@ import (
 	corev1beta1 "github.com/openmcp-project/control-plane-operator/api/v1beta1"
 	"github.com/openmcp-project/control-plane-operator/internal/controller"
 	"github.com/openmcp-project/control-plane-operator/internal/schemes"
+	"github.com/openmcp-project/control-plane-operator/internal/tracing"
+	webhookv1beta1 "github.com/openmcp-project/control-plane-operator/internal/webhook/v1beta1"
 	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/kubeconfiggen"
 	// +kubebuilder:scaffold:imports
 )
@@ -75,7 +79,7 @@ func runInit(setupClient client.Client) {
 			setupClient,
 			schemes.Local,
 			[]webhooks.APITypes{
-				{Obj: &corev1beta1.ControlPlane{}, Validator: false, Defaulter: false},
+				{Obj: &corev1beta1.ControlPlane{}, Validator: true, Defaulter: false},
 			},
 		)
 		if err != nil {
@@ -111,6 +115,26 @@ func main() {
 	flag.StringVar(&syncPeriod, "sync-period", "1m", "The period at which the controller will sync the resources.")
 	var fluxTokenLifetimeStr string
 	flag.StringVar(&fluxTokenLifetimeStr, "flux-token-lifetime", "1h", "The desired lifetime of the flux service account token used to access the MCP.")
+	var maxConcurrentComponentReconciles int
+	flag.IntVar(&maxConcurrentComponentReconciles, "max-concurrent-component-reconciles", 5,
+		"The maximum number of independent components of a single ControlPlane that are reconciled concurrently.")
+	var componentRetryBaseDelayStr string
+	flag.StringVar(&componentRetryBaseDelayStr, "component-retry-base-delay", "1m",
+		"The delay before a failed component is retried. It doubles with every consecutive failure. Set to 0 to disable the backoff.")
+	var componentRetryMaxDelayStr string
+	flag.StringVar(&componentRetryMaxDelayStr, "component-retry-max-delay", "30m", "The maximum delay before a failed component is retried.")
+	var otlpEndpoint string
+	flag.StringVar(&otlpEndpoint, "otlp-endpoint", "",
+		"The OTLP/gRPC endpoint (host:port) traces are exported to. Traces are only recorded for ControlPlanes with enabled telemetry. Tracing is disabled if empty.")
+	var otlpInsecure bool
+	flag.BoolVar(&otlpInsecure, "otlp-insecure", false, "Disable TLS for the connection to the OTLP endpoint.")
+	var telemetryExporterEndpoint string
+	flag.StringVar(&telemetryExporterEndpoint, "telemetry-exporter-endpoint", "",
+		"The OTLP/HTTP endpoint the telemetry collectors of ControlPlanes export metrics to, unless the ControlPlane configures one.")
+	var valuesFromNamespacesStr string
+	flag.StringVar(&valuesFromNamespacesStr, "values-from-namespaces", "",
+		"Comma-separated list of namespaces ConfigMaps and Secrets may be referenced from in the valuesFrom of components. "+
+			"valuesFrom references are rejected if empty.")
 
 	// component flags
 	var webhookMiddlewareName string
@@ -144,6 +168,17 @@ func main() {
 		return
 	}
 
+	shutdownTracing, err := tracing.Setup(setupContext, otlpEndpoint, otlpInsecure)
+	if err != nil {
+		setupLog.Error(err, "unable to set up tracing")
+		os.Exit(1)
+	}
+	defer func() {
+		if err := shutdownTracing(setupContext); err != nil {
+			setupLog.Error(err, "failed to shut down tracing")
+		}
+	}()
+
 	fluxSecretResolver := secretresolver.NewFluxSecretResolver(setupClient)
 	err = fluxSecretResolver.Start(setupContext)
 	if err != nil {
@@ -155,8 +190,12 @@ func main() {
 		Scheme:                 schemes.Local,
 		Metrics:                metricsserver.Options{BindAddress: metricsAddr},
 		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "c627d721.core.orchestrate.cloud.sap",
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Host: webhooksFlags.BindHost,
+			Port: webhooksFlags.BindPort,
+		}),
+		LeaderElection:   enableLeaderElection,
+		LeaderElectionID: "c627d721.core.orchestrate.cloud.sap",
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -186,6 +225,21 @@ func main() {
 	}
 	setupLog.Info("flux token lifetime set to", "fluxTokenLifetime", fluxTokenLifetime)
 
+	componentRetryBaseDelay, errComponentRetryBaseDelay := time.ParseDuration(componentRetryBaseDelayStr)
+	if errComponentRetryBaseDelay != nil {
+		componentRetryBaseDelay = 1 * time.Minute
+	}
+	componentRetryMaxDelay, errComponentRetryMaxDelay := time.ParseDuration(componentRetryMaxDelayStr)
+	if errComponentRetryMaxDelay != nil {
+		componentRetryMaxDelay = 30 * time.Minute
+	}
+	setupLog.Info("component retry backoff set to", "baseDelay", componentRetryBaseDelay, "maxDelay", componentRetryMaxDelay)
+
+	var valuesFromNamespaces []string
+	if valuesFromNamespacesStr != "" {
+		valuesFromNamespaces = strings.Split(valuesFromNamespacesStr, ",")
+	}
+
 	if err = (&controller.ControlPlaneReconciler{
 		Client:             mgr.GetClient(),
 		Scheme:             mgr.GetScheme(),
@@ -196,10 +250,15 @@ func main() {
 			Namespace: webhookMiddlewareNamespace,
 			Name:      webhookMiddlewareName,
 		},
-		ReconcilePeriod:     reconcilePeriod,
-		RemoteConfigBuilder: controller.NewRemoteConfigBuilder(),
-		Recorder:            mgr.GetEventRecorder("controlplane-controller"),
-		EmbeddedCRDs:        crdFiles,
+		ReconcilePeriod:                  reconcilePeriod,
+		RemoteConfigBuilder:              controller.NewRemoteConfigBuilder(),
+		Recorder:                         mgr.GetEventRecorder("controlplane-controller"),
+		EmbeddedCRDs:                     crdFiles,
+		MaxConcurrentComponentReconciles: maxConcurrentComponentReconciles,
+		ComponentRetryBaseDelay:          componentRetryBaseDelay,
+		ComponentRetryMaxDelay:           componentRetryMaxDelay,
+		TelemetryExporterEndpoint:        telemetryExporterEndpoint,
+		ValuesFromNamespaces:             valuesFromNamespaces,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ControlPlane")
 		os.Exit(1)
@@ -212,6 +271,21 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "Secret")
 		os.Exit(1)
 	}
+	if err = (&controller.ComponentRolloutReconciler{
+		Client:          mgr.GetClient(),
+		Scheme:          mgr.GetScheme(),
+		Recorder:        mgr.GetEventRecorder("componentrollout-controller"),
+		ReconcilePeriod: reconcilePeriod,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ComponentRollout")
+		os.Exit(1)
+	}
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = webhookv1beta1.SetupControlPlaneWebhookWithManager(mgr, valuesFromNamespaces, telemetryExporterEndpoint); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ControlPlane")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
 	if err = (&controller.ReleaseChannelReconciler{
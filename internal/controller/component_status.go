@@ -0,0 +1,51 @@
+package controller
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1beta1 "github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+)
+
+// componentStatus converts the results of a reconciliation to be stored in the status of the ControlPlane.
+// Components that are disabled and not installed are omitted. The LastTransitionTime of a component is
+// only updated if its phase differs from the `previous` status. The previous resolved version is kept
+// if the version of a component could not be resolved.
+func componentStatus(previous []corev1beta1.ComponentStatus, results []juggler.ComponentResult, now metav1.Time) []corev1beta1.ComponentStatus {
+	transitions := make(map[string]corev1beta1.ComponentStatus, len(previous))
+	for _, cs := range previous {
+		transitions[cs.Name] = cs
+	}
+
+	var statuses []corev1beta1.ComponentStatus
+	for _, cr := range results {
+		if !cr.Component.IsEnabled() && cr.Result == juggler.StatusDisabled {
+			continue
+		}
+
+		cs := corev1beta1.ComponentStatus{
+			Name:               cr.Component.GetName(),
+			Kind:               cr.Kind(),
+			DesiredVersion:     cr.DesiredVersion(),
+			ResolvedVersion:    cr.ResolvedVersion,
+			ObservedVersion:    cr.ObservedVersion,
+			Phase:              cr.Result.Name,
+			LastTransitionTime: now,
+			Message:            cr.Message,
+			Visibility:         corev1beta1.ComponentVisibilityExternal,
+		}
+		if cr.IsInternal() {
+			cs.Visibility = corev1beta1.ComponentVisibilityInternal
+		}
+		prev, ok := transitions[cs.Name]
+		if ok && prev.Phase == cs.Phase {
+			cs.LastTransitionTime = prev.LastTransitionTime
+		}
+		// keep the last resolved version, e.g. while the component is backing off after failures
+		if ok && cs.ResolvedVersion == "" && prev.DesiredVersion == cs.DesiredVersion && cr.Component.IsEnabled() {
+			cs.ResolvedVersion = prev.ResolvedVersion
+		}
+		statuses = append(statuses, cs)
+	}
+	return statuses
+}
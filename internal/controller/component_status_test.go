@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1beta1 "github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/components"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+)
+
+func Test_componentStatus(t *testing.T) {
+	before := metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	now := metav1.NewTime(before.Add(time.Hour))
+
+	previous := []corev1beta1.ComponentStatus{
+		{Name: components.ComponentNameCrossplane, Phase: juggler.StatusHealthy.Name, LastTransitionTime: before},
+		{Name: components.ComponentNameCertManager, DesiredVersion: "1.16.1", ResolvedVersion: "1.16.1", Phase: juggler.StatusInstalled.Name, LastTransitionTime: before},
+	}
+	results := []juggler.ComponentResult{
+		{
+			Component:       &components.Crossplane{Config: &corev1beta1.CrossplaneConfig{Version: "~1.19"}},
+			Result:          juggler.StatusHealthy,
+			Message:         "Crossplane is healthy.",
+			ObservedVersion: "1.19.0",
+			ResolvedVersion: "1.19.0",
+		},
+		{
+			Component: &components.CertManager{Config: &corev1beta1.CertManagerConfig{Version: "1.16.1"}},
+			Result:    juggler.StatusUnhealthy,
+			Message:   "CertManager is unhealthy.",
+		},
+		{Component: &components.Kyverno{}, Result: juggler.StatusDisabled},
+		{Component: &components.ClusterRole{Enabled: true, Name: "admin"}, Result: juggler.StatusHealthy},
+	}
+
+	assert.Equal(t, []corev1beta1.ComponentStatus{
+		{
+			Name:               components.ComponentNameCrossplane,
+			Kind:               "Crossplane",
+			DesiredVersion:     "~1.19",
+			ResolvedVersion:    "1.19.0",
+			ObservedVersion:    "1.19.0",
+			Phase:              juggler.StatusHealthy.Name,
+			LastTransitionTime: before,
+			Message:            "Crossplane is healthy.",
+			Visibility:         corev1beta1.ComponentVisibilityExternal,
+		},
+		{
+			Name:               components.ComponentNameCertManager,
+			Kind:               "CertManager",
+			DesiredVersion:     "1.16.1",
+			ResolvedVersion:    "1.16.1",
+			Phase:              juggler.StatusUnhealthy.Name,
+			LastTransitionTime: now,
+			Message:            "CertManager is unhealthy.",
+			Visibility:         corev1beta1.ComponentVisibilityExternal,
+		},
+		{
+			Name:               (&components.ClusterRole{Name: "admin"}).GetName(),
+			Kind:               "ClusterRole",
+			Phase:              juggler.StatusHealthy.Name,
+			LastTransitionTime: now,
+			Visibility:         corev1beta1.ComponentVisibilityInternal,
+		},
+	}, componentStatus(previous, results, now))
+}
@@ -0,0 +1,294 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	corev1beta1 "github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+)
+
+// ComponentRolloutReconciler reconciles a ComponentRollout object
+type ComponentRolloutReconciler struct {
+	client.Client
+	Scheme          *runtime.Scheme
+	Recorder        events.EventRecorder
+	ReconcilePeriod time.Duration
+}
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *ComponentRolloutReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := log.FromContext(ctx)
+
+	rollout := &corev1beta1.ComponentRollout{}
+	if err := r.Get(ctx, req.NamespacedName, rollout); err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("ComponentRollout not found")
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "unable to fetch ComponentRollout")
+		return ctrl.Result{}, err
+	}
+	if !rollout.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	cps, err := r.listTargets(ctx, rollout)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Always update status
+	defer func() {
+		rollout.Status.ObservedGeneration = rollout.Generation
+		if err := r.Status().Update(ctx, rollout); err != nil {
+			log.Error(err, "failed to update status")
+		}
+	}()
+
+	if err := r.progress(ctx, rollout, cps, time.Now()); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: r.ReconcilePeriod}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ComponentRolloutReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1beta1.ComponentRollout{}).
+		Watches(&corev1beta1.ControlPlane{}, handler.EnqueueRequestsFromMapFunc(r.rolloutsForControlPlane)).
+		Complete(r)
+}
+
+// rolloutsForControlPlane returns a request for every ComponentRollout that selects the ControlPlane.
+func (r *ComponentRolloutReconciler) rolloutsForControlPlane(ctx context.Context, o client.Object) []reconcile.Request {
+	rollouts := &corev1beta1.ComponentRolloutList{}
+	if err := r.List(ctx, rollouts); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list ComponentRollouts")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, rollout := range rollouts.Items {
+		selector, err := metav1.LabelSelectorAsSelector(&rollout.Spec.Selector)
+		if err != nil || !selector.Matches(labels.Set(o.GetLabels())) {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&rollout)})
+	}
+	return requests
+}
+
+// listTargets returns the ControlPlanes that are selected by the ComponentRollout and configure its component.
+// ControlPlanes that only get the component from their ControlPlaneProfile are not targeted, because the rollout
+// sets the version in the spec of the ControlPlanes. Such components are upgraded by changing the profile.
+func (r *ComponentRolloutReconciler) listTargets(ctx context.Context, rollout *corev1beta1.ComponentRollout) (map[string]*corev1beta1.ControlPlane, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&rollout.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	list := &corev1beta1.ControlPlaneList{}
+	if err := r.List(ctx, list, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return nil, err
+	}
+
+	cps := map[string]*corev1beta1.ControlPlane{}
+	for i := range list.Items {
+		cp := &list.Items[i]
+		if rollout.Spec.Component.ComponentVersion(cp) == nil || !cp.DeletionTimestamp.IsZero() {
+			continue
+		}
+		cps[cp.Name] = cp
+	}
+	return cps, nil
+}
+
+// progress updates the ControlPlanes of the current wave and moves on to the next wave once the component
+// is healthy on all of them. The rollout is paused if the component fails on a ControlPlane of the current
+// wave or does not become healthy within the progress deadline.
+func (r *ComponentRolloutReconciler) progress(ctx context.Context, rollout *corev1beta1.ComponentRollout, cps map[string]*corev1beta1.ControlPlane, now time.Time) error {
+	names := make([]string, 0, len(cps))
+	for name := range cps {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	targets := keepWaves(rollout.Status.Targets, names, rollout.Spec.CanaryPercentage, rollout.Spec.WaveSize)
+	currentWave := int32(-1)
+	healthy := int32(0)
+	for i := range targets {
+		t := &targets[i]
+		t.Phase, t.Message = rolloutTargetPhase(cps[t.Name], rollout.Spec.Component, rollout.Spec.Version)
+		if t.Phase == corev1beta1.RolloutTargetPhaseHealthy {
+			healthy++
+		} else if currentWave < 0 || t.Wave < currentWave {
+			currentWave = t.Wave
+		}
+	}
+
+	status := &rollout.Status
+	status.Targets = targets
+	status.Total = int32(len(targets))
+	status.Healthy = healthy
+	status.Waves = 0
+	for _, t := range targets {
+		status.Waves = max(status.Waves, t.Wave+1)
+	}
+
+	if currentWave < 0 {
+		status.Phase = corev1beta1.RolloutPhaseCompleted
+		status.CurrentWave = max(status.Waves-1, 0)
+		status.CurrentWaveStartTime = nil
+		status.Message = fmt.Sprintf("%s %s is healthy on all %d ControlPlanes", rollout.Spec.Component, rollout.Spec.Version, status.Total)
+		return nil
+	}
+
+	if rollout.Spec.Paused {
+		// the progress deadline starts again once the rollout is resumed
+		status.CurrentWaveStartTime = nil
+		if status.Phase != corev1beta1.RolloutPhasePaused {
+			status.Phase = corev1beta1.RolloutPhasePaused
+			status.Message = "Rollout has been paused"
+		}
+		return nil
+	}
+
+	if status.CurrentWave != currentWave || status.CurrentWaveStartTime == nil {
+		status.CurrentWave = currentWave
+		status.CurrentWaveStartTime = &metav1.Time{Time: now}
+		r.Recorder.Eventf(rollout, nil, corev1.EventTypeNormal, "WaveStarted", "Rollout",
+			"Rolling out %s %s to wave %d of %d", rollout.Spec.Component, rollout.Spec.Version, currentWave+1, status.Waves)
+	}
+	status.Phase = corev1beta1.RolloutPhaseProgressing
+	status.Message = fmt.Sprintf("Rolling out %s %s to wave %d of %d", rollout.Spec.Component, rollout.Spec.Version, currentWave+1, status.Waves)
+
+	for i := range targets {
+		t := &targets[i]
+		if t.Wave != currentWave {
+			continue
+		}
+		switch t.Phase {
+		case corev1beta1.RolloutTargetPhaseFailed:
+			return r.pause(ctx, rollout, fmt.Sprintf("%s failed on ControlPlane %s: %s", rollout.Spec.Component, t.Name, t.Message))
+		case corev1beta1.RolloutTargetPhasePending:
+			if err := r.updateVersion(ctx, cps[t.Name], rollout.Spec.Component, rollout.Spec.Version); err != nil {
+				return err
+			}
+			t.Phase = corev1beta1.RolloutTargetPhaseUpdating
+		}
+	}
+
+	deadline := rollout.Spec.ProgressDeadline.Duration
+	if deadline > 0 && now.Sub(status.CurrentWaveStartTime.Time) > deadline {
+		return r.pause(ctx, rollout, fmt.Sprintf("%s did not become healthy on wave %d within %s", rollout.Spec.Component, currentWave+1, deadline))
+	}
+	return nil
+}
+
+// pause sets the ComponentRollout to paused and keeps its status.
+func (r *ComponentRolloutReconciler) pause(ctx context.Context, rollout *corev1beta1.ComponentRollout, message string) error {
+	status := rollout.Status
+	patch := client.MergeFrom(rollout.DeepCopy())
+	rollout.Spec.Paused = true
+	if err := r.Patch(ctx, rollout, patch); err != nil {
+		return err
+	}
+	rollout.Status = status
+	rollout.Status.Phase = corev1beta1.RolloutPhasePaused
+	rollout.Status.Message = message
+	r.Recorder.Eventf(rollout, nil, corev1.EventTypeWarning, "RolloutPaused", "Rollout", "%s", message)
+	return nil
+}
+
+// updateVersion sets the version of the component in the spec of the ControlPlane.
+func (r *ComponentRolloutReconciler) updateVersion(ctx context.Context, cp *corev1beta1.ControlPlane, component corev1beta1.RolloutComponent, version string) error {
+	patch := client.MergeFrom(cp.DeepCopy())
+	*component.ComponentVersion(cp) = version
+	return r.Patch(ctx, cp, patch)
+}
+
+// assignWaves distributes the ControlPlanes to waves. The first (canary) wave contains `canaryPercentage` percent
+// of the ControlPlanes but at least one, every following wave contains up to `waveSize` ControlPlanes.
+func assignWaves(names []string, canaryPercentage, waveSize int32) []corev1beta1.RolloutTarget {
+	total := int32(len(names))
+	canary := min(max((total*canaryPercentage+99)/100, 1), total)
+	waveSize = max(waveSize, 1)
+
+	targets := make([]corev1beta1.RolloutTarget, 0, total)
+	for i, name := range names {
+		wave := int32(0)
+		if i := int32(i); i >= canary {
+			wave = 1 + (i-canary)/waveSize
+		}
+		targets = append(targets, corev1beta1.RolloutTarget{Name: name, Wave: wave})
+	}
+	return targets
+}
+
+// keepWaves keeps the waves of the ControlPlanes that have been assigned when the rollout started, so that
+// ControlPlanes that are added or removed during the rollout do not change the size of the canary wave
+// or move other ControlPlanes to another wave. ControlPlanes that are selected later are added to the last wave.
+// Waves are assigned with assignWaves if the rollout has no targets yet.
+func keepWaves(assigned []corev1beta1.RolloutTarget, names []string, canaryPercentage, waveSize int32) []corev1beta1.RolloutTarget {
+	if len(assigned) == 0 {
+		return assignWaves(names, canaryPercentage, waveSize)
+	}
+
+	lastWave := int32(0)
+	targets := make([]corev1beta1.RolloutTarget, 0, len(names))
+	for _, t := range assigned {
+		lastWave = max(lastWave, t.Wave)
+		if slices.Contains(names, t.Name) {
+			targets = append(targets, corev1beta1.RolloutTarget{Name: t.Name, Wave: t.Wave})
+		}
+	}
+	for _, name := range names {
+		if !slices.ContainsFunc(assigned, func(t corev1beta1.RolloutTarget) bool { return t.Name == name }) {
+			targets = append(targets, corev1beta1.RolloutTarget{Name: name, Wave: lastWave})
+		}
+	}
+	return targets
+}
+
+// rolloutTargetPhase returns the phase of the rollout on the ControlPlane based on its spec and the
+// status of the component.
+func rolloutTargetPhase(cp *corev1beta1.ControlPlane, component corev1beta1.RolloutComponent, version string) (corev1beta1.RolloutTargetPhase, string) {
+	if *component.ComponentVersion(cp) != version {
+		return corev1beta1.RolloutTargetPhasePending, ""
+	}
+
+	idx := slices.IndexFunc(cp.Status.Components, func(cs corev1beta1.ComponentStatus) bool {
+		return cs.Name == string(component)
+	})
+	if idx < 0 || cp.Status.Components[idx].DesiredVersion != version {
+		return corev1beta1.RolloutTargetPhaseUpdating, ""
+	}
+	cs := cp.Status.Components[idx]
+
+	retrying := slices.ContainsFunc(cp.Status.Retries, func(rs corev1beta1.ComponentRetryStatus) bool {
+		return rs.Name == cs.Name
+	})
+	switch {
+	case juggler.IsFailurePhase(cs.Phase) || retrying:
+		return corev1beta1.RolloutTargetPhaseFailed, cs.Message
+	case juggler.IsReadyPhase(cs.Phase):
+		return corev1beta1.RolloutTargetPhaseHealthy, ""
+	}
+	return corev1beta1.RolloutTargetPhaseUpdating, cs.Message
+}
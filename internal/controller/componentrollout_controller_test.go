@@ -0,0 +1,351 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1beta1 "github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/internal/schemes"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+)
+
+func rolloutControlPlane(name, version string, status ...corev1beta1.ComponentStatus) *corev1beta1.ControlPlane {
+	return &corev1beta1.ControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"stage": "dev"},
+		},
+		Spec: corev1beta1.ControlPlaneSpec{
+			ComponentsConfig: corev1beta1.ComponentsConfig{
+				CertManager: &corev1beta1.CertManagerConfig{Version: version},
+			},
+		},
+		Status: corev1beta1.ControlPlaneStatus{Components: status},
+	}
+}
+
+func certManagerStatus(version string, phase juggler.ComponentStatus) corev1beta1.ComponentStatus {
+	return corev1beta1.ComponentStatus{
+		Name:           string(corev1beta1.RolloutComponentCertManager),
+		DesiredVersion: version,
+		Phase:          phase.Name,
+		Message:        phase.Name,
+	}
+}
+
+func Test_assignWaves(t *testing.T) {
+	names := []string{"a", "b", "c", "d", "e", "f", "g"}
+	waves := func(targets []corev1beta1.RolloutTarget) []int32 {
+		var w []int32
+		for _, t := range targets {
+			w = append(w, t.Wave)
+		}
+		return w
+	}
+
+	assert.Equal(t, []int32{0, 1, 1, 2, 2, 3, 3}, waves(assignWaves(names, 10, 2)))
+	assert.Equal(t, []int32{0, 0, 0, 1, 1, 1, 1}, waves(assignWaves(names, 30, 10)))
+	assert.Equal(t, []int32{0, 0, 0, 0, 0, 0, 0}, waves(assignWaves(names, 100, 1)))
+	assert.Equal(t, []int32{0, 1, 2, 3, 4, 5, 6}, waves(assignWaves(names, 0, 0)))
+	assert.Empty(t, assignWaves(nil, 10, 10))
+}
+
+func Test_keepWaves(t *testing.T) {
+	assigned := []corev1beta1.RolloutTarget{
+		{Name: "a", Wave: 0, Phase: corev1beta1.RolloutTargetPhaseHealthy},
+		{Name: "c", Wave: 1},
+		{Name: "d", Wave: 1},
+		{Name: "e", Wave: 2},
+	}
+	// "c" has been removed, "b" and "f" have been added
+	assert.Equal(t, []corev1beta1.RolloutTarget{
+		{Name: "a", Wave: 0},
+		{Name: "d", Wave: 1},
+		{Name: "e", Wave: 2},
+		{Name: "b", Wave: 2},
+		{Name: "f", Wave: 2},
+	}, keepWaves(assigned, []string{"a", "b", "d", "e", "f"}, 10, 2))
+	assert.Equal(t, assignWaves([]string{"a", "b"}, 10, 2), keepWaves(nil, []string{"a", "b"}, 10, 2))
+}
+
+func Test_rolloutTargetPhase(t *testing.T) {
+	tests := []struct {
+		name string
+		cp   *corev1beta1.ControlPlane
+		want corev1beta1.RolloutTargetPhase
+	}{
+		{
+			name: "version not set",
+			cp:   rolloutControlPlane("cp", "v1.0.0", certManagerStatus("v1.0.0", juggler.StatusHealthy)),
+			want: corev1beta1.RolloutTargetPhasePending,
+		},
+		{
+			name: "not reconciled yet",
+			cp:   rolloutControlPlane("cp", "v1.1.0", certManagerStatus("v1.0.0", juggler.StatusHealthy)),
+			want: corev1beta1.RolloutTargetPhaseUpdating,
+		},
+		{
+			name: "no status",
+			cp:   rolloutControlPlane("cp", "v1.1.0"),
+			want: corev1beta1.RolloutTargetPhaseUpdating,
+		},
+		{
+			name: "unhealthy",
+			cp:   rolloutControlPlane("cp", "v1.1.0", certManagerStatus("v1.1.0", juggler.StatusUnhealthy)),
+			want: corev1beta1.RolloutTargetPhaseUpdating,
+		},
+		{
+			name: "healthy",
+			cp:   rolloutControlPlane("cp", "v1.1.0", certManagerStatus("v1.1.0", juggler.StatusHealthy)),
+			want: corev1beta1.RolloutTargetPhaseHealthy,
+		},
+		{
+			name: "reconciliation skipped",
+			cp:   rolloutControlPlane("cp", "v1.1.0", certManagerStatus("v1.1.0", juggler.StatusHealthyReconciliationSkipped)),
+			want: corev1beta1.RolloutTargetPhaseHealthy,
+		},
+		{
+			name: "failed",
+			cp:   rolloutControlPlane("cp", "v1.1.0", certManagerStatus("v1.1.0", juggler.StatusUpdateFailed)),
+			want: corev1beta1.RolloutTargetPhaseFailed,
+		},
+		{
+			name: "retrying",
+			cp: func() *corev1beta1.ControlPlane {
+				cp := rolloutControlPlane("cp", "v1.1.0", certManagerStatus("v1.1.0", juggler.StatusRetryBackoff))
+				cp.Status.Retries = []corev1beta1.ComponentRetryStatus{{Name: "CertManager", RetryCount: 1}}
+				return cp
+			}(),
+			want: corev1beta1.RolloutTargetPhaseFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _ := rolloutTargetPhase(tt.cp, corev1beta1.RolloutComponentCertManager, "v1.1.0")
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestComponentRolloutReconciler_progress(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		cps          []*corev1beta1.ControlPlane
+		paused       bool
+		status       corev1beta1.ComponentRolloutStatus
+		wantPhase    corev1beta1.RolloutPhase
+		wantWave     int32
+		wantHealthy  int32
+		wantVersions map[string]string
+		wantPaused   bool
+	}{
+		{
+			name: "canary wave is started",
+			cps: []*corev1beta1.ControlPlane{
+				rolloutControlPlane("cp-1", "v1.0.0", certManagerStatus("v1.0.0", juggler.StatusHealthy)),
+				rolloutControlPlane("cp-2", "v1.0.0", certManagerStatus("v1.0.0", juggler.StatusHealthy)),
+				rolloutControlPlane("cp-3", "v1.0.0", certManagerStatus("v1.0.0", juggler.StatusHealthy)),
+			},
+			wantPhase:    corev1beta1.RolloutPhaseProgressing,
+			wantWave:     0,
+			wantVersions: map[string]string{"cp-1": "v1.1.0", "cp-2": "v1.0.0", "cp-3": "v1.0.0"},
+		},
+		{
+			name: "next wave is started once the canary is healthy",
+			cps: []*corev1beta1.ControlPlane{
+				rolloutControlPlane("cp-1", "v1.1.0", certManagerStatus("v1.1.0", juggler.StatusHealthy)),
+				rolloutControlPlane("cp-2", "v1.0.0", certManagerStatus("v1.0.0", juggler.StatusHealthy)),
+				rolloutControlPlane("cp-3", "v1.0.0", certManagerStatus("v1.0.0", juggler.StatusHealthy)),
+			},
+			wantPhase:    corev1beta1.RolloutPhaseProgressing,
+			wantWave:     1,
+			wantHealthy:  1,
+			wantVersions: map[string]string{"cp-1": "v1.1.0", "cp-2": "v1.1.0", "cp-3": "v1.1.0"},
+		},
+		{
+			name: "wave is not healthy yet",
+			cps: []*corev1beta1.ControlPlane{
+				rolloutControlPlane("cp-1", "v1.1.0", certManagerStatus("v1.1.0", juggler.StatusUnhealthy)),
+				rolloutControlPlane("cp-2", "v1.0.0", certManagerStatus("v1.0.0", juggler.StatusHealthy)),
+			},
+			status: corev1beta1.ComponentRolloutStatus{
+				CurrentWave:          0,
+				CurrentWaveStartTime: &metav1.Time{Time: now.Add(-time.Minute)},
+			},
+			wantPhase:    corev1beta1.RolloutPhaseProgressing,
+			wantWave:     0,
+			wantVersions: map[string]string{"cp-1": "v1.1.0", "cp-2": "v1.0.0"},
+		},
+		{
+			name: "rollout is paused on failure",
+			cps: []*corev1beta1.ControlPlane{
+				rolloutControlPlane("cp-1", "v1.1.0", certManagerStatus("v1.1.0", juggler.StatusUpdateFailed)),
+				rolloutControlPlane("cp-2", "v1.0.0", certManagerStatus("v1.0.0", juggler.StatusHealthy)),
+			},
+			wantPhase:    corev1beta1.RolloutPhasePaused,
+			wantWave:     0,
+			wantVersions: map[string]string{"cp-1": "v1.1.0", "cp-2": "v1.0.0"},
+			wantPaused:   true,
+		},
+		{
+			name: "rollout is paused after the progress deadline",
+			cps: []*corev1beta1.ControlPlane{
+				rolloutControlPlane("cp-1", "v1.1.0", certManagerStatus("v1.1.0", juggler.StatusUnhealthy)),
+				rolloutControlPlane("cp-2", "v1.0.0", certManagerStatus("v1.0.0", juggler.StatusHealthy)),
+			},
+			status: corev1beta1.ComponentRolloutStatus{
+				CurrentWave:          0,
+				CurrentWaveStartTime: &metav1.Time{Time: now.Add(-time.Hour)},
+			},
+			wantPhase:    corev1beta1.RolloutPhasePaused,
+			wantWave:     0,
+			wantVersions: map[string]string{"cp-1": "v1.1.0", "cp-2": "v1.0.0"},
+			wantPaused:   true,
+		},
+		{
+			name: "paused rollout does not update ControlPlanes",
+			cps: []*corev1beta1.ControlPlane{
+				rolloutControlPlane("cp-1", "v1.1.0", certManagerStatus("v1.1.0", juggler.StatusHealthy)),
+				rolloutControlPlane("cp-2", "v1.0.0", certManagerStatus("v1.0.0", juggler.StatusHealthy)),
+			},
+			paused:       true,
+			wantPhase:    corev1beta1.RolloutPhasePaused,
+			wantHealthy:  1,
+			wantVersions: map[string]string{"cp-1": "v1.1.0", "cp-2": "v1.0.0"},
+			wantPaused:   true,
+		},
+		{
+			name: "waves are kept when ControlPlanes are added",
+			cps: []*corev1beta1.ControlPlane{
+				rolloutControlPlane("cp-0", "v1.0.0", certManagerStatus("v1.0.0", juggler.StatusHealthy)),
+				rolloutControlPlane("cp-1", "v1.1.0", certManagerStatus("v1.1.0", juggler.StatusUnhealthy)),
+				rolloutControlPlane("cp-2", "v1.0.0", certManagerStatus("v1.0.0", juggler.StatusHealthy)),
+			},
+			status: corev1beta1.ComponentRolloutStatus{
+				CurrentWave:          0,
+				CurrentWaveStartTime: &metav1.Time{Time: now.Add(-time.Minute)},
+				Targets: []corev1beta1.RolloutTarget{
+					{Name: "cp-1", Wave: 0},
+					{Name: "cp-2", Wave: 1},
+				},
+			},
+			wantPhase:    corev1beta1.RolloutPhaseProgressing,
+			wantWave:     0,
+			wantVersions: map[string]string{"cp-0": "v1.0.0", "cp-1": "v1.1.0", "cp-2": "v1.0.0"},
+		},
+		{
+			name: "rollout is completed",
+			cps: []*corev1beta1.ControlPlane{
+				rolloutControlPlane("cp-1", "v1.1.0", certManagerStatus("v1.1.0", juggler.StatusHealthy)),
+				rolloutControlPlane("cp-2", "v1.1.0", certManagerStatus("v1.1.0", juggler.StatusHealthy)),
+			},
+			wantPhase:    corev1beta1.RolloutPhaseCompleted,
+			wantWave:     1,
+			wantHealthy:  2,
+			wantVersions: map[string]string{"cp-1": "v1.1.0", "cp-2": "v1.1.0"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			rollout := &corev1beta1.ComponentRollout{
+				ObjectMeta: metav1.ObjectMeta{Name: "cert-manager"},
+				Spec: corev1beta1.ComponentRolloutSpec{
+					Selector:         metav1.LabelSelector{MatchLabels: map[string]string{"stage": "dev"}},
+					Component:        corev1beta1.RolloutComponentCertManager,
+					Version:          "v1.1.0",
+					CanaryPercentage: 10,
+					WaveSize:         10,
+					ProgressDeadline: metav1.Duration{Duration: 30 * time.Minute},
+					Paused:           tt.paused,
+				},
+				Status: tt.status,
+			}
+			objs := []client.Object{rollout}
+			cps := map[string]*corev1beta1.ControlPlane{}
+			for _, cp := range tt.cps {
+				objs = append(objs, cp)
+				cps[cp.Name] = cp
+			}
+			c := fake.NewClientBuilder().WithObjects(objs...).WithStatusSubresource(rollout).WithScheme(schemes.Local).Build()
+			r := &ComponentRolloutReconciler{
+				Client:   c,
+				Scheme:   schemes.Local,
+				Recorder: events.NewFakeRecorder(100),
+			}
+
+			err := r.progress(ctx, rollout, cps, now)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantPhase, rollout.Status.Phase)
+			assert.Equal(t, tt.wantWave, rollout.Status.CurrentWave)
+			assert.Equal(t, tt.wantHealthy, rollout.Status.Healthy)
+			assert.Equal(t, int32(len(tt.cps)), rollout.Status.Total)
+			assert.Equal(t, tt.wantPaused, rollout.Spec.Paused)
+
+			for name, version := range tt.wantVersions {
+				cp := &corev1beta1.ControlPlane{}
+				require.NoError(t, c.Get(ctx, client.ObjectKey{Name: name}, cp))
+				assert.Equal(t, version, cp.Spec.CertManager.Version, fmt.Sprintf("version of %s", name))
+			}
+
+			stored := &corev1beta1.ComponentRollout{}
+			require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(rollout), stored))
+			assert.Equal(t, tt.wantPaused, stored.Spec.Paused)
+		})
+	}
+}
+
+func TestComponentRolloutReconciler_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	rollout := &corev1beta1.ComponentRollout{
+		ObjectMeta: metav1.ObjectMeta{Name: "cert-manager"},
+		Spec: corev1beta1.ComponentRolloutSpec{
+			Selector:  metav1.LabelSelector{MatchLabels: map[string]string{"stage": "dev"}},
+			Component: corev1beta1.RolloutComponentCertManager,
+			Version:   "v1.1.0",
+			WaveSize:  1,
+		},
+	}
+	other := rolloutControlPlane("other", "v1.0.0")
+	other.Labels = map[string]string{"stage": "prod"}
+	withoutComponent := rolloutControlPlane("without-component", "v1.0.0")
+	withoutComponent.Spec.CertManager = nil
+
+	c := fake.NewClientBuilder().
+		WithObjects(rollout, rolloutControlPlane("cp-1", "v1.0.0"), other, withoutComponent).
+		WithStatusSubresource(rollout).
+		WithScheme(schemes.Local).
+		Build()
+	r := &ComponentRolloutReconciler{
+		Client:          c,
+		Scheme:          schemes.Local,
+		Recorder:        events.NewFakeRecorder(100),
+		ReconcilePeriod: time.Minute,
+	}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(rollout)})
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, result.RequeueAfter)
+
+	stored := &corev1beta1.ComponentRollout{}
+	require.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(rollout), stored))
+	assert.Equal(t, corev1beta1.RolloutPhaseProgressing, stored.Status.Phase)
+	assert.Equal(t, []corev1beta1.RolloutTarget{{Name: "cp-1", Wave: 0, Phase: corev1beta1.RolloutTargetPhaseUpdating}}, stored.Status.Targets)
+
+	requests := r.rolloutsForControlPlane(ctx, other)
+	assert.Empty(t, requests)
+	requests = r.rolloutsForControlPlane(ctx, withoutComponent)
+	assert.Len(t, requests, 1)
+}
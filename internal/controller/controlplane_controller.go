@@ -20,20 +20,26 @@ import (
 	"context"
 	"embed"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/events"
 
+	"github.com/openmcp-project/control-plane-operator/internal/metrics"
 	"github.com/openmcp-project/control-plane-operator/internal/ocm"
+	"github.com/openmcp-project/control-plane-operator/internal/tracing"
 
 	"github.com/openmcp-project/control-plane-operator/cmd/options"
 	"github.com/openmcp-project/control-plane-operator/internal/schemes"
+	"github.com/openmcp-project/control-plane-operator/pkg/constants"
 	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/components/clusterroles"
 	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/components/crds"
 	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/components/policies"
 	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/crossplane"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/profiles"
 	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/secretresolver"
 	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/secrets"
 	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
@@ -47,16 +53,20 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	corev1beta1 "github.com/openmcp-project/control-plane-operator/api/v1beta1"
 	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/components"
 	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/kubeconfiggen"
 	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/targetrbac"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/valuesfrom"
 	"github.com/openmcp-project/control-plane-operator/pkg/utils"
 )
 
@@ -65,15 +75,25 @@ const (
 
 	cpNamespacePrefix = "cp-"
 	cpNamespaceMaxLen = 63
+
+	// fluxKubeconfigName is the name of the Secret with the kubeconfig Flux uses for the target cluster.
+	fluxKubeconfigName = "flux-kubeconfig"
+
+	// maxPlanDiffLen limits the size of a single diff in the ControlPlane status.
+	maxPlanDiffLen = 4096
 )
 
 var (
-	errComponentRemaining           = errors.New("at least one component is still installed")
-	errFailedToCreateCPNamespace    = errors.New("failed to create namespace for ControlPlane")
-	errFailedToBuildRESTConfig      = errors.New("failed to build REST config from ControlPlane target")
-	errFailedToRemoteClient         = errors.New("failed to build client for ControlPlane target")
-	errFailedToEnsureFluxKubeconfig = errors.New("failed to generate or save Flux kubeconfig")
-	errFailedToApplyFluxRBAC        = errors.New("failed to apply Flux RBAC")
+	errComponentRemaining               = errors.New("at least one component is still installed")
+	errFailedToCreateCPNamespace        = errors.New("failed to create namespace for ControlPlane")
+	errFailedToGetProfile               = errors.New("failed to get ControlPlaneProfile")
+	errFailedToApplyProfile             = errors.New("failed to apply ControlPlaneProfile")
+	errFailedToListComponentDefinitions = errors.New("failed to list ComponentDefinitions")
+	errFailedToSyncValuesFrom           = errors.New("failed to copy values of components into ControlPlane namespace")
+	errFailedToBuildRESTConfig          = errors.New("failed to build REST config from ControlPlane target")
+	errFailedToRemoteClient             = errors.New("failed to build client for ControlPlane target")
+	errFailedToEnsureFluxKubeconfig     = errors.New("failed to generate or save Flux kubeconfig")
+	errFailedToApplyFluxRBAC            = errors.New("failed to apply Flux RBAC")
 
 	secretTargetNamespaces = []string{
 		components.CrossplaneNamespace,
@@ -96,11 +116,23 @@ type ControlPlaneReconciler struct {
 	FluxTokenLifetime   time.Duration
 	RemoteConfigBuilder RemoteConfigBuilder
 	EmbeddedCRDs        embed.FS
+	// MaxConcurrentComponentReconciles is the number of components of a single ControlPlane
+	// that may be reconciled at the same time.
+	MaxConcurrentComponentReconciles int
+	// ComponentRetryBaseDelay is the delay before a failed component is retried. It doubles with every
+	// consecutive failure up to ComponentRetryMaxDelay. Failed components are retried immediately if not set.
+	ComponentRetryBaseDelay time.Duration
+	ComponentRetryMaxDelay  time.Duration
+	// TelemetryExporterEndpoint is the OTLP/HTTP endpoint metrics are exported to
+	// if the ControlPlane does not configure one.
+	TelemetryExporterEndpoint string
+	// ValuesFromNamespaces are the namespaces the `valuesFrom` of components may reference ConfigMaps and Secrets in.
+	ValuesFromNamespaces []string
 }
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
-func (r *ControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *ControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	log := log.FromContext(ctx)
 	newConditions := []metav1.Condition{}
 
@@ -114,9 +146,22 @@ func (r *ControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request
 		return ctrl.Result{}, err
 	}
 
-	namespace, err := r.ensureNamespace(ctx, cp)
-	if err != nil {
-		return ctrl.Result{}, errors.Join(errFailedToCreateCPNamespace, err)
+	// traces are only recorded for ControlPlanes with enabled telemetry
+	ctx = tracing.WithEnabled(ctx, cp.Spec.Telemetry != nil && cp.Spec.Telemetry.Enabled)
+	ctx, span := tracing.Start(ctx, "ControlPlaneReconciler.Reconcile", attribute.String("controlplane", cp.Name))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
+	// in dry-run mode, nothing is written to the target cluster or the namespace of the ControlPlane.
+	// The annotation is ignored while the ControlPlane is being deleted.
+	dryRun := cp.Annotations[constants.AnnotationDryRun] == "true" && cp.DeletionTimestamp.IsZero()
+
+	namespace := controlPlaneNamespace(cp)
+	if !dryRun {
+		if err := r.ensureNamespace(ctx, cp, namespace); err != nil {
+			return ctrl.Result{}, errors.Join(errFailedToCreateCPNamespace, err)
+		}
 	}
 	ctx = rcontext.WithTenantNamespace(ctx, namespace)
 
@@ -130,6 +175,7 @@ func (r *ControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	if err != nil {
 		return ctrl.Result{}, errors.Join(errFailedToBuildRESTConfig, err)
 	}
+	tracing.InstrumentConfig(remoteCfg)
 
 	// create a remote client
 	remoteClient, err := client.New(remoteCfg, client.Options{Scheme: schemes.Remote})
@@ -138,15 +184,28 @@ func (r *ControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	}
 
 	// Flux kubeconfig and RBAC
-	if err := targetrbac.Apply(ctx, remoteClient, cp.Spec.Target.FluxServiceAccount); err != nil {
-		return ctrl.Result{}, errors.Join(errFailedToApplyFluxRBAC, err)
+	fluxKubeconfig := &corev1.SecretReference{Name: fluxKubeconfigName, Namespace: namespace}
+	if !dryRun {
+		if err := targetrbac.Apply(ctx, remoteClient, cp.Spec.Target.FluxServiceAccount); err != nil {
+			return ctrl.Result{}, errors.Join(errFailedToApplyFluxRBAC, err)
+		}
+
+		fluxKubeconfig, err = r.ensureKubeconfig(ctx, remoteCfg, namespace, fluxKubeconfigName, cp.Spec.Target.FluxServiceAccount)
+		if err != nil {
+			return ctrl.Result{}, errors.Join(errFailedToEnsureFluxKubeconfig, err)
+		}
 	}
+	ctx = rcontext.WithFluxKubeconfigRef(ctx, fluxKubeconfig)
 
-	fluxKubeconfig, err := r.ensureKubeconfig(ctx, remoteCfg, namespace, "flux-kubeconfig", cp.Spec.Target.FluxServiceAccount)
+	profile, err := profiles.Get(ctx, r.Client, cp)
 	if err != nil {
-		return ctrl.Result{}, errors.Join(errFailedToEnsureFluxKubeconfig, err)
+		return ctrl.Result{}, errors.Join(errFailedToGetProfile, err)
+	}
+
+	definitions, err := components.ListDefinitions(ctx, r.Client)
+	if err != nil {
+		return ctrl.Result{}, errors.Join(errFailedToListComponentDefinitions, err)
 	}
-	ctx = rcontext.WithFluxKubeconfigRef(ctx, fluxKubeconfig)
 
 	// Always update status
 	defer func() {
@@ -157,15 +216,38 @@ func (r *ControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request
 	}()
 
 	if !cp.DeletionTimestamp.IsZero() {
-		return r.deleteControlPlane(ctx, cp, remoteClient, &newConditions)
+		return r.deleteControlPlane(ctx, cp, profile, definitions, remoteClient, &newConditions)
 	}
 
-	if err := r.ensureFinalizer(ctx, cp); err != nil {
-		return ctrl.Result{}, err
+	// the finalizer is only added once the ControlPlane is reconciled for real
+	if !dryRun {
+		if err := r.ensureFinalizer(ctx, cp); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	// the defaults of the profile are only merged in memory and never written back to the ControlPlane
+	if err := profiles.Apply(profile, cp); err != nil {
+		return ctrl.Result{}, errors.Join(errFailedToApplyProfile, err)
+	}
+
+	// in dry-run mode, only compute what would be changed and keep the current conditions
+	if dryRun {
+		if err := r.planControlPlaneComponents(ctx, cp, profile, definitions, remoteClient); err != nil {
+			return ctrl.Result{}, err
+		}
+		newConditions = append(newConditions, cp.Status.Conditions...)
+		condApi.SetStatusCondition(&newConditions, corev1beta1.Available())
+		return ctrl.Result{RequeueAfter: r.ReconcilePeriod}, nil
+	}
+	cp.Status.Plan = nil
+
+	if err := valuesfrom.Sync(ctx, r.Client, cp, namespace, r.ValuesFromNamespaces); err != nil {
+		return ctrl.Result{}, errors.Join(errFailedToSyncValuesFrom, err)
 	}
 
 	// update ControlPlane v1beta1.ComponentConfig
-	conditions, err := r.updateControlPlaneComponents(ctx, cp, remoteClient)
+	conditions, err := r.updateControlPlaneComponents(ctx, cp, profile, definitions, remoteClient)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
@@ -180,7 +262,7 @@ func (r *ControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.Request
 
 	cp.Status.Namespace = namespace
 
-	return ctrl.Result{RequeueAfter: r.ReconcilePeriod}, nil
+	return ctrl.Result{RequeueAfter: r.requeueAfter(cp)}, nil
 }
 
 // getReleaseChannels returns a function that can be used to resolve the version of a component
@@ -197,20 +279,121 @@ func (r *ControlPlaneReconciler) getComponentAvailableVersions(ctx context.Conte
 	}
 }
 
+// valuesFromIndex indexes ControlPlanes and ControlPlaneProfiles by the ConfigMaps and Secrets
+// referenced in the `valuesFrom` of their components.
+const valuesFromIndex = ".spec.valuesFrom"
+
+func indexControlPlaneValuesFrom(o client.Object) []string {
+	return valuesfrom.IndexKeys(valuesfrom.References(o.(*corev1beta1.ControlPlane)))
+}
+
+func indexProfileValuesFrom(o client.Object) []string {
+	return valuesfrom.IndexKeys(valuesfrom.ComponentsReferences(&o.(*corev1beta1.ControlPlaneProfile).Spec.ComponentsConfig))
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ControlPlaneReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	ctx := context.Background()
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &corev1beta1.ControlPlane{}, valuesFromIndex, indexControlPlaneValuesFrom); err != nil {
+		return err
+	}
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &corev1beta1.ControlPlaneProfile{}, valuesFromIndex, indexProfileValuesFrom); err != nil {
+		return err
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&corev1beta1.ControlPlane{}).
+		Watches(&corev1beta1.ControlPlaneProfile{}, handler.EnqueueRequestsFromMapFunc(r.controlPlanesForProfile)).
+		Watches(&corev1beta1.ComponentDefinition{}, handler.EnqueueRequestsFromMapFunc(r.controlPlanesForDefinition))
+	// values can only be referenced in the allowed namespaces, so ConfigMaps and Secrets
+	// in other namespaces, like the copies in the namespaces of the ControlPlanes, are not watched
+	if len(r.ValuesFromNamespaces) > 0 {
+		inValuesFromNamespaces := builder.WithPredicates(predicate.NewPredicateFuncs(func(o client.Object) bool {
+			return valuesfrom.IsNamespaceAllowed(r.ValuesFromNamespaces, o.GetNamespace())
+		}))
+		b = b.
+			Watches(&corev1.ConfigMap{}, handler.EnqueueRequestsFromMapFunc(r.controlPlanesForValues(valuesfrom.KindConfigMap)), inValuesFromNamespaces).
+			Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(r.controlPlanesForValues(valuesfrom.KindSecret)), inValuesFromNamespaces)
+	}
+	return b.
 		WithOptions(controller.TypedOptions[reconcile.Request]{
 			MaxConcurrentReconciles: 10,
 		}).
 		Complete(r)
 }
 
+// controlPlanesForProfile returns a request for every ControlPlane that references the ControlPlaneProfile.
+func (r *ControlPlaneReconciler) controlPlanesForProfile(ctx context.Context, o client.Object) []reconcile.Request {
+	cps := &corev1beta1.ControlPlaneList{}
+	if err := r.List(ctx, cps); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list ControlPlanes")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, cp := range cps.Items {
+		if cp.Spec.CoreReference.Name == o.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cp)})
+		}
+	}
+	return requests
+}
+
+// controlPlanesForDefinition returns a request for every ControlPlane, because a ComponentDefinition
+// may adjust a built-in component of any ControlPlane.
+func (r *ControlPlaneReconciler) controlPlanesForDefinition(ctx context.Context, _ client.Object) []reconcile.Request {
+	cps := &corev1beta1.ControlPlaneList{}
+	if err := r.List(ctx, cps); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list ControlPlanes")
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(cps.Items))
+	for _, cp := range cps.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cp)})
+	}
+	return requests
+}
+
+// controlPlanesForValues returns a function that returns a request for every ControlPlane
+// with a component that references the ConfigMap or Secret in its valuesFrom, directly or through its ControlPlaneProfile.
+func (r *ControlPlaneReconciler) controlPlanesForValues(kind string) handler.MapFunc {
+	return func(ctx context.Context, o client.Object) []reconcile.Request {
+		referencedBy := client.MatchingFields{valuesFromIndex: valuesfrom.IndexKey(kind, client.ObjectKeyFromObject(o))}
+
+		cps := &corev1beta1.ControlPlaneList{}
+		if err := r.List(ctx, cps, referencedBy); err != nil {
+			log.FromContext(ctx).Error(err, "unable to list ControlPlanes")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, cp := range cps.Items {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&cp)})
+		}
+
+		// components may also reference values in the ControlPlaneProfile
+		profiles := &corev1beta1.ControlPlaneProfileList{}
+		if err := r.List(ctx, profiles, referencedBy); err != nil {
+			log.FromContext(ctx).Error(err, "unable to list ControlPlaneProfiles")
+			return requests
+		}
+		for _, profile := range profiles.Items {
+			requests = append(requests, r.controlPlanesForProfile(ctx, &profile)...)
+		}
+		return requests
+	}
+}
+
 // updateControlPlaneComponents is the reconcile method where the v1beta1.ControlPlane components get reconciled
 // by the components.Juggler. This function will return a list of Kubernetes conditions for the particular components.
-func (r *ControlPlaneReconciler) updateControlPlaneComponents(ctx context.Context, cp *corev1beta1.ControlPlane, remoteClient client.Client) ([]metav1.Condition, error) {
-	j, err := r.newJuggler(ctx, cp, remoteClient)
+func (r *ControlPlaneReconciler) updateControlPlaneComponents(ctx context.Context, cp *corev1beta1.ControlPlane, profile *corev1beta1.ControlPlaneProfile, definitions components.Definitions, remoteClient client.Client) ([]metav1.Condition, error) {
+	ctx, componentUpgrades := r.withComponentUpgrades(ctx, cp, definitions, time.Now())
+	for _, u := range componentUpgrades {
+		r.Recorder.Eventf(cp, nil, corev1.EventTypeNormal, "ComponentUpgrade", "Upgrade",
+			"Upgrading %s from %s to %s", u.component, u.from, u.to)
+	}
+
+	j, err := r.newJuggler(ctx, cp, profile, definitions, remoteClient)
 	if err != nil {
 		return nil, err
 	}
@@ -237,18 +420,59 @@ func (r *ControlPlaneReconciler) updateControlPlaneComponents(ctx context.Contex
 
 	cp.Status.ComponentsEnabled = enabledComponents
 	cp.Status.ComponentsHealthy = healthyComponents
+	metrics.RecordComponents(cp.Name, result, enabledComponents, healthyComponents)
+	cp.Status.Components = componentStatus(cp.Status.Components, result, metav1.Now())
+	cp.Status.Retries = retryStatus(result)
 
 	return conditions, nil
 }
 
-func (r *ControlPlaneReconciler) deleteControlPlane(ctx context.Context, cp *corev1beta1.ControlPlane, remoteClient client.Client, newConditions *[]metav1.Condition) (ctrl.Result, error) {
+// planControlPlaneComponents computes the changes the components.Juggler would apply to the v1beta1.ControlPlane
+// components without performing them. The result is stored in the status of the ControlPlane.
+func (r *ControlPlaneReconciler) planControlPlaneComponents(ctx context.Context, cp *corev1beta1.ControlPlane, profile *corev1beta1.ControlPlaneProfile, definitions components.Definitions, remoteClient client.Client) error {
+	ctx, _ = r.withComponentUpgrades(ctx, cp, definitions, time.Now())
+	j, err := r.newJuggler(ctx, cp, profile, definitions, remoteClient)
+	if err != nil {
+		return err
+	}
+
+	plan := []corev1beta1.ComponentPlan{}
+	changes := 0
+	for _, componentPlan := range j.Plan(ctx) {
+		if !componentPlan.Component.IsEnabled() && componentPlan.Action == juggler.PlanActionNone {
+			// Component is not enabled and not installed, nothing to report.
+			continue
+		}
+		switch componentPlan.Action {
+		case juggler.PlanActionInstall, juggler.PlanActionUpdate, juggler.PlanActionUninstall:
+			changes++
+		}
+		diff := componentPlan.Diff
+		if len(diff) > maxPlanDiffLen {
+			diff = diff[:maxPlanDiffLen] + "\n... (truncated)"
+		}
+		plan = append(plan, corev1beta1.ComponentPlan{
+			Name:    componentPlan.Component.GetName(),
+			Action:  string(componentPlan.Action),
+			Message: componentPlan.Message,
+			Diff:    diff,
+		})
+	}
+
+	cp.Status.Plan = plan
+	r.Recorder.Eventf(cp, nil, corev1.EventTypeNormal, "DryRun", "DryRun",
+		"Dry-run mode is active, %d component(s) would be changed", changes)
+	return nil
+}
+
+func (r *ControlPlaneReconciler) deleteControlPlane(ctx context.Context, cp *corev1beta1.ControlPlane, profile *corev1beta1.ControlPlaneProfile, definitions components.Definitions, remoteClient client.Client, newConditions *[]metav1.Condition) (ctrl.Result, error) {
 	if !r.hasFinalizer(cp) {
 		return ctrl.Result{}, nil
 	}
 
 	log := log.FromContext(ctx)
 
-	conditions, err := r.deleteControlPlaneComponents(ctx, cp, remoteClient)
+	conditions, err := r.deleteControlPlaneComponents(ctx, cp, profile, definitions, remoteClient)
 	// append collected conditions to Status
 	for _, c := range conditions {
 		condApi.SetStatusCondition(newConditions, c)
@@ -268,20 +492,24 @@ func (r *ControlPlaneReconciler) deleteControlPlane(ctx context.Context, cp *cor
 	if err := r.removeFinalizer(ctx, cp); err != nil {
 		return ctrl.Result{}, err
 	}
+	metrics.ForgetControlPlane(cp.Name, cp.Status.Namespace)
 
 	return ctrl.Result{}, nil
 }
 
-func (r *ControlPlaneReconciler) deleteControlPlaneComponents(ctx context.Context, cp *corev1beta1.ControlPlane, remoteClient client.Client) ([]metav1.Condition, error) {
+func (r *ControlPlaneReconciler) deleteControlPlaneComponents(ctx context.Context, cp *corev1beta1.ControlPlane, profile *corev1beta1.ControlPlaneProfile, definitions components.Definitions, remoteClient client.Client) ([]metav1.Condition, error) {
 	// disable all components
 	cpCopy := cp.DeepCopy()
 	cpCopy.Spec.ComponentsConfig = corev1beta1.ComponentsConfig{}
+	cpCopy.Spec.Telemetry = nil
 
-	j, err := r.newJuggler(ctx, cpCopy, remoteClient)
+	j, err := r.newJuggler(ctx, cpCopy, profile, definitions, remoteClient)
 	if err != nil {
 		return nil, err
 	}
 	result := j.Reconcile(ctx)
+	cp.Status.Components = componentStatus(cp.Status.Components, result, metav1.Now())
+	cp.Status.Retries = retryStatus(result)
 
 	anyComponentRemaining := false
 	for _, cr := range result {
@@ -307,9 +535,13 @@ func (r *ControlPlaneReconciler) deleteControlPlaneComponents(ctx context.Contex
 	return conditions, nil
 }
 
-func (r *ControlPlaneReconciler) newJuggler(ctx context.Context, cp *corev1beta1.ControlPlane, remoteClient client.Client) (*juggler.Juggler, error) {
+func (r *ControlPlaneReconciler) newJuggler(ctx context.Context, cp *corev1beta1.ControlPlane, profile *corev1beta1.ControlPlaneProfile, definitions components.Definitions, remoteClient client.Client) (*juggler.Juggler, error) {
 	logger := log.FromContext(ctx)
-	juggler := juggler.NewJuggler(logger, juggler.NewEventRecorder(r.Recorder, cp))
+	juggler := juggler.NewJuggler(logger, juggler.NewEventRecorder(r.Recorder, cp)).
+		WithMaxConcurrentReconciles(r.MaxConcurrentComponentReconciles).
+		WithReadinessHistory(juggler.ReadinessFromConditions(cp.Status.Conditions)).
+		WithRetryBackoff(r.retryBackoff(), retryHistory(cp)).
+		WithPhaseObserver(metrics.ObservePhase)
 
 	secretsToCopy, err := r.addPullSecrets(ctx, cp)
 	if err != nil {
@@ -318,7 +550,7 @@ func (r *ControlPlaneReconciler) newJuggler(ctx context.Context, cp *corev1beta1
 	juggler.RegisterComponent(secretsToCopy...)
 
 	// register Components that get installed on the target cluster
-	cpComponents := r.controlPlaneComponents(cp)
+	cpComponents := r.controlPlaneComponents(ctx, cp, definitions)
 	juggler.RegisterComponent(cpComponents...)
 
 	// register ClusterRoles
@@ -330,11 +562,12 @@ func (r *ControlPlaneReconciler) newJuggler(ctx context.Context, cp *corev1beta1
 	}
 
 	// register policies
-	if err := policies.RegisterAsComponents(juggler, r.Client, !cp.WasDeleted()); err != nil {
+	if err := policies.RegisterAsComponents(juggler, r.Client, profiles.CrossplanePackageRestrictionName(profile), !cp.WasDeleted()); err != nil {
 		return nil, err
 	}
 
-	if err := policies.RegisterDeploymentRuntimeConfigProtection(juggler, r.Client, options.IsDeploymentRuntimeConfigProtectionEnabled() && !cp.WasDeleted()); err != nil {
+	drcProtection := profiles.DeploymentRuntimeConfigProtection(profile, options.IsDeploymentRuntimeConfigProtectionEnabled())
+	if err := policies.RegisterDeploymentRuntimeConfigProtection(juggler, r.Client, drcProtection && !cp.WasDeleted()); err != nil {
 		return nil, err
 	}
 
@@ -356,6 +589,8 @@ func (r *ControlPlaneReconciler) registerReconcilers(juggler *juggler.Juggler, l
 		&components.ExternalSecretsOperator{},
 		&components.Flux{},
 		&components.Kyverno{},
+		&components.Telemetry{},
+		&components.AdditionalComponent{},
 	)
 	juggler.RegisterReconciler(fr)
 
@@ -363,7 +598,11 @@ func (r *ControlPlaneReconciler) registerReconcilers(juggler *juggler.Juggler, l
 	or.RegisterType(
 		&components.ClusterRole{},
 		&components.CrossplaneProvider{},
+		&components.CrossplaneConfiguration{},
+		&components.CrossplaneFunction{},
+		&components.CrossplaneImageConfig{},
 		&components.CrossplaneDeploymentRuntimeConfig{},
+		&components.ProviderConfig{},
 		&components.Secret{},
 		&components.GenericObjectComponent{},
 	)
@@ -375,6 +614,19 @@ func (r *ControlPlaneReconciler) addPullSecrets(ctx context.Context, cp *corev1b
 	if err != nil {
 		return nil, err
 	}
+	// pull secrets of the spec are taken from the namespace of the ControlPlane
+	for _, ps := range cp.Spec.PullSecrets {
+		pullSecrets = append(pullSecrets, types.NamespacedName{Name: ps.Name, Namespace: rcontext.TenantNamespace(ctx)})
+	}
+	// pull secrets of ImageConfigs are attached to the package images by prefix instead of to every package,
+	// unless they are already used as global pull secret or pull secret of the spec.
+	imageConfigSecrets := []types.NamespacedName{}
+	for _, name := range imageConfigPullSecrets(cp) {
+		if !slices.ContainsFunc(pullSecrets, func(ps types.NamespacedName) bool { return ps.Name == name }) {
+			imageConfigSecrets = append(imageConfigSecrets, types.NamespacedName{Name: name, Namespace: rcontext.TenantNamespace(ctx)})
+		}
+	}
+	pullSecrets = append(pullSecrets, imageConfigSecrets...)
 
 	comps := []juggler.Component{}
 	for _, ps := range pullSecrets {
@@ -392,11 +644,16 @@ func (r *ControlPlaneReconciler) addPullSecrets(ctx context.Context, cp *corev1b
 		}
 
 		// If Crossplane is enabled, add secret ref to the list of pull secrets.
-		if cp.Spec.Crossplane != nil {
+		if cp.Spec.Crossplane != nil && !slices.Contains(imageConfigSecrets, ps) {
+			ref := corev1.LocalObjectReference{Name: ps.Name}
 			for _, provider := range cp.Spec.Crossplane.Providers {
-				provider.PackagePullSecrets = append(provider.PackagePullSecrets, corev1.LocalObjectReference{
-					Name: ps.Name,
-				})
+				provider.PackagePullSecrets = append(provider.PackagePullSecrets, ref)
+			}
+			for _, configuration := range cp.Spec.Crossplane.Configurations {
+				configuration.PackagePullSecrets = append(configuration.PackagePullSecrets, ref)
+			}
+			for _, function := range cp.Spec.Crossplane.Functions {
+				function.PackagePullSecrets = append(function.PackagePullSecrets, ref)
 			}
 		}
 	}
@@ -404,48 +661,124 @@ func (r *ControlPlaneReconciler) addPullSecrets(ctx context.Context, cp *corev1b
 	return comps, nil
 }
 
+// imageConfigPullSecrets returns the names of the pull secrets referenced by the ImageConfigs of the ControlPlane.
+func imageConfigPullSecrets(cp *corev1beta1.ControlPlane) []string {
+	names := []string{}
+	if cp.Spec.Crossplane == nil {
+		return names
+	}
+	for _, ic := range cp.Spec.Crossplane.ImageConfigs {
+		if ic.PullSecretRef != nil && !slices.Contains(names, ic.PullSecretRef.Name) {
+			names = append(names, ic.PullSecretRef.Name)
+		}
+	}
+	return names
+}
+
 // controlPlaneComponents will extract the components from the v1beta1.ControlPlane spec that will be installed in the target cluster,
-// so that the Juggler can reconcile them.
-func (r *ControlPlaneReconciler) controlPlaneComponents(cp *corev1beta1.ControlPlane) []juggler.Component {
+// so that the Juggler can reconcile them. The ComponentDefinitions adjust the built-in components and are referenced by additional components.
+func (r *ControlPlaneReconciler) controlPlaneComponents(ctx context.Context, cp *corev1beta1.ControlPlane, definitions components.Definitions) []juggler.Component {
 	comps := []juggler.Component{}
 	xp := &components.Crossplane{
-		Config: cp.Spec.Crossplane,
+		Config:      cp.Spec.Crossplane,
+		Definitions: definitions,
 	}
 	comps = append(comps, xp)
 	if cp.Spec.Crossplane != nil {
+		// the revisions of unhealthy providers are inspected with the same lists of runtime objects
+		revisions := &crossplane.RevisionInspector{Namespace: components.CrossplaneNamespace}
+		// the packages depend on the ImageConfigs, so they apply to the packages when they are pulled.
+		imageConfigs := make([]string, 0, len(cp.Spec.Crossplane.ImageConfigs))
+		for i := range cp.Spec.Crossplane.ImageConfigs {
+			comps = append(comps, &components.CrossplaneImageConfig{
+				Config:  &cp.Spec.Crossplane.ImageConfigs[i],
+				Enabled: xp.IsEnabled(),
+			})
+			imageConfigs = append(imageConfigs, cp.Spec.Crossplane.ImageConfigs[i].Name)
+		}
 		for _, provider := range cp.Spec.Crossplane.Providers {
 			comps = append(comps, &components.CrossplaneProvider{
-				Config:  provider,
-				Enabled: xp.IsEnabled(),
+				Config:       provider,
+				Enabled:      xp.IsEnabled(),
+				Revisions:    revisions,
+				ImageConfigs: imageConfigs,
 			})
 			comps = append(comps, &components.CrossplaneDeploymentRuntimeConfig{
-				Name:    crossplane.DeploymentRuntimeNameForProviderConfig(provider),
-				Enabled: xp.IsEnabled(),
+				Name:          crossplane.DeploymentRuntimeNameForProviderConfig(provider),
+				Enabled:       xp.IsEnabled(),
+				RuntimeConfig: provider.RuntimeConfig,
+			})
+			for i := range provider.ProviderConfigs {
+				pc := &components.ProviderConfig{
+					Provider: provider.Name,
+					Config:   &provider.ProviderConfigs[i],
+					Enabled:  xp.IsEnabled(),
+				}
+				// the Secret is read from the namespace of the ControlPlane, like its pull secrets
+				comps = append(comps, pc, pc.CredentialsSecret(r.Client, rcontext.TenantNamespace(ctx)))
+			}
+		}
+		for _, configuration := range cp.Spec.Crossplane.Configurations {
+			comps = append(comps, &components.CrossplaneConfiguration{
+				Config:       configuration,
+				Enabled:      xp.IsEnabled(),
+				ImageConfigs: imageConfigs,
+			})
+		}
+		for _, function := range cp.Spec.Crossplane.Functions {
+			comps = append(comps, &components.CrossplaneFunction{
+				Config:       function,
+				Enabled:      xp.IsEnabled(),
+				ImageConfigs: imageConfigs,
 			})
 		}
 	}
 	comps = append(comps, &components.CertManager{
-		Config: cp.Spec.CertManager,
+		Config:      cp.Spec.CertManager,
+		Definitions: definitions,
 	})
 	comps = append(comps, &components.BTPServiceOperator{
-		Config: cp.Spec.BTPServiceOperator,
+		Config:      cp.Spec.BTPServiceOperator,
+		Definitions: definitions,
 	})
 	comps = append(comps, &components.ExternalSecretsOperator{
-		Config: cp.Spec.ExternalSecretsOperator,
+		Config:      cp.Spec.ExternalSecretsOperator,
+		Definitions: definitions,
 	})
 	comps = append(comps, &components.Kyverno{
-		Config: cp.Spec.Kyverno,
+		Config:      cp.Spec.Kyverno,
+		Definitions: definitions,
 	})
 	comps = append(comps, &components.Flux{
-		Config: cp.Spec.Flux,
+		Config:      cp.Spec.Flux,
+		Definitions: definitions,
 	})
+	telemetry := &components.Telemetry{
+		Config:          cp.Spec.Telemetry,
+		DefaultEndpoint: r.TelemetryExporterEndpoint,
+		Definitions:     definitions,
+	}
+	comps = append(comps, telemetry)
+	for i := range cp.Spec.AdditionalComponents {
+		comps = append(comps, &components.AdditionalComponent{
+			Config:      &cp.Spec.AdditionalComponents[i],
+			Definitions: definitions,
+		})
+	}
+	// the collector scrapes the namespaces of all components, including the additional ones
+	telemetry.ScrapeNamespaces = components.ComponentNamespaces(comps)
 	return comps
 }
 
-func (r *ControlPlaneReconciler) ensureNamespace(ctx context.Context, cp *corev1beta1.ControlPlane) (string, error) {
+// controlPlaneNamespace returns the name of the namespace of the ControlPlane in the core cluster.
+func controlPlaneNamespace(cp *corev1beta1.ControlPlane) string {
+	return shortenToXCharacters(cpNamespacePrefix+cp.Name, cpNamespaceMaxLen)
+}
+
+func (r *ControlPlaneReconciler) ensureNamespace(ctx context.Context, cp *corev1beta1.ControlPlane, name string) error {
 	ns := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: shortenToXCharacters(cpNamespacePrefix+cp.Name, cpNamespaceMaxLen),
+			Name: name,
 		},
 	}
 
@@ -454,7 +787,7 @@ func (r *ControlPlaneReconciler) ensureNamespace(ctx context.Context, cp *corev1
 		utils.SetManagedBy(ns)
 		return controllerutil.SetOwnerReference(cp, ns, r.Scheme)
 	})
-	return ns.Name, err
+	return err
 }
 
 func (r *ControlPlaneReconciler) ensureFinalizer(ctx context.Context, cp *corev1beta1.ControlPlane) error {
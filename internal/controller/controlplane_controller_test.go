@@ -13,6 +13,7 @@ import (
 
 	"github.com/openmcp-project/controller-utils/pkg/clientconfig"
 
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -31,8 +32,12 @@ import (
 	corev1beta1 "github.com/openmcp-project/control-plane-operator/api/v1beta1"
 	"github.com/openmcp-project/control-plane-operator/cmd/options"
 	"github.com/openmcp-project/control-plane-operator/internal/schemes"
+	"github.com/openmcp-project/control-plane-operator/pkg/constants"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/components"
 	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/kubeconfiggen"
 	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/secretresolver"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils/rcontext"
 )
 
 func TestMain(m *testing.M) {
@@ -250,6 +255,67 @@ func TestControlPlaneReconciler_Reconcile(t *testing.T) {
 			expectedResult: ctrl.Result{RequeueAfter: time.Second * 30},
 			expectedErr:    nil,
 		},
+		{
+			desc: "successful ControlPlane dry-run - Crossplane planned but not installed",
+			initObjs: []client.Object{
+				&corev1beta1.ControlPlane{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "some-controlplane",
+						Annotations: map[string]string{
+							constants.AnnotationDryRun: "true",
+						},
+					},
+					Spec: corev1beta1.ControlPlaneSpec{
+						Target: corev1beta1.Target{
+							FluxServiceAccount: corev1beta1.ServiceAccountReference{
+								Name:      "flux-deployer",
+								Namespace: "default",
+							},
+						},
+						ComponentsConfig: corev1beta1.ComponentsConfig{
+							Crossplane: &corev1beta1.CrossplaneConfig{
+								Version: "1.15.0",
+							},
+						},
+					},
+				},
+				&corev1beta1.ReleaseChannel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-releasechannel",
+					},
+					Status: corev1beta1.ReleaseChannelStatus{Components: []corev1beta1.Component{
+						{Name: "crossplane", Versions: []corev1beta1.ComponentVersion{{Version: "1.15.0", HelmRepo: "https://charts.crossplane.io/stable", HelmChart: "crossplane"}}},
+					}},
+				},
+				coSystemNamespace,
+				ocmSecret,
+			},
+			validate: func(t *testing.T, ctx context.Context, c client.Client) error {
+				cp := &corev1beta1.ControlPlane{}
+				if err := c.Get(ctx, client.ObjectKey{Name: "some-controlplane"}, cp); err != nil {
+					return err
+				}
+				assert.Contains(t, cp.Status.Plan, corev1beta1.ComponentPlan{
+					Name:    "Crossplane",
+					Action:  string(juggler.PlanActionInstall),
+					Message: "Crossplane would be installed.",
+				})
+				assert.Nil(t, meta.FindStatusCondition(cp.Status.Conditions, "CrossplaneReady"))
+				helmReleases := &helmv2.HelmReleaseList{}
+				if err := c.List(ctx, helmReleases); err != nil {
+					return err
+				}
+				assert.Empty(t, helmReleases.Items)
+				// nothing is written for the ControlPlane besides its status
+				assert.NotContains(t, cp.Finalizers, corev1beta1.Finalizer)
+				kubeconfig := &corev1.Secret{}
+				err := c.Get(ctx, client.ObjectKey{Name: fluxKubeconfigName, Namespace: "cp-some-controlplane"}, kubeconfig)
+				assert.True(t, apierrors.IsNotFound(err))
+				return nil
+			},
+			expectedResult: ctrl.Result{RequeueAfter: time.Second * 30},
+			expectedErr:    nil,
+		},
 		{
 			desc: "successful ControlPlane deletion - Step 1",
 			initObjs: []client.Object{
@@ -374,3 +440,133 @@ func TestControlPlaneReconciler_Reconcile(t *testing.T) {
 		})
 	}
 }
+
+func TestControlPlaneReconciler_controlPlanesForProfile(t *testing.T) {
+	withProfile := func(name, profile string) *corev1beta1.ControlPlane {
+		cp := &corev1beta1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		cp.Spec.CoreReference.Name = profile
+		return cp
+	}
+	c := fake.NewClientBuilder().WithScheme(schemes.Local).WithObjects(
+		withProfile("a", "default"),
+		withProfile("b", "other"),
+		withProfile("c", "default"),
+	).Build()
+	r := &ControlPlaneReconciler{Client: c}
+
+	profile := &corev1beta1.ControlPlaneProfile{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	requests := r.controlPlanesForProfile(context.Background(), profile)
+	assert.ElementsMatch(t, []ctrl.Request{
+		{NamespacedName: types.NamespacedName{Name: "a"}},
+		{NamespacedName: types.NamespacedName{Name: "c"}},
+	}, requests)
+}
+
+func TestControlPlaneReconciler_controlPlanesForDefinition(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(schemes.Local).WithObjects(
+		&corev1beta1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "a"}},
+		&corev1beta1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "b"}},
+	).Build()
+	r := &ControlPlaneReconciler{Client: c}
+
+	definition := &corev1beta1.ComponentDefinition{ObjectMeta: metav1.ObjectMeta{Name: "kyverno"}}
+	requests := r.controlPlanesForDefinition(context.Background(), definition)
+	assert.ElementsMatch(t, []ctrl.Request{
+		{NamespacedName: types.NamespacedName{Name: "a"}},
+		{NamespacedName: types.NamespacedName{Name: "b"}},
+	}, requests)
+}
+
+func TestControlPlaneReconciler_controlPlanesForValues(t *testing.T) {
+	ref := corev1beta1.ValuesReference{Kind: "Secret", Name: "btp-credentials", Namespace: "default"}
+	withValues := &corev1beta1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "a"}}
+	withValues.Spec.BTPServiceOperator = &corev1beta1.BTPServiceOperatorConfig{ValuesFrom: []corev1beta1.ValuesReference{ref}}
+	withProfileValues := &corev1beta1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "b"}}
+	withProfileValues.Spec.CoreReference.Name = "default"
+	profile := &corev1beta1.ControlPlaneProfile{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	profile.Spec.Kyverno = &corev1beta1.KyvernoConfig{ValuesFrom: []corev1beta1.ValuesReference{ref}}
+	withoutValues := &corev1beta1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "c"}}
+
+	c := fake.NewClientBuilder().WithScheme(schemes.Local).
+		WithObjects(withValues, withProfileValues, withoutValues, profile).
+		WithIndex(&corev1beta1.ControlPlane{}, valuesFromIndex, indexControlPlaneValuesFrom).
+		WithIndex(&corev1beta1.ControlPlaneProfile{}, valuesFromIndex, indexProfileValuesFrom).
+		Build()
+	r := &ControlPlaneReconciler{Client: c}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "btp-credentials", Namespace: "default"}}
+	requests := r.controlPlanesForValues("Secret")(context.Background(), secret)
+	assert.ElementsMatch(t, []ctrl.Request{
+		{NamespacedName: types.NamespacedName{Name: "a"}},
+		{NamespacedName: types.NamespacedName{Name: "b"}},
+	}, requests)
+
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "btp-credentials", Namespace: "default"}}
+	assert.Empty(t, r.controlPlanesForValues("ConfigMap")(context.Background(), cm))
+}
+
+func TestControlPlaneReconciler_controlPlaneComponents(t *testing.T) {
+	cp := &corev1beta1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	cp.Spec.Crossplane = &corev1beta1.CrossplaneConfig{
+		Version: "1.16.0",
+		Providers: []*corev1beta1.CrossplaneProviderConfig{{
+			Name:    "provider-aws-s3",
+			Version: "1.20.0",
+			ProviderConfigs: []corev1beta1.CrossplaneProviderConfigTemplate{{
+				Name:       "default",
+				APIVersion: "aws.upbound.io/v1beta1",
+				SecretRef:  corev1beta1.ProviderConfigSecretReference{Name: "aws-credentials", Key: "credentials"},
+			}},
+		}},
+	}
+	r := &ControlPlaneReconciler{Client: fake.NewClientBuilder().WithScheme(schemes.Local).Build()}
+
+	ctx := rcontext.WithTenantNamespace(context.Background(), "cp-test")
+	var secrets []*components.Secret
+	for _, c := range r.controlPlaneComponents(ctx, cp, nil) {
+		if s, ok := c.(*components.Secret); ok {
+			secrets = append(secrets, s)
+		}
+	}
+
+	// the credentials are read from the namespace of the ControlPlane, ControlPlanes themselves are cluster-scoped
+	assert.Len(t, secrets, 1)
+	assert.Equal(t, types.NamespacedName{Namespace: "cp-test", Name: "aws-credentials"}, secrets[0].Source)
+}
+
+func TestControlPlaneReconciler_addPullSecrets(t *testing.T) {
+	global := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "registry",
+			Namespace: "default",
+			Labels:    map[string]string{constants.LabelCopyToCP: "true"},
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+	}
+	provider := &corev1beta1.CrossplaneProviderConfig{Name: "provider-aws-s3", Version: "1.20.0"}
+	cp := &corev1beta1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	cp.Spec.Crossplane = &corev1beta1.CrossplaneConfig{
+		Version:   "1.16.0",
+		Providers: []*corev1beta1.CrossplaneProviderConfig{provider},
+		ImageConfigs: []corev1beta1.CrossplaneImageConfig{
+			{Name: "mirror", MatchPrefixes: []string{"xpkg.upbound.io"}, PullSecretRef: &corev1.LocalObjectReference{Name: "registry"}},
+			{Name: "private", MatchPrefixes: []string{"registry.example.com"}, PullSecretRef: &corev1.LocalObjectReference{Name: "private"}},
+		},
+	}
+	r := &ControlPlaneReconciler{Client: fake.NewClientBuilder().WithScheme(schemes.Local).WithObjects(global).Build()}
+
+	ctx := rcontext.WithTenantNamespace(context.Background(), "cp-test")
+	comps, err := r.addPullSecrets(ctx, cp)
+	assert.NoError(t, err)
+
+	sources := map[types.NamespacedName]bool{}
+	for _, c := range comps {
+		sources[c.(*components.Secret).Source] = true
+	}
+	assert.Equal(t, map[types.NamespacedName]bool{
+		{Namespace: "default", Name: "registry"}: true,
+		{Namespace: "cp-test", Name: "private"}:  true,
+	}, sources)
+	// the global pull secret is used by all packages, even if an ImageConfig references a pull secret with the same name
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "registry"}}, provider.PackagePullSecrets)
+}
@@ -12,6 +12,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	corev1beta1 "github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/internal/metrics"
 	"github.com/openmcp-project/control-plane-operator/pkg/utils"
 )
 
@@ -49,6 +50,7 @@ func (r *ControlPlaneReconciler) ensureKubeconfig(ctx context.Context, remoteCfg
 
 		if !expired {
 			// kubeconfig is still valid
+			metrics.RecordFluxTokenExpiration(namespace, secretName, expiration)
 			return &corev1.SecretReference{Name: secret.Name, Namespace: secret.Namespace}, nil
 		}
 	}
@@ -74,6 +76,9 @@ func (r *ControlPlaneReconciler) ensureKubeconfig(ctx context.Context, remoteCfg
 		secret.Data[keyExpiration] = []byte(expiration.Format(time.RFC3339))
 		return nil
 	})
+	if err == nil {
+		metrics.RecordFluxTokenExpiration(namespace, secretName, *expiration)
+	}
 
 	return &corev1.SecretReference{Name: secret.Name, Namespace: secret.Namespace}, err
 }
@@ -3,8 +3,10 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	ocmlib "ocm.software/ocm/api/ocm"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -15,6 +17,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/internal/metrics"
 	"github.com/openmcp-project/control-plane-operator/internal/ocm"
 )
 
@@ -23,15 +26,22 @@ type ReleaseChannelReconciler struct {
 	Scheme *runtime.Scheme
 }
 
-func (r *ReleaseChannelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *ReleaseChannelReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	log := log.FromContext(ctx)
 
 	var releasechannel v1beta1.ReleaseChannel
 	if err := r.Get(ctx, req.NamespacedName, &releasechannel); err != nil {
 		log.Error(err, "unable to fetch ReleaseChannel")
+		if apierrors.IsNotFound(err) {
+			metrics.ForgetReleaseChannel(req.Name)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	defer func() {
+		metrics.RecordReleaseChannelSync(req.Name, err == nil, time.Now())
+	}()
+
 	log.Info("Reconciling ReleaseChannel")
 
 	var repo ocmlib.Repository
@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1beta1 "github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+)
+
+// retryBackoff returns the juggler.RetryBackoff for failed components or nil if retries should not be delayed.
+func (r *ControlPlaneReconciler) retryBackoff() juggler.RetryBackoff {
+	if r.ComponentRetryBaseDelay <= 0 {
+		return nil
+	}
+	return juggler.ExponentialBackoff(r.ComponentRetryBaseDelay, max(r.ComponentRetryBaseDelay, r.ComponentRetryMaxDelay))
+}
+
+// retryHistory returns a juggler.RetryHistory based on the retries stored in the status of the ControlPlane.
+func retryHistory(cp *corev1beta1.ControlPlane) juggler.RetryHistory {
+	retries := make(map[string]corev1beta1.ComponentRetryStatus, len(cp.Status.Retries))
+	for _, retry := range cp.Status.Retries {
+		retries[retry.Name] = retry
+	}
+	return func(component juggler.Component) juggler.RetryState {
+		retry, ok := retries[component.GetName()]
+		if !ok {
+			return juggler.RetryState{}
+		}
+		return juggler.RetryState{
+			RetryCount:  retry.RetryCount,
+			NextRetryAt: retry.NextRetryAt.Time,
+			LastError:   retry.LastError,
+			Version:     retry.Version,
+			Enabled:     retry.Enabled,
+		}
+	}
+}
+
+// retryStatus converts the retry states of failed components to be stored in the status of the ControlPlane.
+func retryStatus(results []juggler.ComponentResult) []corev1beta1.ComponentRetryStatus {
+	var retries []corev1beta1.ComponentRetryStatus
+	for _, cr := range results {
+		if cr.Retry.RetryCount == 0 {
+			continue
+		}
+		retries = append(retries, corev1beta1.ComponentRetryStatus{
+			Name:        cr.Component.GetName(),
+			RetryCount:  cr.Retry.RetryCount,
+			NextRetryAt: metav1.NewTime(cr.Retry.NextRetryAt),
+			LastError:   cr.Retry.LastError,
+			Version:     cr.Retry.Version,
+			Enabled:     cr.Retry.Enabled,
+		})
+	}
+	return retries
+}
+
+// requeueAfter returns the ReconcilePeriod or the time until the next component retry, whichever is earlier.
+func (r *ControlPlaneReconciler) requeueAfter(cp *corev1beta1.ControlPlane) time.Duration {
+	requeueAfter := r.ReconcilePeriod
+	for _, retry := range cp.Status.Retries {
+		if d := time.Until(retry.NextRetryAt.Time); d > 0 && d < requeueAfter {
+			requeueAfter = d
+		}
+	}
+	return requeueAfter
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	corev1beta1 "github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/components"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+)
+
+func Test_retryHistory(t *testing.T) {
+	nextRetryAt := metav1.NewTime(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+	cp := &corev1beta1.ControlPlane{
+		Status: corev1beta1.ControlPlaneStatus{
+			Retries: []corev1beta1.ComponentRetryStatus{
+				{Name: components.ComponentNameCrossplane, RetryCount: 2, NextRetryAt: nextRetryAt, LastError: "boom", Version: "1.16.0", Enabled: true},
+			},
+		},
+	}
+
+	history := retryHistory(cp)
+	assert.Equal(t, juggler.RetryState{RetryCount: 2, NextRetryAt: nextRetryAt.Time, LastError: "boom", Version: "1.16.0", Enabled: true}, history(&components.Crossplane{}))
+	assert.Equal(t, juggler.RetryState{}, history(&components.CertManager{}))
+}
+
+func Test_retryStatus(t *testing.T) {
+	nextRetryAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	results := []juggler.ComponentResult{
+		{Component: &components.CertManager{}, Result: juggler.StatusHealthy},
+		{
+			Component: &components.Crossplane{},
+			Result:    juggler.StatusInstallFailed,
+			Retry:     juggler.RetryState{RetryCount: 1, NextRetryAt: nextRetryAt, LastError: "boom", Version: "1.16.0", Enabled: true},
+		},
+	}
+
+	assert.Equal(t, []corev1beta1.ComponentRetryStatus{
+		{Name: components.ComponentNameCrossplane, RetryCount: 1, NextRetryAt: metav1.NewTime(nextRetryAt), LastError: "boom", Version: "1.16.0", Enabled: true},
+	}, retryStatus(results))
+	assert.Nil(t, retryStatus(results[:1]))
+}
+
+func TestControlPlaneReconciler_requeueAfter(t *testing.T) {
+	r := &ControlPlaneReconciler{ReconcilePeriod: time.Minute}
+	tests := []struct {
+		name    string
+		retries []corev1beta1.ComponentRetryStatus
+		wantMax time.Duration
+		wantMin time.Duration
+	}{
+		{
+			name:    "no retries",
+			wantMin: time.Minute,
+			wantMax: time.Minute,
+		},
+		{
+			name: "retry after reconcile period",
+			retries: []corev1beta1.ComponentRetryStatus{
+				{Name: "a", RetryCount: 1, NextRetryAt: metav1.NewTime(time.Now().Add(time.Hour))},
+			},
+			wantMin: time.Minute,
+			wantMax: time.Minute,
+		},
+		{
+			name: "retry before reconcile period",
+			retries: []corev1beta1.ComponentRetryStatus{
+				{Name: "a", RetryCount: 1, NextRetryAt: metav1.NewTime(time.Now().Add(time.Hour))},
+				{Name: "b", RetryCount: 1, NextRetryAt: metav1.NewTime(time.Now().Add(10 * time.Second))},
+				{Name: "c", RetryCount: 1, NextRetryAt: metav1.NewTime(time.Now().Add(-10 * time.Second))},
+			},
+			wantMin: 5 * time.Second,
+			wantMax: 10 * time.Second,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp := &corev1beta1.ControlPlane{Status: corev1beta1.ControlPlaneStatus{Retries: tt.retries}}
+			actual := r.requeueAfter(cp)
+			assert.GreaterOrEqual(t, actual, tt.wantMin)
+			assert.LessOrEqual(t, actual, tt.wantMax)
+		})
+	}
+}
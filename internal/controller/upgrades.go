@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	corev1beta1 "github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/components"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/upgrades"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils/rcontext"
+)
+
+// versionKey identifies the desired version of a component in the ReleaseChannels.
+type versionKey struct {
+	name    string
+	version string
+}
+
+// componentUpgrade describes the automatic upgrade of a component to a newer version.
+type componentUpgrade struct {
+	component string
+	from      string
+	to        string
+}
+
+// withComponentUpgrades wraps the version resolver of the context, so that components with an upgrade policy
+// are reconciled with their effective version instead of the desired version from the spec. Within a maintenance
+// window, the effective version is moved forward to the newest version allowed by the policy. Outside maintenance
+// windows, the version of the previous reconciliation is kept. The upgrades to newer versions are returned.
+func (r *ControlPlaneReconciler) withComponentUpgrades(ctx context.Context, cp *corev1beta1.ControlPlane, definitions components.Definitions, now time.Time) (context.Context, []componentUpgrade) {
+	log := log.FromContext(ctx)
+	rfn := rcontext.VersionResolver(ctx)
+	if rfn == nil {
+		return ctx, nil
+	}
+
+	inWindow, err := upgrades.InMaintenanceWindow(cp.Spec.MaintenanceWindows, now)
+	if err != nil {
+		log.Error(err, "invalid maintenance window")
+	}
+
+	previous := make(map[string]corev1beta1.ComponentStatus, len(cp.Status.Components))
+	for _, cs := range cp.Status.Components {
+		previous[cs.Name] = cs
+	}
+
+	versions := map[versionKey]string{}
+	var componentUpgrades []componentUpgrade
+	for _, c := range r.controlPlaneComponents(ctx, cp, definitions) {
+		uc, ok := c.(components.UpgradableComponent)
+		if !ok || !c.IsEnabled() {
+			continue
+		}
+		if policy := uc.GetUpgradePolicy(); policy == "" || policy == corev1beta1.UpgradePolicyManual {
+			continue
+		}
+
+		available, err := uc.GetAvailableVersions(ctx)
+		if err != nil {
+			log.Error(err, "failed to get available versions", "component", c.GetName())
+			continue
+		}
+		current, err := currentVersion(uc, rfn, previous[c.GetName()], available)
+		if err != nil {
+			log.Error(err, "failed to determine current version", "component", c.GetName())
+			continue
+		}
+		version := current
+		if inWindow {
+			version = upgrades.NextVersion(uc.GetUpgradePolicy(), current, available)
+		}
+		versions[versionKey{name: uc.GetReleaseChannelName(), version: uc.GetVersion()}] = version
+
+		if version != current {
+			componentUpgrades = append(componentUpgrades, componentUpgrade{component: c.GetName(), from: current, to: version})
+		}
+	}
+
+	if len(versions) == 0 {
+		return ctx, nil
+	}
+	return rcontext.WithVersionResolver(ctx, func(componentName string, version string) (corev1beta1.ComponentVersion, error) {
+		if v, ok := versions[versionKey{name: componentName, version: version}]; ok {
+			version = v
+		}
+		return rfn(componentName, version)
+	}), componentUpgrades
+}
+
+// currentVersion returns the version an upgradable component has been reconciled with before.
+// It is the version of the previous reconciliation, unless the desired version has been changed in the meantime
+// or the previous version is no longer available in the ReleaseChannels.
+func currentVersion(uc components.UpgradableComponent, rfn corev1beta1.VersionResolverFn,
+	previous corev1beta1.ComponentStatus, available []string) (string, error) {
+	if previous.DesiredVersion == uc.GetVersion() && slices.Contains(available, previous.ResolvedVersion) {
+		return previous.ResolvedVersion, nil
+	}
+	comp, err := rfn(uc.GetReleaseChannelName(), uc.GetVersion())
+	if err != nil {
+		return "", err
+	}
+	return comp.Version, nil
+}
@@ -0,0 +1,116 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	corev1beta1 "github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/internal/ocm"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/components"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils/rcontext"
+)
+
+func Test_withComponentUpgrades(t *testing.T) {
+	available := []string{"1.15.0", "1.15.3", "1.16.0", "1.16.2", "2.0.0"}
+	ctx := rcontext.WithVersionResolver(context.Background(), func(componentName string, version string) (corev1beta1.ComponentVersion, error) {
+		if !slices.Contains(available, version) {
+			return corev1beta1.ComponentVersion{}, fmt.Errorf("%w: %s", ocm.ErrComponentVersionNotFound, version)
+		}
+		return corev1beta1.ComponentVersion{Version: version}, nil
+	})
+	ctx = rcontext.WithAvailableVersionsResolver(ctx, func(componentName string) ([]string, error) {
+		return available, nil
+	})
+
+	// 2024-01-01 is a Monday
+	inWindow := time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC)
+	outsideWindow := time.Date(2024, 1, 2, 3, 0, 0, 0, time.UTC)
+	windows := []corev1beta1.MaintenanceWindow{{Weekday: "Monday", Start: "02:00", End: "04:00"}}
+
+	newCP := func(policy corev1beta1.UpgradePolicy, previous *corev1beta1.ComponentStatus) *corev1beta1.ControlPlane {
+		cp := &corev1beta1.ControlPlane{}
+		cp.Spec.MaintenanceWindows = windows
+		cp.Spec.Crossplane = &corev1beta1.CrossplaneConfig{Version: "1.15.0", UpgradePolicy: policy}
+		if previous != nil {
+			cp.Status.Components = []corev1beta1.ComponentStatus{*previous}
+		}
+		return cp
+	}
+
+	tests := []struct {
+		name         string
+		cp           *corev1beta1.ControlPlane
+		now          time.Time
+		wantVersion  string
+		wantUpgrades []componentUpgrade
+	}{
+		{
+			name:        "manual",
+			cp:          newCP(corev1beta1.UpgradePolicyManual, nil),
+			now:         inWindow,
+			wantVersion: "1.15.0",
+		},
+		{
+			name:         "patch within window",
+			cp:           newCP(corev1beta1.UpgradePolicyPatch, nil),
+			now:          inWindow,
+			wantVersion:  "1.15.3",
+			wantUpgrades: []componentUpgrade{{component: components.ComponentNameCrossplane, from: "1.15.0", to: "1.15.3"}},
+		},
+		{
+			name: "minor within window",
+			cp: newCP(corev1beta1.UpgradePolicyMinor, &corev1beta1.ComponentStatus{
+				Name: components.ComponentNameCrossplane, DesiredVersion: "1.15.0", ResolvedVersion: "1.15.3",
+			}),
+			now:          inWindow,
+			wantVersion:  "1.16.2",
+			wantUpgrades: []componentUpgrade{{component: components.ComponentNameCrossplane, from: "1.15.3", to: "1.16.2"}},
+		},
+		{
+			name:        "outside window without previous version",
+			cp:          newCP(corev1beta1.UpgradePolicyMinor, nil),
+			now:         outsideWindow,
+			wantVersion: "1.15.0",
+		},
+		{
+			name: "outside window holds previous version",
+			cp: newCP(corev1beta1.UpgradePolicyMinor, &corev1beta1.ComponentStatus{
+				Name: components.ComponentNameCrossplane, DesiredVersion: "1.15.0", ResolvedVersion: "1.15.3",
+			}),
+			now:         outsideWindow,
+			wantVersion: "1.15.3",
+		},
+		{
+			name: "changed desired version resets previous version",
+			cp: newCP(corev1beta1.UpgradePolicyPatch, &corev1beta1.ComponentStatus{
+				Name: components.ComponentNameCrossplane, DesiredVersion: "1.16.0", ResolvedVersion: "1.16.2",
+			}),
+			now:         outsideWindow,
+			wantVersion: "1.15.0",
+		},
+		{
+			name: "previous version is no longer available",
+			cp: newCP(corev1beta1.UpgradePolicyPatch, &corev1beta1.ComponentStatus{
+				Name: components.ComponentNameCrossplane, DesiredVersion: "1.15.0", ResolvedVersion: "1.15.2",
+			}),
+			now:         outsideWindow,
+			wantVersion: "1.15.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ControlPlaneReconciler{}
+			ctx, upgrades := r.withComponentUpgrades(ctx, tt.cp, nil, tt.now)
+			assert.Equal(t, tt.wantUpgrades, upgrades)
+
+			version, err := (&components.Crossplane{Config: tt.cp.Spec.Crossplane}).GetResolvedVersion(ctx)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantVersion, version)
+		})
+	}
+}
@@ -0,0 +1,120 @@
+// Package metrics contains the custom Prometheus metrics of the control-plane-operator.
+// All metrics are registered with the controller-runtime registry and served on the metrics endpoint of the manager.
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+)
+
+const namespace = "control_plane_operator"
+
+var (
+	componentStatus = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "component_status",
+		Help:      "Result of the last reconciliation of a component. The value is always 1, the result is given by the status label.",
+	}, []string{"controlplane", "component", "kind", "status"})
+
+	componentsEnabled = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "components_enabled",
+		Help:      "Number of enabled components of a ControlPlane.",
+	}, []string{"controlplane"})
+
+	componentsHealthy = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "components_healthy",
+		Help:      "Number of healthy components of a ControlPlane.",
+	}, []string{"controlplane"})
+
+	reconcilePhaseDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "component_reconcile_phase_duration_seconds",
+		Help:      "Duration of the phases (observe, hook, install, update, uninstall) of the reconciliation of a component.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 12),
+	}, []string{"kind", "phase"})
+
+	releaseChannelSyncSuccess = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "release_channel_sync_success",
+		Help:      "Whether the last synchronization of a ReleaseChannel succeeded (1) or failed (0).",
+	}, []string{"releasechannel"})
+
+	releaseChannelLastSync = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "release_channel_last_sync_timestamp_seconds",
+		Help:      "Unix timestamp of the last successful synchronization of a ReleaseChannel.",
+	}, []string{"releasechannel"})
+
+	fluxTokenExpiration = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "flux_token_expiration_timestamp_seconds",
+		Help:      "Unix timestamp at which the token in the Flux kubeconfig of a ControlPlane expires.",
+	}, []string{"namespace", "secret"})
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		componentStatus,
+		componentsEnabled,
+		componentsHealthy,
+		reconcilePhaseDuration,
+		releaseChannelSyncSuccess,
+		releaseChannelLastSync,
+		fluxTokenExpiration,
+	)
+}
+
+// RecordComponents records the results of the reconciliation of the components of a ControlPlane.
+// Components that are disabled and not installed are omitted.
+func RecordComponents(controlPlane string, results []juggler.ComponentResult, enabled, healthy int) {
+	componentStatus.DeletePartialMatch(prometheus.Labels{"controlplane": controlPlane})
+	for _, cr := range results {
+		if !cr.Component.IsEnabled() && cr.Result == juggler.StatusDisabled {
+			continue
+		}
+		componentStatus.WithLabelValues(controlPlane, cr.Component.GetName(), cr.Kind(), cr.Result.Name).Set(1)
+	}
+	componentsEnabled.WithLabelValues(controlPlane).Set(float64(enabled))
+	componentsHealthy.WithLabelValues(controlPlane).Set(float64(healthy))
+}
+
+// ForgetControlPlane removes all metrics of a deleted ControlPlane.
+// `tenantNamespace` is the namespace that contains the resources related to the ControlPlane.
+func ForgetControlPlane(controlPlane, tenantNamespace string) {
+	componentStatus.DeletePartialMatch(prometheus.Labels{"controlplane": controlPlane})
+	componentsEnabled.DeleteLabelValues(controlPlane)
+	componentsHealthy.DeleteLabelValues(controlPlane)
+	fluxTokenExpiration.DeletePartialMatch(prometheus.Labels{"namespace": tenantNamespace})
+}
+
+// ObservePhase implements juggler.PhaseObserver.
+func ObservePhase(component juggler.Component, phase juggler.ReconcilePhase, duration time.Duration) {
+	reconcilePhaseDuration.WithLabelValues(juggler.ComponentKind(component), string(phase)).Observe(duration.Seconds())
+}
+
+// RecordReleaseChannelSync records the result of the synchronization of a ReleaseChannel.
+func RecordReleaseChannelSync(releaseChannel string, success bool, now time.Time) {
+	if !success {
+		releaseChannelSyncSuccess.WithLabelValues(releaseChannel).Set(0)
+		return
+	}
+	releaseChannelSyncSuccess.WithLabelValues(releaseChannel).Set(1)
+	releaseChannelLastSync.WithLabelValues(releaseChannel).Set(float64(now.Unix()))
+}
+
+// ForgetReleaseChannel removes all metrics of a deleted ReleaseChannel.
+func ForgetReleaseChannel(releaseChannel string) {
+	releaseChannelSyncSuccess.DeleteLabelValues(releaseChannel)
+	releaseChannelLastSync.DeleteLabelValues(releaseChannel)
+}
+
+// RecordFluxTokenExpiration records the expiration of the token in a Flux kubeconfig secret.
+func RecordFluxTokenExpiration(namespace, secret string, expiration time.Time) {
+	fluxTokenExpiration.WithLabelValues(namespace, secret).Set(float64(expiration.Unix()))
+}
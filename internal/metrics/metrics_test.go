@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/components"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+)
+
+func TestRecordComponents(t *testing.T) {
+	clusterRole := &components.ClusterRole{Name: "admin", Enabled: true}
+	results := []juggler.ComponentResult{
+		{Component: &components.Crossplane{}, Result: juggler.StatusDisabled},
+		{Component: clusterRole, Result: juggler.StatusUnhealthy},
+	}
+	RecordComponents("test", results, 1, 0)
+
+	expected := `
+# HELP control_plane_operator_component_status Result of the last reconciliation of a component. The value is always 1, the result is given by the status label.
+# TYPE control_plane_operator_component_status gauge
+control_plane_operator_component_status{component="` + clusterRole.GetName() + `",controlplane="test",kind="ClusterRole",status="Unhealthy"} 1
+`
+	assert.NoError(t, testutil.CollectAndCompare(componentStatus, strings.NewReader(expected)))
+	assert.Equal(t, float64(1), testutil.ToFloat64(componentsEnabled.WithLabelValues("test")))
+	assert.Equal(t, float64(0), testutil.ToFloat64(componentsHealthy.WithLabelValues("test")))
+
+	// a changed status replaces the previous one
+	results[1].Result = juggler.StatusHealthy
+	RecordComponents("test", results, 1, 1)
+	assert.Equal(t, 1, testutil.CollectAndCount(componentStatus))
+	assert.Equal(t, float64(1), testutil.ToFloat64(componentStatus.WithLabelValues("test", clusterRole.GetName(), "ClusterRole", "Healthy")))
+
+	RecordFluxTokenExpiration("cp-test", "flux-kubeconfig", time.Now())
+	ForgetControlPlane("test", "cp-test")
+	assert.Equal(t, 0, testutil.CollectAndCount(componentStatus))
+	assert.Equal(t, 0, testutil.CollectAndCount(componentsEnabled))
+	assert.Equal(t, 0, testutil.CollectAndCount(componentsHealthy))
+	assert.Equal(t, 0, testutil.CollectAndCount(fluxTokenExpiration))
+}
+
+func TestObservePhase(t *testing.T) {
+	ObservePhase(&components.Crossplane{}, juggler.PhaseInstall, time.Second)
+	assert.Equal(t, 1, testutil.CollectAndCount(reconcilePhaseDuration))
+}
+
+func TestRecordReleaseChannelSync(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	RecordReleaseChannelSync("stable", true, now)
+	assert.Equal(t, float64(1), testutil.ToFloat64(releaseChannelSyncSuccess.WithLabelValues("stable")))
+	assert.Equal(t, float64(now.Unix()), testutil.ToFloat64(releaseChannelLastSync.WithLabelValues("stable")))
+
+	RecordReleaseChannelSync("stable", false, now.Add(time.Hour))
+	assert.Equal(t, float64(0), testutil.ToFloat64(releaseChannelSyncSuccess.WithLabelValues("stable")))
+	assert.Equal(t, float64(now.Unix()), testutil.ToFloat64(releaseChannelLastSync.WithLabelValues("stable")))
+
+	ForgetReleaseChannel("stable")
+	assert.Equal(t, 0, testutil.CollectAndCount(releaseChannelSyncSuccess))
+	assert.Equal(t, 0, testutil.CollectAndCount(releaseChannelLastSync))
+}
@@ -7,6 +7,7 @@ import (
 	"os"
 	"strings"
 
+	"go.opentelemetry.io/otel/attribute"
 	corev1 "k8s.io/api/core/v1"
 	"ocm.software/ocm/api/ocm"
 	"ocm.software/ocm/api/ocm/extensions/accessmethods/helm"
@@ -21,6 +22,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/internal/tracing"
 )
 
 // Create an ocm.Repository entity out of an url specified in the ocmRegistry parameter.
@@ -121,7 +123,12 @@ func GetOCMLocalRepo(ocmRegistry []byte, prefixFilter string) (ocm.Repository, [
 //
 // The prefixFilter must be specified as it will be cut off every componentName in the end
 // so the resulting Component names are without it.
-func GetOCMComponentsWithVersions(ctx context.Context, repo ocm.Repository, components []string, prefixFilter string) ([]v1beta1.Component, error) {
+func GetOCMComponentsWithVersions(ctx context.Context, repo ocm.Repository, components []string, prefixFilter string) (_ []v1beta1.Component, err error) {
+	ctx, span := tracing.Start(ctx, "ocm.GetOCMComponentsWithVersions", attribute.Int("ocm.components", len(components)))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	log := log.FromContext(ctx)
 
 	octx := ocm.DefaultContext()
@@ -172,10 +179,15 @@ func GetOCMComponentsWithVersions(ctx context.Context, repo ocm.Repository, comp
 					return nil, err
 				}
 
-				comp.Versions = append(comp.Versions, v1beta1.ComponentVersion{
+				componentVersion := v1beta1.ComponentVersion{
 					Version:   version,
 					DockerRef: ref,
-				})
+				}
+				// Helm charts can also be stored as OCI artifacts
+				if resources[0].Meta().Type == helmChartResourceType {
+					componentVersion.OCIURL, componentVersion.HelmChart = helmChartFromOCIRef(ref)
+				}
+				comp.Versions = append(comp.Versions, componentVersion)
 			case helm.Type:
 				accessSpec, ok := access.(*helm.AccessSpec)
 				if !ok {
@@ -187,6 +199,7 @@ func GetOCMComponentsWithVersions(ctx context.Context, repo ocm.Repository, comp
 					Version:   version,
 					HelmRepo:  accessSpec.HelmRepository,
 					HelmChart: chartname,
+					OCIURL:    helmChartOCIURL(accessSpec.HelmRepository, chartname),
 				})
 			default:
 				return nil, errors.New("unsupported access method")
@@ -203,14 +216,23 @@ var ErrComponentVersionNotFound = errors.New("component version not found")
 
 // GetOCMComponent takes a component name and a version as input and searches in an OCM registry if the component
 // with version is available. The function returns a Component object with the repository and version of the component.
+// If the version is a constraint (see IsVersionConstraint), the highest matching version is returned.
 func GetOCMComponent(
 	ctx context.Context,
 	client client.Client,
 	componentName string,
 	version string,
-) (v1beta1.ComponentVersion, error) {
+) (_ v1beta1.ComponentVersion, err error) {
+	ctx, span := tracing.Start(ctx, "ocm.GetOCMComponent",
+		attribute.String("ocm.component", componentName),
+		attribute.String("ocm.version", version),
+	)
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	releasechannels := v1beta1.ReleaseChannelList{}
-	err := client.List(ctx, &releasechannels)
+	err = client.List(ctx, &releasechannels)
 	if err != nil {
 		return v1beta1.ComponentVersion{}, err
 	}
@@ -228,6 +250,14 @@ func GetOCMComponent(
 		}
 	}
 
+	if IsVersionConstraint(version) {
+		resolved, err := ResolveVersion(ctx, client, componentName, version)
+		if err != nil {
+			return v1beta1.ComponentVersion{}, err
+		}
+		return GetOCMComponent(ctx, client, componentName, resolved)
+	}
+
 	return v1beta1.ComponentVersion{}, fmt.Errorf("%w: component %s with version %s", ErrComponentVersionNotFound, componentName, version)
 }
 
@@ -235,9 +265,14 @@ func GetOCMComponentAvailableVersions(
 	ctx context.Context,
 	client client.Client,
 	componentName string,
-) ([]string, error) {
+) (_ []string, err error) {
+	ctx, span := tracing.Start(ctx, "ocm.GetOCMComponentAvailableVersions", attribute.String("ocm.component", componentName))
+	defer func() {
+		tracing.End(span, err)
+	}()
+
 	releasechannels := v1beta1.ReleaseChannelList{}
-	err := client.List(ctx, &releasechannels)
+	err = client.List(ctx, &releasechannels)
 	if err != nil {
 		return nil, err
 	}
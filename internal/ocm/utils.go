@@ -72,3 +72,31 @@ func GetRepositoriesInOCIRegistry(ociRegistry string, creds RegistryCredentials,
 
 	return filteredRepositories, nil
 }
+
+const (
+	ociScheme = "oci://"
+	// helmChartResourceType is the OCM resource type of Helm charts.
+	helmChartResourceType = "helmChart"
+)
+
+// helmChartFromOCIRef returns the OCI URL without tag or digest and the name of a Helm chart
+// from the reference of the OCI artifact, e.g. `ghcr.io/org/charts/crossplane:1.16.0`.
+func helmChartFromOCIRef(ref string) (url string, chart string) {
+	ref = strings.TrimPrefix(ref, ociScheme)
+	if i := strings.Index(ref, "@"); i >= 0 {
+		ref = ref[:i]
+	}
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		ref = ref[:i]
+	}
+	return ociScheme + ref, ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// helmChartOCIURL returns the OCI URL of a chart in a Helm repository, or an empty string if the
+// repository is not stored in an OCI registry.
+func helmChartOCIURL(repository, chart string) string {
+	if !strings.HasPrefix(repository, ociScheme) {
+		return ""
+	}
+	return strings.TrimSuffix(repository, "/") + "/" + chart
+}
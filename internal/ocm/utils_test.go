@@ -0,0 +1,33 @@
+package ocm
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_helmChartFromOCIRef(t *testing.T) {
+	tests := []struct {
+		ref       string
+		wantURL   string
+		wantChart string
+	}{
+		{ref: "ghcr.io/org/charts/crossplane:1.16.0", wantURL: "oci://ghcr.io/org/charts/crossplane", wantChart: "crossplane"},
+		{ref: "oci://ghcr.io/org/charts/crossplane:1.16.0", wantURL: "oci://ghcr.io/org/charts/crossplane", wantChart: "crossplane"},
+		{ref: "localhost:5000/crossplane@sha256:abc", wantURL: "oci://localhost:5000/crossplane", wantChart: "crossplane"},
+		{ref: "localhost:5000/charts/crossplane:1.16.0@sha256:abc", wantURL: "oci://localhost:5000/charts/crossplane", wantChart: "crossplane"},
+		{ref: "localhost:5000/charts/crossplane", wantURL: "oci://localhost:5000/charts/crossplane", wantChart: "crossplane"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			url, chart := helmChartFromOCIRef(tt.ref)
+			assert.Equal(t, tt.wantURL, url)
+			assert.Equal(t, tt.wantChart, chart)
+		})
+	}
+}
+
+func Test_helmChartOCIURL(t *testing.T) {
+	assert.Equal(t, "", helmChartOCIURL("https://charts.crossplane.io/stable", "crossplane"))
+	assert.Equal(t, "oci://ghcr.io/org/charts/crossplane", helmChartOCIURL("oci://ghcr.io/org/charts/", "crossplane"))
+}
@@ -0,0 +1,66 @@
+package ocm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Masterminds/semver/v3"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// VersionLatest is a version constraint that matches the highest available (non-prerelease) version.
+const VersionLatest = "latest"
+
+// IsVersionConstraint returns if the version is a constraint (e.g. `~1.16`, `>=1.3 <2` or `latest`)
+// instead of an exact version.
+func IsVersionConstraint(version string) bool {
+	if version == VersionLatest {
+		return true
+	}
+	if _, err := semver.NewVersion(version); err == nil {
+		return false
+	}
+	_, err := semver.NewConstraint(version)
+	return err == nil
+}
+
+// ResolveVersion resolves a version constraint to the highest matching version of the component
+// as returned by GetOCMComponentAvailableVersions.
+func ResolveVersion(ctx context.Context, client client.Client, componentName string, constraint string) (string, error) {
+	versions, err := GetOCMComponentAvailableVersions(ctx, client, componentName)
+	if err != nil {
+		return "", err
+	}
+
+	resolved, ok := highestMatchingVersion(versions, constraint)
+	if !ok {
+		return "", fmt.Errorf("%w: component %s with version matching %s", ErrComponentVersionNotFound, componentName, constraint)
+	}
+	return resolved, nil
+}
+
+// highestMatchingVersion returns the highest of the versions that matches the constraint.
+// Versions that are not valid semantic versions are ignored.
+func highestMatchingVersion(versions []string, constraint string) (string, bool) {
+	if constraint == VersionLatest {
+		constraint = "*"
+	}
+	c, err := semver.NewConstraint(constraint)
+	if err != nil {
+		return "", false
+	}
+
+	var highest *semver.Version
+	var resolved string
+	for _, version := range versions {
+		v, err := semver.NewVersion(version)
+		if err != nil || !c.Check(v) {
+			continue
+		}
+		if highest == nil || v.GreaterThan(highest) {
+			highest = v
+			resolved = version
+		}
+	}
+	return resolved, highest != nil
+}
@@ -0,0 +1,81 @@
+package ocm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1beta1 "github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/internal/schemes"
+)
+
+func TestIsVersionConstraint(t *testing.T) {
+	tests := []struct {
+		version string
+		want    bool
+	}{
+		{version: "1.16.2", want: false},
+		{version: "v1.16.2", want: false},
+		{version: "1.16", want: false},
+		{version: "~1.16", want: true},
+		{version: "^1.16.0", want: true},
+		{version: ">=1.3 <2", want: true},
+		{version: "1.x", want: true},
+		{version: "latest", want: true},
+		{version: "not a version", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.version, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsVersionConstraint(tt.version))
+		})
+	}
+}
+
+func TestResolveVersion(t *testing.T) {
+	releaseChannel := &corev1beta1.ReleaseChannel{
+		ObjectMeta: metav1.ObjectMeta{Name: "stable"},
+		Status: corev1beta1.ReleaseChannelStatus{Components: []corev1beta1.Component{
+			{
+				Name: "crossplane",
+				Versions: []corev1beta1.ComponentVersion{
+					{Version: "1.15.3", HelmRepo: "https://charts.crossplane.io/stable", HelmChart: "crossplane"},
+					{Version: "1.16.0", HelmRepo: "https://charts.crossplane.io/stable", HelmChart: "crossplane"},
+					{Version: "1.16.2", HelmRepo: "https://charts.crossplane.io/stable", HelmChart: "crossplane"},
+					{Version: "2.0.0-rc.1", HelmRepo: "https://charts.crossplane.io/stable", HelmChart: "crossplane"},
+				},
+			},
+		}},
+	}
+	c := fake.NewClientBuilder().WithObjects(releaseChannel).WithScheme(schemes.Local).Build()
+
+	tests := []struct {
+		constraint string
+		want       string
+		wantErr    bool
+	}{
+		{constraint: "~1.16", want: "1.16.2"},
+		{constraint: ">=1.3 <1.16", want: "1.15.3"},
+		{constraint: "latest", want: "1.16.2"},
+		{constraint: ">=2.0.0-rc.0", want: "2.0.0-rc.1"},
+		{constraint: "^3", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			got, err := ResolveVersion(context.Background(), c, "crossplane", tt.constraint)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrComponentVersionNotFound)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+
+			// GetOCMComponent resolves constraints in the same way
+			comp, err := GetOCMComponent(context.Background(), c, "crossplane", tt.constraint)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, comp.Version)
+		})
+	}
+}
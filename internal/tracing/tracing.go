@@ -0,0 +1,111 @@
+// Package tracing configures OpenTelemetry tracing for the control-plane-operator.
+// Spans are only recorded for ControlPlanes that have telemetry enabled (see WithEnabled).
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	instrumentationName = "github.com/openmcp-project/control-plane-operator"
+	serviceName         = "control-plane-operator"
+)
+
+type enabledKey struct{}
+
+// WithEnabled returns a context that states whether traces should be recorded.
+// Spans that are started without a parent are only sampled if enabled.
+func WithEnabled(ctx context.Context, enabled bool) context.Context {
+	return context.WithValue(ctx, enabledKey{}, enabled)
+}
+
+// IsEnabled returns whether traces should be recorded for the given context.
+func IsEnabled(ctx context.Context) bool {
+	enabled, _ := ctx.Value(enabledKey{}).(bool)
+	return enabled
+}
+
+// Setup configures the global TracerProvider to export spans via OTLP/gRPC to `endpoint`.
+// Tracing stays disabled if `endpoint` is empty. The returned function flushes and stops the exporter.
+func Setup(ctx context.Context, endpoint string, insecure bool) (func(context.Context) error, error) {
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(endpoint)}
+	if insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithSampler(Sampler()),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	return provider.Shutdown, nil
+}
+
+// Sampler returns a sampler that samples root spans if the context is enabled (see WithEnabled)
+// and follows the decision of the parent otherwise.
+func Sampler() sdktrace.Sampler {
+	return sdktrace.ParentBased(contextSampler{})
+}
+
+type contextSampler struct{}
+
+// ShouldSample implements sdktrace.Sampler.
+func (contextSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	decision := sdktrace.Drop
+	if IsEnabled(p.ParentContext) {
+		decision = sdktrace.RecordAndSample
+	}
+	return sdktrace.SamplingResult{
+		Decision:   decision,
+		Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+// Description implements sdktrace.Sampler.
+func (contextSampler) Description() string {
+	return "ControlPlaneTelemetrySampler"
+}
+
+// Start starts a new span using the global TracerProvider.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End records `err` (if any) and ends the span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// InstrumentConfig adds a span for every request that is sent with the given rest.Config.
+func InstrumentConfig(cfg *rest.Config) {
+	cfg.Wrap(func(rt http.RoundTripper) http.RoundTripper {
+		return otelhttp.NewTransport(rt)
+	})
+}
@@ -0,0 +1,111 @@
+package tracing
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	collectortrace "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+)
+
+// fakeCollector is a stand-in for an OpenTelemetry Collector that records the names of all received spans.
+type fakeCollector struct {
+	collectortrace.UnimplementedTraceServiceServer
+
+	mu    sync.Mutex
+	spans []string
+}
+
+func (c *fakeCollector) Export(_ context.Context, req *collectortrace.ExportTraceServiceRequest) (*collectortrace.ExportTraceServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, rs := range req.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			for _, span := range ss.Spans {
+				c.spans = append(c.spans, span.Name)
+			}
+		}
+	}
+	return &collectortrace.ExportTraceServiceResponse{}, nil
+}
+
+func (c *fakeCollector) received() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.spans
+}
+
+func TestSetup(t *testing.T) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	collector := &fakeCollector{}
+	server := grpc.NewServer()
+	collectortrace.RegisterTraceServiceServer(server, collector)
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	previous := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(previous)
+
+	ctx := context.Background()
+	shutdown, err := Setup(ctx, lis.Addr().String(), true)
+	require.NoError(t, err)
+
+	enabledCtx, parent := Start(WithEnabled(ctx, true), "enabled")
+	_, child := Start(enabledCtx, "child")
+	child.End()
+	parent.End()
+	_, span := Start(WithEnabled(ctx, false), "disabled")
+	span.End()
+	_, span = Start(ctx, "unset")
+	span.End()
+
+	require.NoError(t, shutdown(ctx))
+	assert.ElementsMatch(t, []string{"enabled", "child"}, collector.received())
+}
+
+func TestSetup_NoEndpoint(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	shutdown, err := Setup(context.Background(), "", false)
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+	assert.Equal(t, previous, otel.GetTracerProvider())
+}
+
+func TestSampler(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSampler(Sampler()), sdktrace.WithSpanProcessor(recorder))
+	tracer := provider.Tracer("test")
+
+	ctx, parent := tracer.Start(WithEnabled(context.Background(), true), "parent")
+	// children follow the decision of their parent
+	_, child := tracer.Start(WithEnabled(ctx, false), "child")
+	child.End()
+	parent.End()
+	_, span := tracer.Start(context.Background(), "other")
+	span.End()
+
+	names := []string{}
+	for _, s := range recorder.Ended() {
+		names = append(names, s.Name())
+	}
+	assert.Equal(t, []string{"child", "parent"}, names)
+}
+
+func TestEnd(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	_, span := provider.Tracer("test").Start(context.Background(), "failed")
+	End(span, assert.AnError)
+
+	require.Len(t, recorder.Ended(), 1)
+	assert.Equal(t, assert.AnError.Error(), recorder.Ended()[0].Status().Description)
+}
@@ -0,0 +1,414 @@
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	corev1beta1 "github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/internal/ocm"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/components"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/crossplane"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/profiles"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/upgrades"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/valuesfrom"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils/rcontext"
+)
+
+// SetupControlPlaneWebhookWithManager registers the webhooks for ControlPlanes with the manager.
+// `valuesFromNamespaces` are the namespaces the `valuesFrom` of components may reference,
+// `telemetryExporterEndpoint` is the default exporter endpoint of telemetry.
+func SetupControlPlaneWebhookWithManager(mgr ctrl.Manager, valuesFromNamespaces []string, telemetryExporterEndpoint string) error {
+	return ctrl.NewWebhookManagedBy(mgr, &corev1beta1.ControlPlane{}).
+		WithValidator(&ControlPlaneCustomValidator{
+			Client:                    mgr.GetClient(),
+			ValuesFromNamespaces:      valuesFromNamespaces,
+			TelemetryExporterEndpoint: telemetryExporterEndpoint,
+		}).
+		Complete()
+}
+
+// ControlPlaneCustomValidator validates ControlPlanes when they are created or updated.
+// It reads ReleaseChannels and the CrossplanePackageRestriction with the given client.
+type ControlPlaneCustomValidator struct {
+	Client client.Client
+	// ValuesFromNamespaces are the namespaces the `valuesFrom` of components may reference ConfigMaps and Secrets in.
+	ValuesFromNamespaces []string
+	// TelemetryExporterEndpoint is the exporter endpoint of telemetry if the ControlPlane does not configure one.
+	TelemetryExporterEndpoint string
+}
+
+var _ admission.Validator[*corev1beta1.ControlPlane] = &ControlPlaneCustomValidator{}
+
+// ValidateCreate implements admission.Validator.
+func (v *ControlPlaneCustomValidator) ValidateCreate(ctx context.Context, cp *corev1beta1.ControlPlane) (admission.Warnings, error) {
+	return nil, v.validate(ctx, nil, cp)
+}
+
+// ValidateUpdate implements admission.Validator.
+func (v *ControlPlaneCustomValidator) ValidateUpdate(ctx context.Context, oldCP, newCP *corev1beta1.ControlPlane) (admission.Warnings, error) {
+	return nil, v.validate(ctx, oldCP, newCP)
+}
+
+// ValidateDelete implements admission.Validator.
+func (v *ControlPlaneCustomValidator) ValidateDelete(ctx context.Context, cp *corev1beta1.ControlPlane) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validate validates the ControlPlane. `oldCP` is nil on creation.
+func (v *ControlPlaneCustomValidator) validate(ctx context.Context, oldCP, cp *corev1beta1.ControlPlane) error {
+	// ControlPlanes that are being deleted must not be blocked, e.g. when finalizers are removed.
+	if !cp.DeletionTimestamp.IsZero() {
+		return nil
+	}
+
+	// the ControlPlanes are validated with the defaults of their profile
+	profile, err := profiles.Get(ctx, v.Client, cp)
+	if err != nil {
+		return err
+	}
+	cp = withProfile(profile, cp)
+	if oldCP != nil {
+		oldCP = withProfile(profile, oldCP)
+	}
+
+	// the ComponentDefinitions adjust the built-in components and are referenced by additional components
+	definitions, err := components.ListDefinitions(ctx, v.Client)
+	if err != nil {
+		return err
+	}
+
+	specPath := field.NewPath("spec")
+	allErrs := field.ErrorList{}
+
+	if oldCP != nil && !equality.Semantic.DeepEqual(oldCP.Spec.Target, cp.Spec.Target) {
+		allErrs = append(allErrs, field.Forbidden(specPath.Child("target"), "field is immutable"))
+	}
+
+	for i, w := range cp.Spec.MaintenanceWindows {
+		if err := upgrades.Validate(w); err != nil {
+			allErrs = append(allErrs, field.Invalid(specPath.Child("maintenanceWindows").Index(i), w, err.Error()))
+		}
+	}
+
+	oldVersions := map[string]string{}
+	if oldCP != nil {
+		for _, cf := range v.componentFields(specPath, &oldCP.Spec, definitions) {
+			oldVersions[cf.path.String()] = cf.version
+		}
+	}
+	for _, cf := range v.componentFields(specPath, &cp.Spec, definitions) {
+		allErrs = append(allErrs, validateValues(cf.path.Child("values"), cf.values)...)
+		allErrs = append(allErrs, v.validateValuesFrom(cf.path.Child("valuesFrom"), cf.valuesFrom)...)
+
+		// Only changed versions are validated, so that ControlPlanes with versions that have
+		// been removed from the ReleaseChannels after their installation can still be updated.
+		if oldVersion, ok := oldVersions[cf.path.String()]; ok && oldVersion == cf.version {
+			continue
+		}
+		allErrs = append(allErrs, v.validateVersion(ctx, cf)...)
+	}
+
+	// telemetry is not installed without a version, so that it is never upgraded implicitly
+	if c := cp.Spec.Telemetry; c != nil && c.Enabled && c.Version == "" {
+		allErrs = append(allErrs, field.Required(specPath.Child("telemetry", "version"), "must be set if telemetry is enabled"))
+	}
+
+	if cp.Spec.Crossplane != nil {
+		allErrs = append(allErrs, validateProviderConfigNames(specPath.Child("crossplane", "providers"), cp.Spec.Crossplane.Providers)...)
+		for _, pt := range crossplane.PackageTypes {
+			packagesPath := specPath.Child("crossplane", string(pt))
+			packages := crossplanePackages(cp.Spec.Crossplane, pt)
+			allErrs = append(allErrs, validatePackageNames(packagesPath, packages)...)
+			allErrs = append(allErrs, v.validatePackages(ctx, packagesPath, profiles.CrossplanePackageRestrictionName(profile), pt, packages)...)
+		}
+	}
+
+	if len(allErrs) == 0 {
+		return nil
+	}
+	return apierrors.NewInvalid(corev1beta1.GroupVersion.WithKind("ControlPlane").GroupKind(), cp.Name, allErrs)
+}
+
+// withProfile returns a copy of the ControlPlane with the defaults of the profile.
+// If the defaults cannot be merged, e.g. due to invalid values, the ControlPlane is returned unchanged.
+func withProfile(profile *corev1beta1.ControlPlaneProfile, cp *corev1beta1.ControlPlane) *corev1beta1.ControlPlane {
+	merged := cp.DeepCopy()
+	if err := profiles.Apply(profile, merged); err != nil {
+		return cp
+	}
+	return merged
+}
+
+// componentField describes the configuration of a single component in the spec of a ControlPlane.
+type componentField struct {
+	path       *field.Path
+	component  juggler.Component
+	version    string
+	values     *apiextensionsv1.JSON
+	valuesFrom []corev1beta1.ValuesReference
+	// definition is the ComponentDefinition referenced by an additional component.
+	definition string
+}
+
+// componentFields returns all configured components of the ControlPlane.
+func (v *ControlPlaneCustomValidator) componentFields(specPath *field.Path, spec *corev1beta1.ControlPlaneSpec, definitions components.Definitions) []componentField {
+	cc := &spec.ComponentsConfig
+	fields := []componentField{}
+	if c := cc.Crossplane; c != nil {
+		fields = append(fields, componentField{
+			path:       specPath.Child("crossplane"),
+			component:  &components.Crossplane{Config: c, Definitions: definitions},
+			version:    c.Version,
+			values:     c.Values,
+			valuesFrom: c.ValuesFrom,
+		})
+		for i, p := range c.Providers {
+			fields = append(fields, componentField{
+				path:      specPath.Child("crossplane", "providers").Index(i),
+				component: &components.CrossplaneProvider{Config: p, Enabled: true},
+				version:   p.Version,
+			})
+		}
+		for i, p := range c.Configurations {
+			fields = append(fields, componentField{
+				path:      specPath.Child("crossplane", "configurations").Index(i),
+				component: &components.CrossplaneConfiguration{Config: p, Enabled: true},
+				version:   p.Version,
+			})
+		}
+		for i, p := range c.Functions {
+			fields = append(fields, componentField{
+				path:      specPath.Child("crossplane", "functions").Index(i),
+				component: &components.CrossplaneFunction{Config: p, Enabled: true},
+				version:   p.Version,
+			})
+		}
+	}
+	if c := cc.BTPServiceOperator; c != nil {
+		fields = append(fields, componentField{
+			path:       specPath.Child("btpServiceOperator"),
+			component:  &components.BTPServiceOperator{Config: c, Definitions: definitions},
+			version:    c.Version,
+			values:     c.Values,
+			valuesFrom: c.ValuesFrom,
+		})
+	}
+	if c := cc.CertManager; c != nil {
+		fields = append(fields, componentField{
+			path:       specPath.Child("certManager"),
+			component:  &components.CertManager{Config: c, Definitions: definitions},
+			version:    c.Version,
+			values:     c.Values,
+			valuesFrom: c.ValuesFrom,
+		})
+	}
+	if c := cc.ExternalSecretsOperator; c != nil {
+		fields = append(fields, componentField{
+			path:       specPath.Child("externalSecretsOperator"),
+			component:  &components.ExternalSecretsOperator{Config: c, Definitions: definitions},
+			version:    c.Version,
+			values:     c.Values,
+			valuesFrom: c.ValuesFrom,
+		})
+	}
+	if c := cc.Kyverno; c != nil {
+		fields = append(fields, componentField{
+			path:       specPath.Child("kyverno"),
+			component:  &components.Kyverno{Config: c, Definitions: definitions},
+			version:    c.Version,
+			values:     c.Values,
+			valuesFrom: c.ValuesFrom,
+		})
+	}
+	if c := cc.Flux; c != nil {
+		fields = append(fields, componentField{
+			path:       specPath.Child("flux"),
+			component:  &components.Flux{Config: c, Definitions: definitions},
+			version:    c.Version,
+			values:     c.Values,
+			valuesFrom: c.ValuesFrom,
+		})
+	}
+	if c := spec.Telemetry; c != nil {
+		fields = append(fields, componentField{
+			path:       specPath.Child("telemetry"),
+			component:  &components.Telemetry{Config: c, DefaultEndpoint: v.TelemetryExporterEndpoint, Definitions: definitions},
+			version:    c.Version,
+			values:     c.Values,
+			valuesFrom: c.ValuesFrom,
+		})
+	}
+	for i := range cc.AdditionalComponents {
+		c := &cc.AdditionalComponents[i]
+		fields = append(fields, componentField{
+			path:       specPath.Child("additionalComponents").Index(i),
+			component:  &components.AdditionalComponent{Config: c, Definitions: definitions},
+			version:    c.Version,
+			values:     c.Values,
+			valuesFrom: c.ValuesFrom,
+			definition: c.Definition,
+		})
+	}
+	return fields
+}
+
+// resolveVersion looks up a component version in the ReleaseChannels.
+func (v *ControlPlaneCustomValidator) resolveVersion(ctx context.Context) corev1beta1.VersionResolverFn {
+	return func(componentName string, version string) (corev1beta1.ComponentVersion, error) {
+		return ocm.GetOCMComponent(ctx, v.Client, componentName, version)
+	}
+}
+
+// validateVersion checks that the version of an enabled component is available in a ReleaseChannel.
+func (v *ControlPlaneCustomValidator) validateVersion(ctx context.Context, cf componentField) field.ErrorList {
+	if !cf.component.IsEnabled() {
+		return nil
+	}
+	ctx = rcontext.WithVersionResolver(ctx, v.resolveVersion(ctx))
+	if _, err := cf.component.IsInstallable(ctx); err != nil {
+		if errors.Is(err, ocm.ErrComponentVersionNotFound) {
+			return field.ErrorList{field.NotFound(cf.path.Child("version"), cf.version)}
+		}
+		if errors.Is(err, components.ErrComponentDefinitionNotFound) {
+			return field.ErrorList{field.NotFound(cf.path.Child("definition"), cf.definition)}
+		}
+		return field.ErrorList{field.InternalError(cf.path.Child("version"), err)}
+	}
+	return nil
+}
+
+// validateValues checks that Helm values are a JSON object.
+func validateValues(path *field.Path, values *apiextensionsv1.JSON) field.ErrorList {
+	if values == nil {
+		return nil
+	}
+	var obj map[string]any
+	if err := json.Unmarshal(values.Raw, &obj); err != nil || obj == nil {
+		return field.ErrorList{field.Invalid(path, string(values.Raw), "must be a JSON object")}
+	}
+	return nil
+}
+
+// validateValuesFrom checks that the referents of the valuesFrom references are in an allowed namespace.
+func (v *ControlPlaneCustomValidator) validateValuesFrom(path *field.Path, refs []corev1beta1.ValuesReference) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, ref := range refs {
+		if !valuesfrom.IsNamespaceAllowed(v.ValuesFromNamespaces, ref.Namespace) {
+			allErrs = append(allErrs, field.Forbidden(path.Index(i).Child("namespace"),
+				fmt.Sprintf("values may not be referenced from namespace %q", ref.Namespace)))
+		}
+	}
+	return allErrs
+}
+
+// crossplanePackage describes a Crossplane package in the spec of a ControlPlane.
+type crossplanePackage struct {
+	// name is the configured name of the package.
+	name string
+	// releaseChannelName is the name of the package in the ReleaseChannels and of its manifest.
+	releaseChannelName string
+	version            string
+}
+
+// crossplanePackages returns the packages of the given type in the Crossplane configuration.
+func crossplanePackages(c *corev1beta1.CrossplaneConfig, pt crossplane.PackageType) []crossplanePackage {
+	packages := []crossplanePackage{}
+	switch pt {
+	case crossplane.Providers:
+		for _, p := range c.Providers {
+			packages = append(packages, crossplanePackage{p.Name, crossplane.ProviderNameForProviderConfig(p), p.Version})
+		}
+	case crossplane.Configurations:
+		for _, p := range c.Configurations {
+			packages = append(packages, crossplanePackage{p.Name, crossplane.ConfigurationNameForPackageConfig(p), p.Version})
+		}
+	case crossplane.Functions:
+		for _, p := range c.Functions {
+			packages = append(packages, crossplanePackage{p.Name, crossplane.FunctionNameForPackageConfig(p), p.Version})
+		}
+	}
+	return packages
+}
+
+// restrictionForPackageType returns the restriction of the CrossplanePackageRestriction for the given package type.
+func restrictionForPackageType(spec corev1beta1.CrossplanePackageRestrictionSpec, pt crossplane.PackageType) corev1beta1.PackageRestriction {
+	switch pt {
+	case crossplane.Configurations:
+		return spec.Configurations
+	case crossplane.Functions:
+		return spec.Functions
+	default:
+		return spec.Providers
+	}
+}
+
+// validatePackageNames checks that every package is only configured once.
+func validatePackageNames(path *field.Path, packages []crossplanePackage) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[string]bool{}
+	for i, p := range packages {
+		if seen[p.releaseChannelName] {
+			allErrs = append(allErrs, field.Duplicate(path.Index(i).Child("name"), p.name))
+		}
+		seen[p.releaseChannelName] = true
+	}
+	return allErrs
+}
+
+// validateProviderConfigNames checks that the credentials of every ProviderConfig are copied into a Secret of their own.
+func validateProviderConfigNames(path *field.Path, providers []*corev1beta1.CrossplaneProviderConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[string]bool{}
+	for i, p := range providers {
+		for j, pc := range p.ProviderConfigs {
+			secretName := crossplane.ProviderConfigSecretName(p.Name, pc.Name)
+			if seen[secretName] {
+				allErrs = append(allErrs, field.Duplicate(path.Index(i).Child("providerConfigs").Index(j).Child("name"), pc.Name))
+			}
+			seen[secretName] = true
+		}
+	}
+	return allErrs
+}
+
+// validatePackages checks that the packages are allowed by the CrossplanePackageRestriction.
+// The check is skipped if no CrossplanePackageRestriction exists.
+func (v *ControlPlaneCustomValidator) validatePackages(ctx context.Context, path *field.Path, restrictionName string, pt crossplane.PackageType, packages []crossplanePackage) field.ErrorList {
+	if len(packages) == 0 {
+		return nil
+	}
+
+	cpr := &corev1beta1.CrossplanePackageRestriction{}
+	if err := v.Client.Get(ctx, types.NamespacedName{Name: restrictionName}, cpr); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return field.ErrorList{field.InternalError(path, err)}
+	}
+
+	allErrs := field.ErrorList{}
+	resolve := v.resolveVersion(ctx)
+	for i, p := range packages {
+		// the package is always taken from the ReleaseChannel, unknown versions are reported separately
+		comp, err := resolve(p.releaseChannelName, p.version)
+		if err != nil {
+			continue
+		}
+		if !crossplane.IsPackageAllowed(restrictionForPackageType(cpr.Spec, pt), comp.DockerRef) {
+			allErrs = append(allErrs, field.Forbidden(path.Index(i), fmt.Sprintf("package %s is not allowed by CrossplanePackageRestriction %q", comp.DockerRef, cpr.Name)))
+		}
+	}
+	return allErrs
+}
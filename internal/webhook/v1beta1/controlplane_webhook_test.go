@@ -0,0 +1,461 @@
+package v1beta1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	corev1beta1 "github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/internal/schemes"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/components/policies"
+)
+
+var (
+	releaseChannel = &corev1beta1.ReleaseChannel{
+		ObjectMeta: metav1.ObjectMeta{Name: "stable"},
+		Status: corev1beta1.ReleaseChannelStatus{Components: []corev1beta1.Component{
+			{
+				Name: "crossplane",
+				Versions: []corev1beta1.ComponentVersion{
+					{Version: "1.15.0", HelmRepo: "https://charts.crossplane.io/stable", HelmChart: "crossplane"},
+					{Version: "1.16.0", HelmRepo: "https://charts.crossplane.io/stable", HelmChart: "crossplane"},
+				},
+			},
+			{
+				Name: "provider-helm",
+				Versions: []corev1beta1.ComponentVersion{
+					{Version: "0.19.0", DockerRef: "xpkg.upbound.io/crossplane-contrib/provider-helm:v0.19.0"},
+				},
+			},
+			{
+				Name: "provider-kubernetes",
+				Versions: []corev1beta1.ComponentVersion{
+					{Version: "0.13.0", DockerRef: "example.com/crossplane/provider-kubernetes:v0.13.0"},
+				},
+			},
+			{
+				Name: "podinfo",
+				Versions: []corev1beta1.ComponentVersion{
+					{Version: "6.7.0", HelmRepo: "https://stefanprodan.github.io/podinfo", HelmChart: "podinfo"},
+				},
+			},
+			{
+				Name: "opentelemetry-collector",
+				Versions: []corev1beta1.ComponentVersion{
+					{Version: "0.110.0", HelmRepo: "https://open-telemetry.github.io/opentelemetry-helm-charts", HelmChart: "opentelemetry-collector"},
+				},
+			},
+			{
+				Name: "function-auto-ready",
+				Versions: []corev1beta1.ComponentVersion{
+					{Version: "0.2.1", DockerRef: "xpkg.upbound.io/crossplane-contrib/function-auto-ready:v0.2.1"},
+				},
+			},
+		}},
+	}
+
+	packageRestriction = &corev1beta1.CrossplanePackageRestriction{
+		ObjectMeta: metav1.ObjectMeta{Name: policies.CrossplanePackageRestrictionName},
+		Spec: corev1beta1.CrossplanePackageRestrictionSpec{
+			Providers: corev1beta1.PackageRestriction{Registries: []string{"xpkg.upbound.io"}},
+		},
+	}
+
+	profile = &corev1beta1.ControlPlaneProfile{
+		ObjectMeta: metav1.ObjectMeta{Name: "default"},
+		Spec: corev1beta1.ControlPlaneProfileSpec{
+			CrossplanePackageRestriction: "strict",
+			ComponentsConfig: corev1beta1.ComponentsConfig{
+				Crossplane: &corev1beta1.CrossplaneConfig{Version: "1.16.0"},
+			},
+		},
+	}
+
+	strictPackageRestriction = &corev1beta1.CrossplanePackageRestriction{
+		ObjectMeta: metav1.ObjectMeta{Name: "strict"},
+		Spec: corev1beta1.CrossplanePackageRestrictionSpec{
+			Providers: corev1beta1.PackageRestriction{Packages: []string{"xpkg.upbound.io/crossplane-contrib/provider-nop"}},
+		},
+	}
+)
+
+func withCoreRef(cp *corev1beta1.ControlPlane, name string) *corev1beta1.ControlPlane {
+	cp.Spec.CoreReference.Name = name
+	return cp
+}
+
+func withVersion(profile *corev1beta1.ControlPlaneProfile, version string) *corev1beta1.ControlPlaneProfile {
+	profile = profile.DeepCopy()
+	profile.Spec.Crossplane.Version = version
+	return profile
+}
+
+func newControlPlane(crossplane *corev1beta1.CrossplaneConfig) *corev1beta1.ControlPlane {
+	cp := &corev1beta1.ControlPlane{ObjectMeta: metav1.ObjectMeta{Name: "test"}}
+	cp.Spec.Crossplane = crossplane
+	return cp
+}
+
+func withAdditionalComponents(cp *corev1beta1.ControlPlane, acs ...corev1beta1.AdditionalComponentConfig) *corev1beta1.ControlPlane {
+	cp.Spec.AdditionalComponents = acs
+	return cp
+}
+
+func withTelemetry(cp *corev1beta1.ControlPlane, telemetry *corev1beta1.TelemetryConfig) *corev1beta1.ControlPlane {
+	cp.Spec.Telemetry = telemetry
+	return cp
+}
+
+func TestControlPlaneCustomValidator_ValidateCreate(t *testing.T) {
+	tests := []struct {
+		name     string
+		initObjs []client.Object
+		cp       *corev1beta1.ControlPlane
+		wantErrs []string
+	}{
+		{
+			name:     "valid",
+			initObjs: []client.Object{releaseChannel, packageRestriction},
+			cp: newControlPlane(&corev1beta1.CrossplaneConfig{
+				Version:   "1.15.0",
+				Values:    &apiextensionsv1.JSON{Raw: []byte(`{"replicas":2}`)},
+				Providers: []*corev1beta1.CrossplaneProviderConfig{{Name: "provider-helm", Version: "0.19.0"}},
+			}),
+		},
+		{
+			name:     "version from profile",
+			initObjs: []client.Object{releaseChannel, profile},
+			cp:       withCoreRef(newControlPlane(&corev1beta1.CrossplaneConfig{}), "default"),
+		},
+		{
+			name:     "version from profile is validated",
+			initObjs: []client.Object{releaseChannel, withVersion(profile, "0.0.1")},
+			cp:       withCoreRef(newControlPlane(&corev1beta1.CrossplaneConfig{}), "default"),
+			wantErrs: []string{`spec.crossplane.version: Not found: "0.0.1"`},
+		},
+		{
+			name:     "provider denied by package restriction of profile",
+			initObjs: []client.Object{releaseChannel, packageRestriction, strictPackageRestriction, profile},
+			cp: withCoreRef(newControlPlane(&corev1beta1.CrossplaneConfig{
+				Providers: []*corev1beta1.CrossplaneProviderConfig{{Name: "provider-helm", Version: "0.19.0"}},
+			}), "default"),
+			wantErrs: []string{
+				`spec.crossplane.providers[0]: Forbidden: package xpkg.upbound.io/crossplane-contrib/provider-helm:v0.19.0 is not allowed`,
+			},
+		},
+		{
+			name:     "no components",
+			initObjs: []client.Object{releaseChannel},
+			cp:       newControlPlane(nil),
+		},
+		{
+			name:     "unknown versions",
+			initObjs: []client.Object{releaseChannel},
+			cp: newControlPlane(&corev1beta1.CrossplaneConfig{
+				Version:   "0.0.1",
+				Providers: []*corev1beta1.CrossplaneProviderConfig{{Name: "provider-helm", Version: "0.0.2"}},
+			}),
+			wantErrs: []string{
+				`spec.crossplane.version: Not found: "0.0.1"`,
+				`spec.crossplane.providers[0].version: Not found: "0.0.2"`,
+			},
+		},
+		{
+			name:     "version constraints",
+			initObjs: []client.Object{releaseChannel},
+			cp: newControlPlane(&corev1beta1.CrossplaneConfig{
+				Version:   "~1.15",
+				Providers: []*corev1beta1.CrossplaneProviderConfig{{Name: "provider-helm", Version: "latest"}},
+			}),
+		},
+		{
+			name:     "version constraint without matching version",
+			initObjs: []client.Object{releaseChannel},
+			cp:       newControlPlane(&corev1beta1.CrossplaneConfig{Version: ">=2"}),
+			wantErrs: []string{`spec.crossplane.version: Not found: ">=2"`},
+		},
+		{
+			name:     "invalid time zone of maintenance window",
+			initObjs: []client.Object{releaseChannel},
+			cp: func() *corev1beta1.ControlPlane {
+				cp := newControlPlane(nil)
+				cp.Spec.MaintenanceWindows = []corev1beta1.MaintenanceWindow{
+					{Weekday: "Monday", Start: "02:00", End: "04:00", TimeZone: "Europe/Berlin"},
+					{Weekday: "Monday", Start: "02:00", End: "04:00", TimeZone: "Mars/Olympus"},
+				}
+				return cp
+			}(),
+			wantErrs: []string{`spec.maintenanceWindows[1]: Invalid value:`, `invalid time zone`},
+		},
+		{
+			name:     "duplicate providers",
+			initObjs: []client.Object{releaseChannel},
+			cp: newControlPlane(&corev1beta1.CrossplaneConfig{
+				Version: "1.15.0",
+				Providers: []*corev1beta1.CrossplaneProviderConfig{
+					{Name: "provider-helm", Version: "0.19.0"},
+					{Name: "helm", Version: "0.19.0"},
+				},
+			}),
+			wantErrs: []string{`spec.crossplane.providers[1].name: Duplicate value: "helm"`},
+		},
+		{
+			name:     "duplicate ProviderConfigs",
+			initObjs: []client.Object{releaseChannel},
+			cp: newControlPlane(&corev1beta1.CrossplaneConfig{
+				Version: "1.15.0",
+				Providers: []*corev1beta1.CrossplaneProviderConfig{
+					{Name: "provider-helm", Version: "0.19.0", ProviderConfigs: []corev1beta1.CrossplaneProviderConfigTemplate{
+						{Name: "default", APIVersion: "helm.crossplane.io/v1beta1"},
+						{Name: "default", APIVersion: "helm.crossplane.io/v1beta1"},
+					}},
+				},
+			}),
+			wantErrs: []string{`spec.crossplane.providers[0].providerConfigs[1].name: Duplicate value: "default"`},
+		},
+		{
+			name:     "provider denied by package restriction",
+			initObjs: []client.Object{releaseChannel, packageRestriction},
+			cp: newControlPlane(&corev1beta1.CrossplaneConfig{
+				Version:   "1.15.0",
+				Providers: []*corev1beta1.CrossplaneProviderConfig{{Name: "provider-kubernetes", Version: "0.13.0"}},
+			}),
+			wantErrs: []string{
+				`spec.crossplane.providers[0]: Forbidden: package example.com/crossplane/provider-kubernetes:v0.13.0 is not allowed`,
+			},
+		},
+		{
+			name:     "duplicate functions",
+			initObjs: []client.Object{releaseChannel},
+			cp: newControlPlane(&corev1beta1.CrossplaneConfig{
+				Version: "1.15.0",
+				Functions: []*corev1beta1.CrossplanePackageConfig{
+					{Name: "function-auto-ready", Version: "0.2.1"},
+					{Name: "auto-ready", Version: "0.2.1"},
+				},
+			}),
+			wantErrs: []string{`spec.crossplane.functions[1].name: Duplicate value: "auto-ready"`},
+		},
+		{
+			name:     "function denied by package restriction",
+			initObjs: []client.Object{releaseChannel, packageRestriction},
+			cp: newControlPlane(&corev1beta1.CrossplaneConfig{
+				Version:   "1.15.0",
+				Functions: []*corev1beta1.CrossplanePackageConfig{{Name: "auto-ready", Version: "0.2.1"}},
+			}),
+			wantErrs: []string{
+				`spec.crossplane.functions[0]: Forbidden: package xpkg.upbound.io/crossplane-contrib/function-auto-ready:v0.2.1 is not allowed`,
+			},
+		},
+		{
+			name:     "unknown configuration",
+			initObjs: []client.Object{releaseChannel},
+			cp: newControlPlane(&corev1beta1.CrossplaneConfig{
+				Version:        "1.15.0",
+				Configurations: []*corev1beta1.CrossplanePackageConfig{{Name: "platform-ref", Version: "1.0.0"}},
+			}),
+			wantErrs: []string{`spec.crossplane.configurations[0].version: Not found: "1.0.0"`},
+		},
+		{
+			name:     "values are not an object",
+			initObjs: []client.Object{releaseChannel},
+			cp: newControlPlane(&corev1beta1.CrossplaneConfig{
+				Version: "1.15.0",
+				Values:  &apiextensionsv1.JSON{Raw: []byte(`["replicas"]`)},
+			}),
+			wantErrs: []string{`spec.crossplane.values: Invalid value: "[\"replicas\"]": must be a JSON object`},
+		},
+		{
+			name:     "values are null",
+			initObjs: []client.Object{releaseChannel},
+			cp: newControlPlane(&corev1beta1.CrossplaneConfig{
+				Version: "1.15.0",
+				Values:  &apiextensionsv1.JSON{Raw: []byte(`null`)},
+			}),
+			wantErrs: []string{`spec.crossplane.values: Invalid value: "null": must be a JSON object`},
+		},
+		{
+			name:     "valid additional component",
+			initObjs: []client.Object{releaseChannel},
+			cp: withAdditionalComponents(newControlPlane(nil), corev1beta1.AdditionalComponentConfig{
+				Name:    "podinfo",
+				Version: "~6.7",
+				Values:  &apiextensionsv1.JSON{Raw: []byte(`{"replicaCount":2}`)},
+			}),
+		},
+		{
+			name:     "invalid additional component",
+			initObjs: []client.Object{releaseChannel},
+			cp: withAdditionalComponents(newControlPlane(nil),
+				corev1beta1.AdditionalComponentConfig{Name: "podinfo", Version: "6.6.0"},
+				corev1beta1.AdditionalComponentConfig{Name: "other", Version: "1.0.0", Values: &apiextensionsv1.JSON{Raw: []byte(`"replicas"`)}},
+			),
+			wantErrs: []string{
+				`spec.additionalComponents[0].version: Not found: "6.6.0"`,
+				`spec.additionalComponents[1].values: Invalid value: "\"replicas\"": must be a JSON object`,
+				`spec.additionalComponents[1].version: Not found: "1.0.0"`,
+			},
+		},
+		{
+			name: "additional component with ComponentDefinition",
+			initObjs: []client.Object{releaseChannel, &corev1beta1.ComponentDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: "web"},
+				Spec:       corev1beta1.ComponentDefinitionSpec{ReleaseChannelName: "podinfo"},
+			}},
+			cp: withAdditionalComponents(newControlPlane(nil), corev1beta1.AdditionalComponentConfig{
+				Name:       "frontend",
+				Definition: "web",
+				Version:    "6.7.0",
+			}),
+		},
+		{
+			name:     "additional component with unknown ComponentDefinition",
+			initObjs: []client.Object{releaseChannel},
+			cp: withAdditionalComponents(newControlPlane(nil), corev1beta1.AdditionalComponentConfig{
+				Name:       "frontend",
+				Definition: "web",
+				Version:    "6.7.0",
+			}),
+			wantErrs: []string{`spec.additionalComponents[0].definition: Not found: "web"`},
+		},
+		{
+			name:     "valid telemetry",
+			initObjs: []client.Object{releaseChannel},
+			cp: withTelemetry(newControlPlane(nil), &corev1beta1.TelemetryConfig{
+				Enabled: true, Version: "0.110.0", Values: &apiextensionsv1.JSON{Raw: []byte(`{"mode":"daemonset"}`)},
+			}),
+		},
+		{
+			name:     "invalid telemetry",
+			initObjs: []client.Object{releaseChannel},
+			cp: withTelemetry(newControlPlane(nil), &corev1beta1.TelemetryConfig{
+				Enabled: true, Version: "0.100.0", Values: &apiextensionsv1.JSON{Raw: []byte(`[]`)},
+			}),
+			wantErrs: []string{
+				`spec.telemetry.version: Not found: "0.100.0"`,
+				`spec.telemetry.values: Invalid value: "[]": must be a JSON object`,
+			},
+		},
+		{
+			name:     "telemetry without version",
+			initObjs: []client.Object{releaseChannel},
+			cp:       withTelemetry(newControlPlane(nil), &corev1beta1.TelemetryConfig{Enabled: true}),
+			wantErrs: []string{`spec.telemetry.version: Required value: must be set if telemetry is enabled`},
+		},
+		{
+			name:     "valuesFrom in allowed namespace",
+			initObjs: []client.Object{releaseChannel},
+			cp: newControlPlane(&corev1beta1.CrossplaneConfig{
+				Version:    "1.15.0",
+				ValuesFrom: []corev1beta1.ValuesReference{{Kind: "Secret", Name: "values", Namespace: "shared-values"}},
+			}),
+		},
+		{
+			name:     "valuesFrom in namespace that is not allowed",
+			initObjs: []client.Object{releaseChannel},
+			cp: newControlPlane(&corev1beta1.CrossplaneConfig{
+				Version: "1.15.0",
+				ValuesFrom: []corev1beta1.ValuesReference{
+					{Kind: "ConfigMap", Name: "values", Namespace: "shared-values"},
+					{Kind: "Secret", Name: "token", Namespace: "kube-system"},
+				},
+			}),
+			wantErrs: []string{`spec.crossplane.valuesFrom[1].namespace: Forbidden: values may not be referenced from namespace "kube-system"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(tt.initObjs...).WithScheme(schemes.Local).Build()
+			v := &ControlPlaneCustomValidator{
+				Client:                    c,
+				ValuesFromNamespaces:      []string{"shared-values"},
+				TelemetryExporterEndpoint: "https://otlp.example.com",
+			}
+
+			warnings, err := v.ValidateCreate(context.Background(), tt.cp)
+			assert.Empty(t, warnings)
+			assertInvalid(t, err, tt.wantErrs)
+		})
+	}
+}
+
+func TestControlPlaneCustomValidator_ValidateUpdate(t *testing.T) {
+	tests := []struct {
+		name     string
+		oldCP    *corev1beta1.ControlPlane
+		newCP    *corev1beta1.ControlPlane
+		wantErrs []string
+	}{
+		{
+			name:  "unchanged version that is no longer in the release channel",
+			oldCP: newControlPlane(&corev1beta1.CrossplaneConfig{Version: "1.14.0"}),
+			newCP: newControlPlane(&corev1beta1.CrossplaneConfig{Version: "1.14.0"}),
+		},
+		{
+			name:  "changed version",
+			oldCP: newControlPlane(&corev1beta1.CrossplaneConfig{Version: "1.15.0"}),
+			newCP: newControlPlane(&corev1beta1.CrossplaneConfig{Version: "1.16.0"}),
+		},
+		{
+			name:     "changed to unknown version",
+			oldCP:    newControlPlane(&corev1beta1.CrossplaneConfig{Version: "1.15.0"}),
+			newCP:    newControlPlane(&corev1beta1.CrossplaneConfig{Version: "1.17.0"}),
+			wantErrs: []string{`spec.crossplane.version: Not found: "1.17.0"`},
+		},
+		{
+			name:  "changed target",
+			oldCP: newControlPlane(nil),
+			newCP: func() *corev1beta1.ControlPlane {
+				cp := newControlPlane(nil)
+				cp.Spec.Target.FluxServiceAccount.Name = "flux"
+				return cp
+			}(),
+			wantErrs: []string{`spec.target: Forbidden: field is immutable`},
+		},
+		{
+			name:  "deleted ControlPlane",
+			oldCP: newControlPlane(&corev1beta1.CrossplaneConfig{Version: "1.15.0"}),
+			newCP: func() *corev1beta1.ControlPlane {
+				cp := newControlPlane(&corev1beta1.CrossplaneConfig{Version: "1.17.0"})
+				now := metav1.Now()
+				cp.DeletionTimestamp = &now
+				return cp
+			}(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(releaseChannel).WithScheme(schemes.Local).Build()
+			v := &ControlPlaneCustomValidator{Client: c}
+
+			warnings, err := v.ValidateUpdate(context.Background(), tt.oldCP, tt.newCP)
+			assert.Empty(t, warnings)
+			assertInvalid(t, err, tt.wantErrs)
+		})
+	}
+}
+
+func TestControlPlaneCustomValidator_ValidateDelete(t *testing.T) {
+	v := &ControlPlaneCustomValidator{Client: fake.NewClientBuilder().WithScheme(schemes.Local).Build()}
+	warnings, err := v.ValidateDelete(context.Background(), newControlPlane(&corev1beta1.CrossplaneConfig{Version: "0.0.1"}))
+	assert.Empty(t, warnings)
+	assert.NoError(t, err)
+}
+
+func assertInvalid(t *testing.T, err error, wantErrs []string) {
+	t.Helper()
+	if len(wantErrs) == 0 {
+		assert.NoError(t, err)
+		return
+	}
+	assert.True(t, apierrors.IsInvalid(err), "expected an Invalid error, got %v", err)
+	for _, want := range wantErrs {
+		assert.ErrorContains(t, err, want)
+	}
+}
@@ -3,4 +3,12 @@ package constants
 const (
 	AnnotationCredentialsForUrl  = "core.orchestrate.cloud.sap/credentials-for-url"
 	AnnotationSkipReconciliation = "core.orchestrate.cloud.sap/skip-reconciliation"
+	AnnotationDryRun             = "core.orchestrate.cloud.sap/dry-run"
+
+	AnnotationProviderConfigProvider   = "core.orchestrate.cloud.sap/provider-config-provider"
+	AnnotationProviderConfigAPIVersion = "core.orchestrate.cloud.sap/provider-config-api-version"
+	AnnotationProviderConfigKind       = "core.orchestrate.cloud.sap/provider-config-kind"
+	AnnotationProviderConfigName       = "core.orchestrate.cloud.sap/provider-config-name"
+
+	AnnotationRuntimeConfigArgs = "core.orchestrate.cloud.sap/runtime-config-args"
 )
@@ -5,4 +5,6 @@ const (
 	LabelCopyToCP            = "core.orchestrate.cloud.sap/copy-to-cp"
 	LabelCopySourceName      = "core.orchestrate.cloud.sap/copy-source-name"
 	LabelCopySourceNamespace = "core.orchestrate.cloud.sap/copy-source-namespace"
+	LabelValuesFrom          = "core.orchestrate.cloud.sap/values-from"
+	LabelProviderConfig      = "core.orchestrate.cloud.sap/provider-config"
 )
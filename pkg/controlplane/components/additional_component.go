@@ -0,0 +1,321 @@
+package components
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler/fluxcd"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler/object"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils/rcontext"
+)
+
+// additionalComponentPrefix is the prefix of the Flux resources of additional components,
+// which prevents conflicts with the Flux resources of the built-in components.
+const additionalComponentPrefix = "additional-"
+
+var _ fluxcd.FluxComponent = &AdditionalComponent{}
+var _ fluxcd.OrphanedManifestosDetector = &AdditionalComponent{}
+var _ juggler.DependencyMatcher = &AdditionalComponent{}
+var _ juggler.VersionedComponent = &AdditionalComponent{}
+var _ juggler.VersionResolvingComponent = &AdditionalComponent{}
+var _ UpgradableComponent = &AdditionalComponent{}
+var _ TargetComponent = &AdditionalComponent{}
+var _ PolicyRulesComponent = &AdditionalComponent{}
+
+// AdditionalComponent is a user-defined component, which is installed from a Helm chart or from plain manifests.
+// The component may reference a ComponentDefinition with its defaults.
+type AdditionalComponent struct {
+	Config *v1beta1.AdditionalComponentConfig
+	// Definitions are the ComponentDefinitions the component may reference.
+	Definitions Definitions
+
+	cached lazyHelm
+}
+
+// definition returns the definition of the component. The fields of the component take precedence
+// over the referenced ComponentDefinition.
+func (a *AdditionalComponent) definition() v1beta1.ComponentDefinitionSpec {
+	definition := v1beta1.ComponentDefinitionSpec{
+		ReleaseChannelName: a.Config.Name,
+		ReleaseName:        a.Config.Name,
+	}
+	if a.Config.Definition != "" {
+		definition = mergeDefinition(definition, a.Definitions[a.Config.Definition])
+	}
+
+	override := &v1beta1.ComponentDefinitionSpec{
+		Namespace:   a.Config.TargetNamespace,
+		DependsOn:   a.Config.DependsOn,
+		PolicyRules: a.Config.PolicyRules,
+	}
+	return mergeDefinition(definition, override)
+}
+
+func (a *AdditionalComponent) helm() *helmComponent {
+	return a.cached.get(func() *helmComponent {
+		h := &helmComponent{
+			resourceName: a.resourceName(),
+			definition:   a.definition(),
+			config:       helmConfig{Version: a.Config.Version, Values: a.Config.Values, ValuesFrom: a.Config.ValuesFrom},
+		}
+		if a.Config.Source != nil {
+			h.config.Chart = a.Config.Source.Chart
+		}
+		return h
+	})
+}
+
+// GetName implements juggler.Component.
+func (a *AdditionalComponent) GetName() string {
+	return a.Config.Name
+}
+
+// GetDependencies implements juggler.Component.
+// Dependencies are either built-in components or other additional components.
+func (a *AdditionalComponent) GetDependencies() []juggler.Component {
+	return a.helm().dependencies()
+}
+
+// MatchesDependency implements juggler.DependencyMatcher.
+func (a *AdditionalComponent) MatchesDependency(registered juggler.Component) bool {
+	return registered.GetName() == a.GetName()
+}
+
+// builtinComponent returns an empty built-in component by its name or nil if there is none.
+func builtinComponent(name string) juggler.Component {
+	switch name {
+	case ComponentNameCrossplane:
+		return &Crossplane{}
+	case ComponentNameCertManager:
+		return &CertManager{}
+	case ComponentNameBTPSO:
+		return &BTPServiceOperator{}
+	case ComponentNameESO:
+		return &ExternalSecretsOperator{}
+	case ComponentNameKyverno:
+		return &Kyverno{}
+	case ComponentNameFlux:
+		return &Flux{}
+	case ComponentNameTelemetry:
+		return &Telemetry{}
+	}
+	return nil
+}
+
+// IsEnabled implements juggler.Component.
+func (a *AdditionalComponent) IsEnabled() bool {
+	return a.Config.Version != ""
+}
+
+// Hooks implements juggler.Component.
+func (a *AdditionalComponent) Hooks() juggler.ComponentHooks {
+	return a.helm().hooks()
+}
+
+// IsInstallable implements juggler.Component.
+func (a *AdditionalComponent) IsInstallable(ctx context.Context) (bool, error) {
+	if a.Config.Definition != "" && a.Definitions[a.Config.Definition] == nil {
+		return false, fmt.Errorf("%w: %s", ErrComponentDefinitionNotFound, a.Config.Definition)
+	}
+	return a.helm().isInstallable(ctx)
+}
+
+// GetNamespace implements TargetComponent.
+func (a *AdditionalComponent) GetNamespace() string {
+	return a.helm().definition.Namespace
+}
+
+// GetPolicyRules implements PolicyRulesComponent.
+func (a *AdditionalComponent) GetPolicyRules() PolicyRules {
+	return a.helm().policyRules()
+}
+
+// GetVersion implements juggler.VersionedComponent.
+func (a *AdditionalComponent) GetVersion() string {
+	return a.Config.Version
+}
+
+// GetResolvedVersion implements juggler.VersionResolvingComponent.
+func (a *AdditionalComponent) GetResolvedVersion(ctx context.Context) (string, error) {
+	return a.helm().getResolvedVersion(ctx)
+}
+
+// GetReleaseChannelName implements UpgradableComponent.
+func (a *AdditionalComponent) GetReleaseChannelName() string {
+	return a.definition().ReleaseChannelName
+}
+
+// GetUpgradePolicy implements UpgradableComponent.
+func (a *AdditionalComponent) GetUpgradePolicy() v1beta1.UpgradePolicy {
+	return a.Config.UpgradePolicy
+}
+
+// GetAvailableVersions implements juggler.GetAvailableVersions.
+func (a *AdditionalComponent) GetAvailableVersions(ctx context.Context) ([]string, error) {
+	return a.helm().getAvailableVersions(ctx)
+}
+
+// resourceName returns the name of the Flux resources of the component.
+func (a *AdditionalComponent) resourceName() string {
+	return additionalComponentPrefix + a.Config.Name
+}
+
+// source returns the source of the component and the version of the component in the ReleaseChannels.
+// The source defaults to the Helm chart of the definition.
+func (a *AdditionalComponent) source(ctx context.Context) (v1beta1.AdditionalComponentSource, string) {
+	h := a.helm()
+	version := ""
+	if rfn := rcontext.VersionResolver(ctx); rfn != nil && a.IsEnabled() {
+		comp, _ := rfn(h.definition.ReleaseChannelName, a.Config.Version)
+		version = comp.Version
+	}
+
+	if a.Config.Source != nil && (a.Config.Source.Git != nil || a.Config.Source.OCI != nil) {
+		return *a.Config.Source.DeepCopy(), version
+	}
+	return v1beta1.AdditionalComponentSource{Chart: h.chart(ctx)}, version
+}
+
+// BuildSourceRepository implements fluxcd.FluxComponent.
+func (a *AdditionalComponent) BuildSourceRepository(ctx context.Context) (fluxcd.SourceAdapter, error) {
+	source, version := a.source(ctx)
+	objectMeta := metav1.ObjectMeta{
+		Name:      a.resourceName(),
+		Namespace: rcontext.TenantNamespace(ctx),
+	}
+
+	switch {
+	case source.Git != nil:
+		repo := &sourcev1.GitRepository{
+			ObjectMeta: objectMeta,
+			Spec: sourcev1.GitRepositorySpec{
+				URL: source.Git.URL,
+			},
+		}
+		if version != "" {
+			repo.Spec.Reference = &sourcev1.GitRepositoryRef{Tag: version}
+		}
+		adapter := &fluxcd.GitRepositoryAdapter{Source: repo}
+		adapter.ApplyDefaults()
+		return adapter, nil
+	case source.OCI != nil:
+		repo := &sourcev1.OCIRepository{
+			ObjectMeta: objectMeta,
+			Spec: sourcev1.OCIRepositorySpec{
+				URL: source.OCI.URL,
+			},
+		}
+		if version != "" {
+			repo.Spec.Reference = &sourcev1.OCIRepositoryRef{Tag: version}
+		}
+		adapter := &fluxcd.OCIRepositoryAdapter{Source: repo}
+		adapter.ApplyDefaults()
+		return adapter, nil
+	default:
+		return buildChartSource(ctx, a.resourceName(), source.Chart), nil
+	}
+}
+
+// BuildManifesto implements fluxcd.FluxComponent.
+func (a *AdditionalComponent) BuildManifesto(ctx context.Context) (fluxcd.Manifesto, error) {
+	source, _ := a.source(ctx)
+	if source.Git == nil && source.OCI == nil {
+		return a.helm().buildManifesto(ctx)
+	}
+
+	kustomization := &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      a.resourceName(),
+			Namespace: rcontext.TenantNamespace(ctx),
+		},
+		Spec: kustomizev1.KustomizationSpec{
+			SourceRef: kustomizev1.CrossNamespaceSourceReference{
+				Kind: sourcev1.GitRepositoryKind,
+				Name: a.resourceName(),
+			},
+			TargetNamespace: a.GetNamespace(),
+			KubeConfig:      rcontext.FluxKubeconfigRef(ctx),
+		},
+	}
+	if source.Git != nil {
+		kustomization.Spec.Path = source.Git.Path
+	} else {
+		kustomization.Spec.SourceRef.Kind = sourcev1.OCIRepositoryKind
+		kustomization.Spec.Path = source.OCI.Path
+	}
+
+	adapter := &fluxcd.KustomizationManifesto{Manifest: kustomization}
+	adapter.ApplyDefaults()
+	return adapter, nil
+}
+
+// OrphanDetectorContexts implements fluxcd.OrphanedManifestosDetector.
+// The orphaned components are converted with the kind of their source, which is all that is needed to uninstall them.
+func (*AdditionalComponent) OrphanDetectorContexts(ctx context.Context) []object.DetectorContext {
+	filterCriteria := object.FilterCriteria{
+		client.InNamespace(rcontext.TenantNamespace(ctx)),
+		utils.IsManaged(),
+		utils.HasComponentLabel(),
+	}
+	sameFunc := func(configured, detected juggler.Component) bool {
+		return configured.GetName() == detected.GetName()
+	}
+
+	return []object.DetectorContext{
+		{
+			ListType:       &helmv2.HelmReleaseList{},
+			FilterCriteria: filterCriteria,
+			ConvertFunc: func(list client.ObjectList) []juggler.Component {
+				comps := []juggler.Component{}
+				for _, release := range list.(*helmv2.HelmReleaseList).Items {
+					chart := &v1beta1.ChartSpec{}
+					if release.Spec.ChartRef != nil {
+						chart.URL = ociScheme
+					}
+					comps = appendOrphanedAdditionalComponent(comps, release.Name, &v1beta1.AdditionalComponentSource{Chart: chart})
+				}
+				return comps
+			},
+			SameFunc: sameFunc,
+		},
+		{
+			ListType:       &kustomizev1.KustomizationList{},
+			FilterCriteria: filterCriteria,
+			ConvertFunc: func(list client.ObjectList) []juggler.Component {
+				comps := []juggler.Component{}
+				for _, kustomization := range list.(*kustomizev1.KustomizationList).Items {
+					source := &v1beta1.AdditionalComponentSource{Git: &v1beta1.ManifestsSource{}}
+					if kustomization.Spec.SourceRef.Kind == sourcev1.OCIRepositoryKind {
+						source = &v1beta1.AdditionalComponentSource{OCI: &v1beta1.ManifestsSource{}}
+					}
+					comps = appendOrphanedAdditionalComponent(comps, kustomization.Name, source)
+				}
+				return comps
+			},
+			SameFunc: sameFunc,
+		},
+	}
+}
+
+// appendOrphanedAdditionalComponent appends a disabled component for the Flux resource,
+// unless the resource belongs to a built-in component.
+func appendOrphanedAdditionalComponent(comps []juggler.Component, resourceName string, source *v1beta1.AdditionalComponentSource) []juggler.Component {
+	name, ok := strings.CutPrefix(resourceName, additionalComponentPrefix)
+	if !ok {
+		return comps
+	}
+	return append(comps, &AdditionalComponent{Config: &v1beta1.AdditionalComponentConfig{
+		Name:   name,
+		Source: source,
+	}})
+}
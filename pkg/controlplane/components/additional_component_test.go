@@ -0,0 +1,305 @@
+//nolint:dupl
+package components
+
+import (
+	"context"
+	"testing"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/stretchr/testify/assert"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler/fluxcd"
+)
+
+func hasChartVersion(expected string) helmReleaseValidationFunc {
+	return func(t *testing.T, ctx context.Context, h *fluxcd.HelmReleaseManifesto) {
+		if assert.NotNil(t, h.Manifest.Spec.Chart, "chart template is nil") {
+			assert.Equal(t, expected, h.Manifest.Spec.Chart.Spec.Version)
+		}
+	}
+}
+
+func Test_AdditionalComponent(t *testing.T) {
+	testCases := []struct {
+		desc                      string
+		config                    *v1beta1.AdditionalComponentConfig
+		definitions               Definitions
+		versionResolver           v1beta1.VersionResolverFn
+		availableVersionsResolver v1beta1.AvailableVersionsResolverFn
+		validationFuncs           []validationFunc
+	}{
+		{
+			desc: "should be disabled",
+			config: &v1beta1.AdditionalComponentConfig{
+				Name: "reloader",
+			},
+			validationFuncs: []validationFunc{
+				hasName("reloader"),
+				isEnabled(false),
+			},
+		},
+		{
+			desc: "should not be allowed",
+			config: &v1beta1.AdditionalComponentConfig{
+				Name:    "reloader",
+				Version: "1.2.3",
+			},
+			versionResolver: fakeVersionResolver(true),
+			validationFuncs: []validationFunc{
+				isEnabled(true),
+				isAllowed(false),
+			},
+		},
+		{
+			desc: "returns available versions from context resolver",
+			config: &v1beta1.AdditionalComponentConfig{
+				Name: "reloader",
+			},
+			availableVersionsResolver: fakeAvailableVersionsResolver(false),
+			validationFuncs: []validationFunc{
+				hasAvailableVersions([]string{"1.1.0", "1.2.0"}),
+			},
+		},
+		{
+			desc: "chart from the ReleaseChannels",
+			config: &v1beta1.AdditionalComponentConfig{
+				Name:            "reloader",
+				Version:         "1.2.3",
+				TargetNamespace: "reloader",
+			},
+			versionResolver: fakeOCIVersionResolver(),
+			validationFuncs: []validationFunc{
+				isEnabled(true),
+				isAllowed(true),
+				hasNoHooks(),
+				hasDependencies(0),
+				hasResolvedVersion("v1.0.0"),
+				isTargetComponent(
+					hasNamespace("reloader"),
+				),
+				isFluxComponent(
+					returnsOCIRepo("oci://registry.example.com/charts/reloader", "v1.0.0"),
+					returnsHelmRelease(
+						hasKubeconfigRef(),
+						hasChartRef("OCIRepository"),
+					),
+				),
+			},
+		},
+		{
+			desc: "chart of the source with the resolved version",
+			config: &v1beta1.AdditionalComponentConfig{
+				Name:            "reloader",
+				Version:         "1.2.3",
+				TargetNamespace: "reloader",
+				Source: &v1beta1.AdditionalComponentSource{
+					Chart: &v1beta1.ChartSpec{
+						Repository: "https://stakater.github.io/stakater-charts",
+						Name:       "reloader",
+					},
+				},
+				Values: &apiextensionsv1.JSON{Raw: []byte(`{"reloader":{"watchGlobally":false}}`)},
+			},
+			versionResolver: fakeVersionResolver(false),
+			validationFuncs: []validationFunc{
+				isFluxComponent(
+					returnsHelmRepo(),
+					returnsHelmRelease(
+						hasKubeconfigRef(),
+						hasChartVersion("v1.0.0"),
+						hasHelmValue(false, "reloader", "watchGlobally"),
+					),
+				),
+			},
+		},
+		{
+			desc: "manifests from a Git repository",
+			config: &v1beta1.AdditionalComponentConfig{
+				Name:            "reloader",
+				Version:         "1.2.3",
+				TargetNamespace: "reloader",
+				Source: &v1beta1.AdditionalComponentSource{
+					Git: &v1beta1.ManifestsSource{URL: "https://github.com/org/reloader", Path: "./deploy"},
+				},
+			},
+			versionResolver: fakeVersionResolver(false),
+			validationFuncs: []validationFunc{
+				isFluxComponent(
+					returnsGitRepo("https://github.com/org/reloader", "v1.0.0"),
+					returnsKustomization("GitRepository", "./deploy"),
+				),
+			},
+		},
+		{
+			desc: "manifests from an OCI artifact",
+			config: &v1beta1.AdditionalComponentConfig{
+				Name:            "reloader",
+				Version:         "1.2.3",
+				TargetNamespace: "reloader",
+				Source: &v1beta1.AdditionalComponentSource{
+					OCI: &v1beta1.ManifestsSource{URL: "oci://registry.example.com/manifests/reloader"},
+				},
+			},
+			versionResolver: fakeVersionResolver(false),
+			validationFuncs: []validationFunc{
+				isFluxComponent(
+					returnsOCIRepo("oci://registry.example.com/manifests/reloader", "v1.0.0"),
+					returnsKustomization("OCIRepository", ""),
+				),
+			},
+		},
+		{
+			desc: "dependencies and policy rules",
+			config: &v1beta1.AdditionalComponentConfig{
+				Name:      "reloader",
+				Version:   "1.2.3",
+				DependsOn: []string{ComponentNameCertManager, "metrics-server"},
+				PolicyRules: &v1beta1.ComponentPolicyRules{
+					Admin: []rbacv1.PolicyRule{{APIGroups: []string{"example.com"}, Resources: []string{"reloaders"}, Verbs: VerbsAdmin}},
+					View:  []rbacv1.PolicyRule{{APIGroups: []string{"example.com"}, Resources: []string{"reloaders"}, Verbs: VerbsView}},
+				},
+			},
+			versionResolver: fakeVersionResolver(false),
+			validationFuncs: []validationFunc{
+				hasDependencies(2),
+				isPolicyRulesComponent(
+					hasPolicyRules(),
+				),
+			},
+		},
+		{
+			desc: "defaults from the definition",
+			config: &v1beta1.AdditionalComponentConfig{
+				Name:       "policy-engine",
+				Version:    "1.2.3",
+				Definition: "gatekeeper",
+				Values:     &apiextensionsv1.JSON{Raw: []byte(`{"replicas":2}`)},
+			},
+			definitions: Definitions{
+				"gatekeeper": {
+					ReleaseChannelName: "gatekeeper",
+					ReleaseName:        "gatekeeper",
+					Namespace:          "gatekeeper-system",
+					Chart:              &v1beta1.ChartSpec{Repository: "https://open-policy-agent.github.io/gatekeeper/charts", Name: "gatekeeper"},
+					Values:             &apiextensionsv1.JSON{Raw: []byte(`{"replicas":3,"auditInterval":60}`)},
+					DependsOn:          []string{ComponentNameCertManager},
+					PolicyRules: &v1beta1.ComponentPolicyRules{
+						Admin: []rbacv1.PolicyRule{{APIGroups: []string{"constraints.gatekeeper.sh"}, Resources: []string{"*"}, Verbs: VerbsAdmin}},
+						View:  []rbacv1.PolicyRule{{APIGroups: []string{"constraints.gatekeeper.sh"}, Resources: []string{"*"}, Verbs: VerbsView}},
+					},
+					ProtectedResources: []metav1.GroupVersionKind{
+						{Group: "templates.gatekeeper.sh", Version: "v1", Kind: "ConstraintTemplate"},
+					},
+				},
+			},
+			versionResolver: fakeVersionResolver(false),
+			validationFuncs: []validationFunc{
+				hasName("policy-engine"),
+				isAllowed(true),
+				hasPreUninstallHook(),
+				hasDependencies(1),
+				isTargetComponent(
+					hasNamespace("gatekeeper-system"),
+				),
+				isPolicyRulesComponent(
+					hasPolicyRules(),
+				),
+				isFluxComponent(
+					returnsHelmRepo(),
+					returnsHelmRelease(
+						hasReleaseName("gatekeeper"),
+						hasChartVersion("v1.0.0"),
+						hasHelmValue(2, "replicas"),
+						hasHelmValue(60, "auditInterval"),
+					),
+				),
+			},
+		},
+		{
+			desc: "missing definition",
+			config: &v1beta1.AdditionalComponentConfig{
+				Name:       "policy-engine",
+				Version:    "1.2.3",
+				Definition: "gatekeeper",
+			},
+			versionResolver: fakeVersionResolver(false),
+			validationFuncs: []validationFunc{
+				isAllowed(false),
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx := newContext(nil, tC.versionResolver, tC.availableVersionsResolver)
+			c := &AdditionalComponent{Config: tC.config, Definitions: tC.definitions}
+			for _, vfn := range tC.validationFuncs {
+				vfn(t, ctx, c)
+			}
+		})
+	}
+}
+
+func Test_AdditionalComponent_GetDependencies(t *testing.T) {
+	c := &AdditionalComponent{Config: &v1beta1.AdditionalComponentConfig{
+		Name:      "reloader",
+		DependsOn: []string{ComponentNameCertManager, "metrics-server"},
+	}}
+	deps := c.GetDependencies()
+	if !assert.Len(t, deps, 2) {
+		return
+	}
+	assert.IsType(t, &CertManager{}, deps[0])
+
+	dep, ok := deps[1].(juggler.DependencyMatcher)
+	if !assert.True(t, ok, "not a DependencyMatcher") {
+		return
+	}
+	assert.True(t, dep.MatchesDependency(&AdditionalComponent{Config: &v1beta1.AdditionalComponentConfig{Name: "metrics-server"}}))
+	assert.False(t, dep.MatchesDependency(c))
+}
+
+func Test_AdditionalComponent_OrphanDetectorContexts(t *testing.T) {
+	ctx := newContext(nil, nil, nil)
+	contexts := (&AdditionalComponent{}).OrphanDetectorContexts(ctx)
+	if !assert.Len(t, contexts, 2) {
+		return
+	}
+
+	releases := contexts[0].ConvertFunc(&helmv2.HelmReleaseList{Items: []helmv2.HelmRelease{
+		{ObjectMeta: metav1.ObjectMeta{Name: "kyverno"}},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "additional-reloader"},
+			Spec:       helmv2.HelmReleaseSpec{ChartRef: &helmv2.CrossNamespaceSourceReference{Kind: "OCIRepository"}},
+		},
+	}})
+	if assert.Len(t, releases, 1) {
+		assert.Equal(t, "reloader", releases[0].GetName())
+		assert.False(t, releases[0].IsEnabled())
+		isFluxComponent(
+			returnsHelmRelease(hasChartRef("OCIRepository")),
+		)(t, ctx, releases[0])
+	}
+
+	kustomizations := contexts[1].ConvertFunc(&kustomizev1.KustomizationList{Items: []kustomizev1.Kustomization{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "additional-metrics-server"},
+			Spec:       kustomizev1.KustomizationSpec{SourceRef: kustomizev1.CrossNamespaceSourceReference{Kind: "OCIRepository"}},
+		},
+	}})
+	if assert.Len(t, kustomizations, 1) {
+		assert.Equal(t, "metrics-server", kustomizations[0].GetName())
+		isFluxComponent(
+			returnsKustomization("OCIRepository", ""),
+		)(t, ctx, kustomizations[0])
+	}
+
+	configured := &AdditionalComponent{Config: &v1beta1.AdditionalComponentConfig{Name: "reloader"}}
+	assert.True(t, contexts[0].SameFunc(configured, releases[0]))
+	assert.False(t, contexts[1].SameFunc(configured, kustomizations[0]))
+}
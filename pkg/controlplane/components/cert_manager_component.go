@@ -2,21 +2,14 @@ package components
 
 import (
 	"context"
-	"encoding/json"
 	"strings"
 
-	helmv2 "github.com/fluxcd/helm-controller/api/v2"
-	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
 	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
 	"github.com/openmcp-project/control-plane-operator/pkg/juggler/fluxcd"
-	"github.com/openmcp-project/control-plane-operator/pkg/juggler/hooks"
-	"github.com/openmcp-project/control-plane-operator/pkg/utils"
-	"github.com/openmcp-project/control-plane-operator/pkg/utils/rcontext"
 )
 
 const (
@@ -26,90 +19,73 @@ const (
 )
 
 var _ fluxcd.FluxComponent = &CertManager{}
+var _ juggler.VersionedComponent = &CertManager{}
+var _ juggler.VersionResolvingComponent = &CertManager{}
+var _ UpgradableComponent = &CertManager{}
 var _ TargetComponent = &CertManager{}
 
+// certManagerDefinition is the built-in definition of cert-manager.
+var certManagerDefinition = v1beta1.ComponentDefinitionSpec{
+	ReleaseChannelName: certManagerRelease,
+	ReleaseName:        certManagerRelease,
+	Namespace:          certManagerNamespace,
+	Chart: &v1beta1.ChartSpec{
+		Repository: "https://charts.jetstack.io",
+		Name:       "cert-manager",
+	},
+	Values: &apiextensionsv1.JSON{Raw: []byte(`{"installCRDs":true}`)},
+	ProtectedResources: []metav1.GroupVersionKind{
+		{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"},
+		{Group: "cert-manager.io", Version: "v1", Kind: "Issuer"},
+		{Group: "cert-manager.io", Version: "v1", Kind: "ClusterIssuer"},
+	},
+}
+
 type CertManager struct {
 	Config *v1beta1.CertManagerConfig
+	// Definitions may adjust the built-in definition of the component.
+	Definitions Definitions
+
+	cached lazyHelm
+}
+
+func (c *CertManager) helm() *helmComponent {
+	return c.cached.get(func() *helmComponent {
+		h := &helmComponent{
+			resourceName: strings.ToLower(ComponentNameCertManager),
+			definition:   c.Definitions.builtin(certManagerDefinition),
+		}
+		if c.Config != nil {
+			h.config = helmConfig{Version: c.Config.Version, Chart: c.Config.Chart, Values: c.Config.Values, ValuesFrom: c.Config.ValuesFrom}
+		}
+		return h
+	})
 }
 
 // GetNamespace implements TargetComponent.
 func (c *CertManager) GetNamespace() string {
-	return certManagerNamespace
+	return c.helm().definition.Namespace
 }
 
 func (c *CertManager) IsInstallable(ctx context.Context) (bool, error) {
-	rfn := rcontext.VersionResolver(ctx)
-	if rfn == nil {
-		return false, ErrVersionResolverNotConfigured
-	}
-	if _, err := rfn(certManagerRelease, c.Config.Version); err != nil {
-		return false, err
-	}
-	return true, nil
+	return c.helm().isInstallable(ctx)
 }
 
 func (c *CertManager) GetAvailableVersions(ctx context.Context) ([]string, error) {
-	resolve := rcontext.AvailableVersionsResolver(ctx)
-	return resolve(certManagerRelease)
+	return c.helm().getAvailableVersions(ctx)
 }
 
 func (c *CertManager) BuildSourceRepository(ctx context.Context) (fluxcd.SourceAdapter, error) {
-	rfn := rcontext.VersionResolver(ctx)
-	c.applyDefaultChartSpec(rfn)
-
-	repo := &sourcev1.HelmRepository{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      strings.ToLower(ComponentNameCertManager),
-			Namespace: rcontext.TenantNamespace(ctx),
-		},
-		Spec: sourcev1.HelmRepositorySpec{
-			URL: c.Config.Chart.Repository,
-		},
-	}
-
-	adapter := &fluxcd.HelmRepositoryAdapter{Source: repo}
-	adapter.ApplyDefaults()
-	return adapter, nil
+	return c.helm().buildSourceRepository(ctx), nil
 }
 
-//nolint:dupl
 func (c *CertManager) BuildManifesto(ctx context.Context) (fluxcd.Manifesto, error) {
-	if err := c.applyDefaultValues(); err != nil {
-		return nil, err
-	}
-
-	release := &helmv2.HelmRelease{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      strings.ToLower(ComponentNameCertManager),
-			Namespace: rcontext.TenantNamespace(ctx),
-		},
-		Spec: helmv2.HelmReleaseSpec{
-			Chart: &helmv2.HelmChartTemplate{
-				Spec: helmv2.HelmChartTemplateSpec{
-					Chart:   c.Config.Chart.Name,
-					Version: c.Config.Chart.Version,
-					SourceRef: helmv2.CrossNamespaceObjectReference{
-						Kind: "HelmRepository",
-						Name: strings.ToLower(ComponentNameCertManager),
-					},
-				},
-			},
-			ReleaseName:      certManagerRelease,
-			TargetNamespace:  certManagerNamespace,
-			StorageNamespace: certManagerNamespace,
-			KubeConfig:       rcontext.FluxKubeconfigRef(ctx),
-			Values:           c.Config.Values,
-		},
-	}
-
-	adapter := &fluxcd.HelmReleaseManifesto{Manifest: release}
-	adapter.ApplyDefaults()
-	return adapter, nil
+	return c.helm().buildManifesto(ctx)
 }
 
 // GetDependencies implements Component.
-func (*CertManager) GetDependencies() []juggler.Component {
-	return []juggler.Component{}
+func (c *CertManager) GetDependencies() []juggler.Component {
+	return c.helm().dependencies()
 }
 
 // GetName implements Component.
@@ -122,53 +98,33 @@ func (c *CertManager) IsEnabled() bool {
 	return c.Config != nil && c.Config.Version != ""
 }
 
-func (c *CertManager) applyDefaultChartSpec(rfn v1beta1.VersionResolverFn) {
+// GetVersion implements juggler.VersionedComponent.
+func (c *CertManager) GetVersion() string {
 	if c.Config == nil {
-		c.Config = &v1beta1.CertManagerConfig{}
+		return ""
 	}
+	return c.Config.Version
+}
 
-	comp, _ := rfn(certManagerRelease, c.Config.Version)
-
-	if c.Config.Chart == nil {
-		c.Config.Chart = &v1beta1.ChartSpec{
-			Repository: "https://charts.jetstack.io",
-			Name:       "cert-manager",
-			Version:    comp.Version,
-		}
-	}
+// GetResolvedVersion implements juggler.VersionResolvingComponent.
+func (c *CertManager) GetResolvedVersion(ctx context.Context) (string, error) {
+	return c.helm().getResolvedVersion(ctx)
 }
 
-// Hooks implements Component.
-func (*CertManager) Hooks() juggler.ComponentHooks {
-	return juggler.ComponentHooks{
-		PreUninstall: hooks.PreventOrphanedResources([]schema.GroupVersionKind{
-			{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"},
-			{Group: "cert-manager.io", Version: "v1", Kind: "Issuer"},
-			{Group: "cert-manager.io", Version: "v1", Kind: "ClusterIssuer"},
-		}),
-	}
+// GetReleaseChannelName implements UpgradableComponent.
+func (c *CertManager) GetReleaseChannelName() string {
+	return c.helm().definition.ReleaseChannelName
 }
 
-func (c *CertManager) applyDefaultValues() error {
+// GetUpgradePolicy implements UpgradableComponent.
+func (c *CertManager) GetUpgradePolicy() v1beta1.UpgradePolicy {
 	if c.Config == nil {
-		return nil
-	}
-
-	// Read user-provided values
-	values := map[string]any{}
-	if c.Config.Values != nil {
-		if err := json.Unmarshal(c.Config.Values.Raw, &values); err != nil {
-			return err
-		}
-	}
-
-	// Apply defaults
-	if err := utils.SetNestedDefault(values, true, "installCRDs"); err != nil {
-		return err
+		return ""
 	}
+	return c.Config.UpgradePolicy
+}
 
-	// Write updated values
-	encoded, err := json.Marshal(values)
-	c.Config.Values = &apiextensionsv1.JSON{Raw: encoded}
-	return err
+// Hooks implements Component.
+func (c *CertManager) Hooks() juggler.ComponentHooks {
+	return c.helm().hooks()
 }
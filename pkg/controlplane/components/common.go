@@ -1,15 +1,122 @@
 package components
 
 import (
+	"context"
 	"errors"
+	"strings"
 
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
 	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler/fluxcd"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils/rcontext"
+)
+
+const (
+	ociScheme = "oci://"
+	// helmChartMediaType is the media type of the layer of an OCI artifact that contains the Helm chart.
+	helmChartMediaType = "application/vnd.cncf.helm.chart.content.v1.tar+gzip"
 )
 
 var ErrVersionResolverNotConfigured = errors.New("version resolver is not configured in context")
 
+// resolveVersion returns the concrete version of a component in the ReleaseChannels.
+// The version may be a constraint, which is resolved by the version resolver of the context.
+func resolveVersion(ctx context.Context, componentName string, version string) (string, error) {
+	rfn := rcontext.VersionResolver(ctx)
+	if rfn == nil {
+		return "", ErrVersionResolverNotConfigured
+	}
+	comp, err := rfn(componentName, version)
+	if err != nil {
+		return "", err
+	}
+	return comp.Version, nil
+}
+
+// ociChartURL returns the OCI URL of the chart, or an empty string if the chart is stored in a Helm repository.
+func ociChartURL(chart *v1beta1.ChartSpec) string {
+	if chart.URL != "" {
+		return chart.URL
+	}
+	if strings.HasPrefix(chart.Repository, ociScheme) {
+		return strings.TrimSuffix(chart.Repository, "/") + "/" + chart.Name
+	}
+	return ""
+}
+
+// buildChartSource returns an OCIRepository for charts stored in an OCI registry and a HelmRepository otherwise.
+func buildChartSource(ctx context.Context, name string, chart *v1beta1.ChartSpec) fluxcd.SourceAdapter {
+	objectMeta := metav1.ObjectMeta{
+		Name:      name,
+		Namespace: rcontext.TenantNamespace(ctx),
+	}
+
+	if url := ociChartURL(chart); url != "" {
+		repo := &sourcev1.OCIRepository{
+			ObjectMeta: objectMeta,
+			Spec: sourcev1.OCIRepositorySpec{
+				URL: url,
+				LayerSelector: &sourcev1.OCILayerSelector{
+					MediaType: helmChartMediaType,
+					Operation: sourcev1.OCILayerCopy,
+				},
+			},
+		}
+		if chart.Version != "" {
+			repo.Spec.Reference = &sourcev1.OCIRepositoryRef{Tag: chart.Version}
+		}
+		adapter := &fluxcd.OCIRepositoryAdapter{Source: repo}
+		adapter.ApplyDefaults()
+		return adapter
+	}
+
+	repo := &sourcev1.HelmRepository{
+		ObjectMeta: objectMeta,
+		Spec: sourcev1.HelmRepositorySpec{
+			URL: chart.Repository,
+		},
+	}
+	adapter := &fluxcd.HelmRepositoryAdapter{Source: repo}
+	adapter.ApplyDefaults()
+	return adapter
+}
+
+// setChartSource references the source built by buildChartSource in the HelmRelease.
+func setChartSource(spec *helmv2.HelmReleaseSpec, name string, chart *v1beta1.ChartSpec) {
+	if ociChartURL(chart) != "" {
+		spec.ChartRef = &helmv2.CrossNamespaceSourceReference{
+			Kind: sourcev1.OCIRepositoryKind,
+			Name: name,
+		}
+		return
+	}
+	spec.Chart = &helmv2.HelmChartTemplate{
+		Spec: helmv2.HelmChartTemplateSpec{
+			Chart:   chart.Name,
+			Version: chart.Version,
+			SourceRef: helmv2.CrossNamespaceObjectReference{
+				Kind: sourcev1.HelmRepositoryKind,
+				Name: name,
+			},
+		},
+	}
+}
+
+// UpgradableComponent is a component that can be upgraded automatically to newer versions of the ReleaseChannels.
+type UpgradableComponent interface {
+	juggler.VersionedComponent
+	juggler.GetAvailableVersions
+	// GetReleaseChannelName returns the name of the component in the ReleaseChannels.
+	GetReleaseChannelName() string
+	// GetUpgradePolicy returns the policy for automatic upgrades of the component.
+	GetUpgradePolicy() v1beta1.UpgradePolicy
+}
+
 // TargetComponent is a component that should be installed on the Target (remote/workload) cluster.
 type TargetComponent interface {
 	GetNamespace() string
@@ -0,0 +1,121 @@
+package components
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils"
+)
+
+var ErrComponentDefinitionNotFound = errors.New("component definition not found")
+
+// Definitions are the ComponentDefinitions of the cluster by name.
+type Definitions map[string]*v1beta1.ComponentDefinitionSpec
+
+// ListDefinitions returns the ComponentDefinitions of the cluster.
+// The release channel name and the release name default to the name of the ComponentDefinition.
+func ListDefinitions(ctx context.Context, c client.Client) (Definitions, error) {
+	list := &v1beta1.ComponentDefinitionList{}
+	if err := c.List(ctx, list); err != nil {
+		return nil, err
+	}
+
+	definitions := make(Definitions, len(list.Items))
+	for i := range list.Items {
+		item := &list.Items[i]
+		spec := item.Spec.DeepCopy()
+		spec.ReleaseChannelName = cmp.Or(spec.ReleaseChannelName, item.Name)
+		spec.ReleaseName = cmp.Or(spec.ReleaseName, item.Name)
+		definitions[item.Name] = spec
+	}
+	return definitions, nil
+}
+
+// builtin returns the definition of a built-in component, adjusted by the ComponentDefinition
+// that is named like the component in the ReleaseChannels. The policy rules and protected resources of
+// the ComponentDefinition are added to the built-in ones, so that the RBAC and the protection
+// of a built-in component cannot be removed.
+func (d Definitions) builtin(definition v1beta1.ComponentDefinitionSpec) v1beta1.ComponentDefinitionSpec {
+	override := d[definition.ReleaseChannelName]
+	merged := mergeDefinition(definition, override)
+	if override != nil {
+		merged.PolicyRules = addPolicyRules(definition.PolicyRules, override.PolicyRules)
+		merged.ProtectedResources = addProtectedResources(definition.ProtectedResources, override.ProtectedResources)
+	}
+	return merged
+}
+
+// addPolicyRules returns the rules with the added rules appended.
+func addPolicyRules(rules, added *v1beta1.ComponentPolicyRules) *v1beta1.ComponentPolicyRules {
+	if rules == nil || added == nil {
+		return cmp.Or(added, rules).DeepCopy()
+	}
+	merged := rules.DeepCopy()
+	merged.Admin = append(merged.Admin, added.DeepCopy().Admin...)
+	merged.View = append(merged.View, added.DeepCopy().View...)
+	return merged
+}
+
+// addProtectedResources returns the protected resources with the added resources that are not yet protected.
+func addProtectedResources(resources, added []metav1.GroupVersionKind) []metav1.GroupVersionKind {
+	merged := slices.Clone(resources)
+	for _, gvk := range added {
+		if !slices.Contains(merged, gvk) {
+			merged = append(merged, gvk)
+		}
+	}
+	return merged
+}
+
+// mergeDefinition returns the definition with all fields of the override that are set.
+// The values of the override are merged into the values of the definition.
+func mergeDefinition(definition v1beta1.ComponentDefinitionSpec, override *v1beta1.ComponentDefinitionSpec) v1beta1.ComponentDefinitionSpec {
+	merged := *definition.DeepCopy()
+	if override == nil {
+		return merged
+	}
+
+	override = override.DeepCopy()
+	merged.ReleaseChannelName = cmp.Or(override.ReleaseChannelName, merged.ReleaseChannelName)
+	merged.ReleaseName = cmp.Or(override.ReleaseName, merged.ReleaseName)
+	merged.Namespace = cmp.Or(override.Namespace, merged.Namespace)
+	if override.Chart != nil {
+		merged.Chart = override.Chart
+	}
+	merged.Values = mergeDefinitionValues(merged.Values, override.Values)
+	if override.DependsOn != nil {
+		merged.DependsOn = override.DependsOn
+	}
+	if override.PolicyRules != nil {
+		merged.PolicyRules = override.PolicyRules
+	}
+	if override.ProtectedResources != nil {
+		merged.ProtectedResources = override.ProtectedResources
+	}
+	return merged
+}
+
+// mergeDefinitionValues deep-merges the override into the values. Values that are not objects
+// cannot be merged and replace the values, so that they are reported as invalid when the HelmRelease is built.
+func mergeDefinitionValues(values, override *apiextensionsv1.JSON) *apiextensionsv1.JSON {
+	if values == nil || override == nil {
+		return cmp.Or(override, values)
+	}
+	base, overrideMap := map[string]any{}, map[string]any{}
+	if json.Unmarshal(values.Raw, &base) != nil || json.Unmarshal(override.Raw, &overrideMap) != nil {
+		return override
+	}
+	encoded, err := json.Marshal(utils.MergeMaps(base, overrideMap))
+	if err != nil {
+		return override
+	}
+	return &apiextensionsv1.JSON{Raw: encoded}
+}
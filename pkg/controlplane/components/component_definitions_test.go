@@ -0,0 +1,225 @@
+package components
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/internal/schemes"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler/fluxcd"
+)
+
+func hasReleaseName(expected string) helmReleaseValidationFunc {
+	return func(t *testing.T, ctx context.Context, h *fluxcd.HelmReleaseManifesto) {
+		assert.Equal(t, expected, h.Manifest.Spec.ReleaseName)
+	}
+}
+
+func TestListDefinitions(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(schemes.Local).WithObjects(
+		&v1beta1.ComponentDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "kyverno"},
+			Spec:       v1beta1.ComponentDefinitionSpec{Namespace: "policies"},
+		},
+		&v1beta1.ComponentDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: "policy-engine"},
+			Spec:       v1beta1.ComponentDefinitionSpec{ReleaseChannelName: "gatekeeper", Namespace: "gatekeeper-system"},
+		},
+	).Build()
+
+	definitions, err := ListDefinitions(context.Background(), c)
+	require.NoError(t, err)
+	assert.Equal(t, Definitions{
+		"kyverno":       {ReleaseChannelName: "kyverno", ReleaseName: "kyverno", Namespace: "policies"},
+		"policy-engine": {ReleaseChannelName: "gatekeeper", ReleaseName: "policy-engine", Namespace: "gatekeeper-system"},
+	}, definitions)
+}
+
+func Test_BuiltinDefinition(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		component       func(Definitions) juggler.Component
+		definitions     Definitions
+		validationFuncs []validationFunc
+	}{
+		{
+			desc: "built-in definition without ComponentDefinition",
+			component: func(d Definitions) juggler.Component {
+				return &Kyverno{Config: &v1beta1.KyvernoConfig{Version: "1.2.3"}, Definitions: d}
+			},
+			validationFuncs: []validationFunc{
+				hasPreUninstallHook(),
+				isTargetComponent(
+					hasNamespace(kyvernoNamespace),
+				),
+				isPolicyRulesComponent(
+					hasPolicyRules(),
+				),
+			},
+		},
+		{
+			desc: "ComponentDefinition adjusts the built-in definition but keeps its policy rules and protected resources",
+			component: func(d Definitions) juggler.Component {
+				return &Kyverno{Config: &v1beta1.KyvernoConfig{Version: "1.2.3"}, Definitions: d}
+			},
+			definitions: Definitions{
+				kyvernoRelease: {
+					ReleaseChannelName: kyvernoRelease,
+					ReleaseName:        kyvernoRelease,
+					Namespace:          "policies",
+					Values:             &apiextensionsv1.JSON{Raw: []byte(`{"admissionController":{"replicas":3}}`)},
+					PolicyRules:        &v1beta1.ComponentPolicyRules{},
+					ProtectedResources: []metav1.GroupVersionKind{},
+				},
+			},
+			validationFuncs: []validationFunc{
+				hasPreUninstallHook(),
+				isTargetComponent(
+					hasNamespace("policies"),
+				),
+				isPolicyRulesComponent(
+					hasPolicyRules(),
+				),
+				isFluxComponent(
+					returnsHelmRelease(
+						hasReleaseName(kyvernoRelease),
+						hasHelmValue(3, "admissionController", "replicas"),
+					),
+				),
+			},
+		},
+		{
+			desc: "ComponentDefinition values are merged into the built-in values",
+			component: func(d Definitions) juggler.Component {
+				return &Crossplane{Config: &v1beta1.CrossplaneConfig{Version: "1.2.3"}, Definitions: d}
+			},
+			definitions: Definitions{
+				crossplaneRelease: {Values: &apiextensionsv1.JSON{Raw: []byte(`{"replicas":2}`)}},
+			},
+			validationFuncs: []validationFunc{
+				isFluxComponent(
+					returnsHelmRelease(
+						hasHelmValue(2, "replicas"),
+						hasHelmValue(true, "rbacManager", "skipAggregatedClusterRoles"),
+					),
+				),
+			},
+		},
+		{
+			desc: "ComponentDefinition replaces the dependencies",
+			component: func(d Definitions) juggler.Component {
+				return &BTPServiceOperator{Config: &v1beta1.BTPServiceOperatorConfig{Version: "1.2.3"}, Definitions: d}
+			},
+			definitions: Definitions{
+				btpServiceOperatorRelease: {DependsOn: []string{}},
+			},
+			validationFuncs: []validationFunc{
+				hasDependencies(0),
+			},
+		},
+		{
+			desc: "Crossplane stays in its namespace",
+			component: func(d Definitions) juggler.Component {
+				return &Crossplane{Config: &v1beta1.CrossplaneConfig{Version: "1.2.3"}, Definitions: d}
+			},
+			definitions: Definitions{
+				crossplaneRelease: {Namespace: "other"},
+			},
+			validationFuncs: []validationFunc{
+				isTargetComponent(
+					hasNamespace(CrossplaneNamespace),
+				),
+			},
+		},
+		{
+			desc: "ComponentDefinition adjusts the values of telemetry",
+			component: func(d Definitions) juggler.Component {
+				return &Telemetry{Config: &v1beta1.TelemetryConfig{Enabled: true, Version: "1.2.3"}, DefaultEndpoint: "https://otlp.example.com", Definitions: d}
+			},
+			definitions: Definitions{
+				telemetryRelease: {
+					Namespace: "observability",
+					Values:    &apiextensionsv1.JSON{Raw: []byte(`{"mode":"daemonset"}`)},
+				},
+			},
+			validationFuncs: []validationFunc{
+				isTargetComponent(
+					hasNamespace("observability"),
+				),
+				isFluxComponent(
+					returnsHelmRelease(
+						hasHelmValue("daemonset", "mode"),
+						hasHelmValue("https://otlp.example.com", "config", "exporters", "otlphttp", "endpoint"),
+					),
+				),
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx := newContext(nil, fakeVersionResolver(false), nil)
+			c := tC.component(tC.definitions)
+			for _, vfn := range tC.validationFuncs {
+				vfn(t, ctx, c)
+			}
+		})
+	}
+}
+
+func Test_mergeDefinition(t *testing.T) {
+	rules := &v1beta1.ComponentPolicyRules{
+		Admin: []rbacv1.PolicyRule{{APIGroups: []string{"example.com"}, Resources: []string{"*"}, Verbs: VerbsAdmin}},
+	}
+	definition := v1beta1.ComponentDefinitionSpec{
+		ReleaseChannelName: "example",
+		ReleaseName:        "example",
+		Namespace:          "example-system",
+		DependsOn:          []string{ComponentNameCertManager},
+		PolicyRules:        rules,
+	}
+
+	assert.Equal(t, definition, mergeDefinition(definition, nil))
+
+	merged := mergeDefinition(definition, &v1beta1.ComponentDefinitionSpec{
+		ReleaseName: "other",
+		Chart:       &v1beta1.ChartSpec{Repository: "https://charts.example.com", Name: "example"},
+	})
+	assert.Equal(t, "example", merged.ReleaseChannelName)
+	assert.Equal(t, "other", merged.ReleaseName)
+	assert.Equal(t, "example-system", merged.Namespace)
+	assert.Equal(t, "https://charts.example.com", merged.Chart.Repository)
+	assert.Equal(t, []string{ComponentNameCertManager}, merged.DependsOn)
+	assert.Equal(t, rules, merged.PolicyRules)
+
+	definition.Values = &apiextensionsv1.JSON{Raw: []byte(`{"a":{"b":1,"c":2}}`)}
+	merged = mergeDefinition(definition, &v1beta1.ComponentDefinitionSpec{Values: &apiextensionsv1.JSON{Raw: []byte(`{"a":{"c":3}}`)}})
+	assert.JSONEq(t, `{"a":{"b":1,"c":3}}`, string(merged.Values.Raw))
+}
+
+func TestDefinitions_builtin(t *testing.T) {
+	added := metav1.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Example"}
+	definitions := Definitions{
+		kyvernoRelease: {
+			PolicyRules: &v1beta1.ComponentPolicyRules{
+				Admin: []rbacv1.PolicyRule{{APIGroups: []string{"example.com"}, Resources: []string{"*"}, Verbs: VerbsAdmin}},
+			},
+			ProtectedResources: []metav1.GroupVersionKind{added},
+		},
+	}
+
+	builtin := definitions.builtin(kyvernoDefinition)
+	assert.Len(t, builtin.PolicyRules.Admin, len(kyvernoDefinition.PolicyRules.Admin)+1)
+	assert.Equal(t, kyvernoDefinition.PolicyRules.View, builtin.PolicyRules.View)
+	assert.Equal(t, append(slices.Clone(kyvernoDefinition.ProtectedResources), added), builtin.ProtectedResources)
+	// the built-in definition is not modified
+	assert.NotContains(t, kyvernoDefinition.ProtectedResources, added)
+}
@@ -2,23 +2,16 @@ package components
 
 import (
 	"context"
-	"encoding/json"
 	"strings"
 	"time"
 
-	helmv2 "github.com/fluxcd/helm-controller/api/v2"
-	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
 	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
 	"github.com/openmcp-project/control-plane-operator/pkg/juggler/fluxcd"
-	"github.com/openmcp-project/control-plane-operator/pkg/juggler/hooks"
-	"github.com/openmcp-project/control-plane-operator/pkg/utils"
-	"github.com/openmcp-project/control-plane-operator/pkg/utils/rcontext"
 )
 
 const (
@@ -28,16 +21,19 @@ const (
 )
 
 var _ fluxcd.FluxComponent = &Crossplane{}
+var _ juggler.VersionedComponent = &Crossplane{}
+var _ juggler.VersionResolvingComponent = &Crossplane{}
+var _ UpgradableComponent = &Crossplane{}
 var _ TargetComponent = &Crossplane{}
 var _ PolicyRulesComponent = &Crossplane{}
 
-type Crossplane struct {
-	Config *v1beta1.CrossplaneConfig
-}
-
-// GetPolicyRules implements PolicyRulesComponent.
-func (c *Crossplane) GetPolicyRules() PolicyRules {
-	rules := PolicyRules{
+// crossplaneDefinition is the built-in definition of Crossplane.
+var crossplaneDefinition = v1beta1.ComponentDefinitionSpec{
+	ReleaseChannelName: crossplaneRelease,
+	ReleaseName:        crossplaneRelease,
+	Namespace:          CrossplaneNamespace,
+	Values:             &apiextensionsv1.JSON{Raw: []byte(`{"rbacManager":{"skipAggregatedClusterRoles":true}}`)},
+	PolicyRules: &v1beta1.ComponentPolicyRules{
 		Admin: []rbacv1.PolicyRule{
 			{
 				APIGroups: []string{"pkg.crossplane.io"},
@@ -68,6 +64,13 @@ func (c *Crossplane) GetPolicyRules() PolicyRules {
 				Resources: []string{rbacv1.ResourceAll},
 				Verbs:     VerbsView,
 			},
+			{
+				APIGroups: []string{"pkg.crossplane.io"},
+				Resources: []string{
+					"deploymentruntimeconfigs",
+				},
+				Verbs: VerbsModify,
+			},
 		},
 		View: []rbacv1.PolicyRule{
 			{
@@ -81,93 +84,64 @@ func (c *Crossplane) GetPolicyRules() PolicyRules {
 				Verbs:     VerbsView,
 			},
 		},
-	}
+	},
+	ProtectedResources: []metav1.GroupVersionKind{
+		{Group: "pkg.crossplane.io", Version: "v1", Kind: "Provider"},
+		{Group: "pkg.crossplane.io", Version: "v1", Kind: "ProviderRevision"},
+	},
+}
 
-	rules.Admin = append(rules.Admin, rbacv1.PolicyRule{
-		APIGroups: []string{"pkg.crossplane.io"},
-		Resources: []string{
-			"deploymentruntimeconfigs",
-		},
-		Verbs: VerbsModify,
+type Crossplane struct {
+	Config *v1beta1.CrossplaneConfig
+	// Definitions may adjust the built-in definition of the component.
+	Definitions Definitions
+
+	cached lazyHelm
+}
+
+func (c *Crossplane) helm() *helmComponent {
+	return c.cached.get(func() *helmComponent {
+		h := &helmComponent{
+			resourceName: strings.ToLower(ComponentNameCrossplane),
+			definition:   c.Definitions.builtin(crossplaneDefinition),
+		}
+		// The namespace is fixed, because the providers and their configuration are installed in it.
+		h.definition.Namespace = CrossplaneNamespace
+		if c.Config != nil {
+			h.config = helmConfig{Version: c.Config.Version, Chart: c.Config.Chart, Values: c.Config.Values, ValuesFrom: c.Config.ValuesFrom}
+		}
+		return h
 	})
+}
 
-	return rules
+// GetPolicyRules implements PolicyRulesComponent.
+func (c *Crossplane) GetPolicyRules() PolicyRules {
+	return c.helm().policyRules()
 }
 
 // GetNamespace implements TargetComponent.
 func (c *Crossplane) GetNamespace() string {
-	return CrossplaneNamespace
+	return c.helm().definition.Namespace
 }
 
 func (c *Crossplane) IsInstallable(ctx context.Context) (bool, error) {
-	rfn := rcontext.VersionResolver(ctx)
-	if rfn == nil {
-		return false, ErrVersionResolverNotConfigured
-	}
-	if _, err := rfn(crossplaneRelease, c.Config.Version); err != nil {
-		return false, err
-	}
-	return true, nil
+	return c.helm().isInstallable(ctx)
 }
 
 func (c *Crossplane) GetAvailableVersions(ctx context.Context) ([]string, error) {
-	resolve := rcontext.AvailableVersionsResolver(ctx)
-	return resolve(crossplaneRelease)
+	return c.helm().getAvailableVersions(ctx)
 }
 
 func (c *Crossplane) BuildSourceRepository(ctx context.Context) (fluxcd.SourceAdapter, error) {
-	rfn := rcontext.VersionResolver(ctx)
-	c.applyDefaultChartSpec(rfn)
-
-	repo := &sourcev1.HelmRepository{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      strings.ToLower(ComponentNameCrossplane),
-			Namespace: rcontext.TenantNamespace(ctx),
-		},
-		Spec: sourcev1.HelmRepositorySpec{
-			URL:     c.Config.Chart.Repository,
-			Timeout: &metav1.Duration{Duration: 1 * time.Minute},
-		},
+	adapter := c.helm().buildSourceRepository(ctx)
+	if helmRepo, ok := adapter.(*fluxcd.HelmRepositoryAdapter); ok {
+		helmRepo.Source.Spec.Timeout = &metav1.Duration{Duration: 1 * time.Minute}
 	}
-
-	adapter := &fluxcd.HelmRepositoryAdapter{Source: repo}
-	adapter.ApplyDefaults()
 	return adapter, nil
 }
 
-//nolint:dupl
 func (c *Crossplane) BuildManifesto(ctx context.Context) (fluxcd.Manifesto, error) {
-	if err := c.applyDefaultValues(); err != nil {
-		return nil, err
-	}
-
-	release := &helmv2.HelmRelease{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      strings.ToLower(ComponentNameCrossplane),
-			Namespace: rcontext.TenantNamespace(ctx),
-		},
-		Spec: helmv2.HelmReleaseSpec{
-			Chart: &helmv2.HelmChartTemplate{
-				Spec: helmv2.HelmChartTemplateSpec{
-					Chart:   c.Config.Chart.Name,
-					Version: c.Config.Chart.Version,
-					SourceRef: helmv2.CrossNamespaceObjectReference{
-						Kind: "HelmRepository",
-						Name: strings.ToLower(ComponentNameCrossplane),
-					},
-				},
-			},
-			ReleaseName:      crossplaneRelease,
-			TargetNamespace:  CrossplaneNamespace,
-			StorageNamespace: CrossplaneNamespace,
-			KubeConfig:       rcontext.FluxKubeconfigRef(ctx),
-			Values:           c.Config.Values,
-		},
-	}
-
-	adapter := &fluxcd.HelmReleaseManifesto{Manifest: release}
-	adapter.ApplyDefaults()
-	return adapter, nil
+	return c.helm().buildManifesto(ctx)
 }
 
 // GetName implements Component.
@@ -176,9 +150,8 @@ func (*Crossplane) GetName() string {
 }
 
 // GetDependencies implements Component.
-func (*Crossplane) GetDependencies() []juggler.Component {
-	// No dependencies
-	return []juggler.Component{}
+func (c *Crossplane) GetDependencies() []juggler.Component {
+	return c.helm().dependencies()
 }
 
 // IsEnabled implements Component.
@@ -186,52 +159,33 @@ func (c *Crossplane) IsEnabled() bool {
 	return c.Config != nil && c.Config.Version != ""
 }
 
-func (c *Crossplane) applyDefaultChartSpec(rfn v1beta1.VersionResolverFn) {
+// GetVersion implements juggler.VersionedComponent.
+func (c *Crossplane) GetVersion() string {
 	if c.Config == nil {
-		c.Config = &v1beta1.CrossplaneConfig{}
+		return ""
 	}
+	return c.Config.Version
+}
 
-	comp, _ := rfn(crossplaneRelease, c.Config.Version)
+// GetResolvedVersion implements juggler.VersionResolvingComponent.
+func (c *Crossplane) GetResolvedVersion(ctx context.Context) (string, error) {
+	return c.helm().getResolvedVersion(ctx)
+}
 
-	if c.Config.Chart == nil {
-		c.Config.Chart = &v1beta1.ChartSpec{
-			Repository: comp.HelmRepo,
-			Name:       comp.HelmChart,
-			Version:    comp.Version,
-		}
-	}
+// GetReleaseChannelName implements UpgradableComponent.
+func (c *Crossplane) GetReleaseChannelName() string {
+	return c.helm().definition.ReleaseChannelName
 }
 
-func (c *Crossplane) applyDefaultValues() error {
+// GetUpgradePolicy implements UpgradableComponent.
+func (c *Crossplane) GetUpgradePolicy() v1beta1.UpgradePolicy {
 	if c.Config == nil {
-		return nil
-	}
-
-	// Read user-provided values
-	values := map[string]any{}
-	if c.Config.Values != nil {
-		if err := json.Unmarshal(c.Config.Values.Raw, &values); err != nil {
-			return err
-		}
-	}
-
-	// Apply defaults
-	if err := utils.SetNestedDefault(values, true, "rbacManager", "skipAggregatedClusterRoles"); err != nil {
-		return err
+		return ""
 	}
-
-	// Write updated values
-	encoded, err := json.Marshal(values)
-	c.Config.Values = &apiextensionsv1.JSON{Raw: encoded}
-	return err
+	return c.Config.UpgradePolicy
 }
 
 // Hooks implements Component.
-func (*Crossplane) Hooks() juggler.ComponentHooks {
-	return juggler.ComponentHooks{
-		PreUninstall: hooks.PreventOrphanedResources([]schema.GroupVersionKind{
-			{Group: "pkg.crossplane.io", Version: "v1", Kind: "Provider"},
-			{Group: "pkg.crossplane.io", Version: "v1", Kind: "ProviderRevision"},
-		}),
-	}
+func (c *Crossplane) Hooks() juggler.ComponentHooks {
+	return c.helm().hooks()
 }
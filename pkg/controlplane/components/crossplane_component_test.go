@@ -34,6 +34,7 @@ func Test_Crossplane(t *testing.T) {
 				hasName("Crossplane"),
 				isEnabled(true),
 				isAllowed(false),
+				hasResolvedVersionError(errFake),
 			},
 		},
 		{
@@ -54,6 +55,21 @@ func Test_Crossplane(t *testing.T) {
 				hasAvailableVersionsError(errFake),
 			},
 		},
+		{
+			desc: "chart from OCI registry of the release channel",
+			config: &v1beta1.CrossplaneConfig{
+				Version: "1.2.3",
+			},
+			versionResolver: fakeOCIVersionResolver(),
+			validationFuncs: []validationFunc{
+				isFluxComponent(
+					returnsOCIRepo("oci://registry.example.com/charts/crossplane", "v1.0.0"),
+					returnsHelmRelease(
+						hasChartRef("OCIRepository"),
+					),
+				),
+			},
+		},
 		{
 			desc: "should be enabled",
 			config: &v1beta1.CrossplaneConfig{
@@ -65,6 +81,7 @@ func Test_Crossplane(t *testing.T) {
 				hasName("Crossplane"),
 				isEnabled(true),
 				isAllowed(true),
+				hasResolvedVersion("v1.0.0"),
 				hasPreUninstallHook(),
 				hasDependencies(0),
 				isTargetComponent(
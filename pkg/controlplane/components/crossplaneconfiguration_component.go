@@ -0,0 +1,126 @@
+package components
+
+import (
+	"context"
+
+	crossplanev1 "github.com/crossplane/crossplane/apis/v2/pkg/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/crossplane"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler/object"
+)
+
+var _ object.ObjectComponent = &CrossplaneConfiguration{}
+var _ object.OrphanedObjectsDetector = &CrossplaneConfiguration{}
+var _ TargetComponent = &CrossplaneConfiguration{}
+var _ juggler.VersionedComponent = &CrossplaneConfiguration{}
+var _ juggler.VersionResolvingComponent = &CrossplaneConfiguration{}
+var _ UpgradableComponent = &CrossplaneConfiguration{}
+var _ object.InstalledVersionDetector = &CrossplaneConfiguration{}
+
+// configurationKind describes Crossplane Configuration packages.
+var configurationKind = &crossplanePackageKind{
+	kind:        "Configuration",
+	packageType: crossplane.Configurations,
+	newObject:   func() crossplanev1.Package { return &crossplanev1.Configuration{} },
+	newList:     func() client.ObjectList { return &crossplanev1.ConfigurationList{} },
+	nameFor:     crossplane.ConfigurationNameForPackageConfig,
+	trimPrefix:  crossplane.TrimConfigurationPrefix,
+	newComponent: func(config *v1beta1.CrossplanePackageConfig) juggler.Component {
+		return &CrossplaneConfiguration{Config: config}
+	},
+}
+
+type CrossplaneConfiguration struct {
+	Config  *v1beta1.CrossplanePackageConfig
+	Enabled bool
+	// ImageConfigs are the names of the ImageConfigs that are applied before the package is pulled.
+	ImageConfigs []string
+}
+
+func (c *CrossplaneConfiguration) pkg() *crossplanePackage {
+	return &crossplanePackage{kind: configurationKind, config: c.Config, enabled: c.Enabled, imageConfigs: c.ImageConfigs}
+}
+
+// BuildObjectToReconcile implements object.ObjectComponent.
+func (c *CrossplaneConfiguration) BuildObjectToReconcile(ctx context.Context) (client.Object, types.NamespacedName, error) {
+	return c.pkg().buildObjectToReconcile()
+}
+
+// ReconcileObject implements object.ObjectComponent.
+func (c *CrossplaneConfiguration) ReconcileObject(ctx context.Context, obj client.Object) error {
+	return c.pkg().reconcileObject(ctx, obj)
+}
+
+// OrphanDetectorContext implements object.OrphanedObjectsDetector.
+func (*CrossplaneConfiguration) OrphanDetectorContext(_ context.Context) object.DetectorContext {
+	return configurationKind.orphanDetectorContext()
+}
+
+// IsObjectHealthy implements object.ObjectComponent.
+func (c *CrossplaneConfiguration) IsObjectHealthy(obj client.Object) juggler.ResourceHealthiness {
+	return c.pkg().isObjectHealthy(obj)
+}
+
+// GetInstalledVersion implements object.InstalledVersionDetector.
+func (c *CrossplaneConfiguration) GetInstalledVersion(obj client.Object) string {
+	return c.pkg().getInstalledVersion(obj)
+}
+
+// GetNamespace implements TargetComponent.
+func (c *CrossplaneConfiguration) GetNamespace() string {
+	return CrossplaneNamespace
+}
+
+// IsInstallable implements Component.
+func (c *CrossplaneConfiguration) IsInstallable(ctx context.Context) (bool, error) {
+	return c.pkg().isInstallable(ctx)
+}
+
+// GetAvailableVersions implements Component.
+func (c *CrossplaneConfiguration) GetAvailableVersions(ctx context.Context) ([]string, error) {
+	return c.pkg().getAvailableVersions(ctx)
+}
+
+// GetName implements Component.
+func (c *CrossplaneConfiguration) GetName() string {
+	return c.pkg().name()
+}
+
+// GetDependencies implements Component.
+func (c *CrossplaneConfiguration) GetDependencies() []juggler.Component {
+	return c.pkg().dependencies()
+}
+
+// IsEnabled implements Component.
+func (c *CrossplaneConfiguration) IsEnabled() bool {
+	return c.Enabled
+}
+
+// GetVersion implements juggler.VersionedComponent.
+func (c *CrossplaneConfiguration) GetVersion() string {
+	return c.Config.Version
+}
+
+// GetResolvedVersion implements juggler.VersionResolvingComponent.
+func (c *CrossplaneConfiguration) GetResolvedVersion(ctx context.Context) (string, error) {
+	return c.pkg().getResolvedVersion(ctx)
+}
+
+// GetReleaseChannelName implements UpgradableComponent.
+func (c *CrossplaneConfiguration) GetReleaseChannelName() string {
+	return c.pkg().releaseChannelName()
+}
+
+// GetUpgradePolicy implements UpgradableComponent.
+func (c *CrossplaneConfiguration) GetUpgradePolicy() v1beta1.UpgradePolicy {
+	return c.Config.UpgradePolicy
+}
+
+// Hooks implements Component.
+func (*CrossplaneConfiguration) Hooks() juggler.ComponentHooks {
+	return configurationKind.hooks()
+}
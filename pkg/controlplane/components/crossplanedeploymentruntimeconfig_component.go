@@ -13,6 +13,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/constants"
 	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/crossplane"
 	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
 	"github.com/openmcp-project/control-plane-operator/pkg/juggler/object"
@@ -25,8 +26,9 @@ var _ object.OrphanedObjectsDetector = &CrossplaneDeploymentRuntimeConfig{}
 var _ juggler.StatusVisibility = &CrossplaneDeploymentRuntimeConfig{}
 
 type CrossplaneDeploymentRuntimeConfig struct {
-	Name    string
-	Enabled bool
+	Name          string
+	Enabled       bool
+	RuntimeConfig *v1beta1.CrossplaneProviderRuntimeConfig
 }
 
 // BuildObjectToReconcile implements object.ObjectComponent.
@@ -52,6 +54,8 @@ func (c *CrossplaneDeploymentRuntimeConfig) ReconcileObject(ctx context.Context,
 	// for reference.
 	cdrc.Spec.DeploymentTemplate = applyDeploymentTemplateDefaults(cdrc.Spec.DeploymentTemplate)
 
+	applyRuntimeConfig(cdrc, c.RuntimeConfig)
+
 	return nil
 }
 
@@ -127,6 +131,11 @@ func (c *CrossplaneDeploymentRuntimeConfig) IsStatusInternal() bool {
 	return true
 }
 
+// packageRuntimeContainer is the name of the provider container. We need to hardcode this, since crossplane keeps it private.
+// nolint:lll
+// https://github.com/crossplane/crossplane/blob/bee7c095b2c8b2e157a3154cbb85bfc8e54ace6f/internal/controller/pkg/revision/runtime.go#L35
+const packageRuntimeContainer = "package-runtime"
+
 // applyDeploymentTemplateDefaults makes sure that all required fields are set
 // on a DeploymentTemplate. Specifically this means that we need to set
 // template.spec, template.spec.selector, and template.spec.template.containers
@@ -152,22 +161,23 @@ func applyDeploymentTemplateDefaults(in *crossplanev1beta1.DeploymentTemplate) *
 		out.Spec.Selector = &metav1.LabelSelector{}
 	}
 
-	if len(out.Spec.Template.Spec.Containers) == 0 {
-		out.Spec.Template.Spec.Containers = []v1.Container{
-			{
-				// we need to hardcode this, since crossplane keeps it private.
-				// It is the default, and only, name a provider container can have
-				// nolint:lll
-				// https://github.com/crossplane/crossplane/blob/bee7c095b2c8b2e157a3154cbb85bfc8e54ace6f/internal/controller/pkg/revision/runtime.go#L35
-				Name: "package-runtime",
-				Args: []string{},
-			},
-		}
-	}
+	packageRuntime(&out.Spec.Template.Spec)
 
 	return out
 }
 
+// packageRuntime returns the provider container of the pod spec. It is appended if it does not exist yet,
+// e.g. because only sidecar containers have been added to the DeploymentRuntimeConfig.
+func packageRuntime(spec *v1.PodSpec) *v1.Container {
+	for i := range spec.Containers {
+		if spec.Containers[i].Name == packageRuntimeContainer {
+			return &spec.Containers[i]
+		}
+	}
+	spec.Containers = append(spec.Containers, v1.Container{Name: packageRuntimeContainer, Args: []string{}})
+	return &spec.Containers[len(spec.Containers)-1]
+}
+
 // applyServiceAccountTemplateDefaults makes sure that the name of a
 // serviceAccount matches the name of the passed in provider
 func applyServiceAccountTemplateDefaults(
@@ -189,3 +199,37 @@ func applyServiceAccountTemplateDefaults(
 
 	return out
 }
+
+// applyRuntimeConfig renders the runtime config of the ControlPlane into the DeploymentRuntimeConfig.
+// Settings that are not configured, e.g. because the runtime config has been removed, are reset.
+func applyRuntimeConfig(cdrc *crossplanev1beta1.DeploymentRuntimeConfig, rc *v1beta1.CrossplaneProviderRuntimeConfig) {
+	if rc == nil {
+		rc = &v1beta1.CrossplaneProviderRuntimeConfig{}
+	}
+	rc = rc.DeepCopy()
+
+	cdrc.Spec.ServiceAccountTemplate.Metadata.Annotations = rc.ServiceAccountAnnotations
+
+	deployment := cdrc.Spec.DeploymentTemplate.Spec
+	deployment.Replicas = rc.Replicas
+	deployment.Template.Spec.NodeSelector = rc.NodeSelector
+	deployment.Template.Spec.Tolerations = rc.Tolerations
+
+	container := packageRuntime(&deployment.Template.Spec)
+	container.Env = rc.Env
+	container.Resources = v1.ResourceRequirements{}
+	if rc.Resources != nil {
+		container.Resources = *rc.Resources
+	}
+
+	// args may also be changed in the DeploymentRuntimeConfig itself, they are only overwritten if configured.
+	// The annotation remembers that they have been configured, so that they are reset once they are removed.
+	switch {
+	case rc.Args != nil:
+		container.Args = rc.Args
+		metav1.SetMetaDataAnnotation(&cdrc.ObjectMeta, constants.AnnotationRuntimeConfigArgs, "true")
+	case metav1.HasAnnotation(cdrc.ObjectMeta, constants.AnnotationRuntimeConfigArgs):
+		container.Args = []string{}
+		delete(cdrc.Annotations, constants.AnnotationRuntimeConfigArgs)
+	}
+}
@@ -9,9 +9,12 @@ import (
 	"github.com/google/go-cmp/cmp"
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
 
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/constants"
 	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
 )
 
@@ -148,6 +151,23 @@ func TestApplyDeploymentTemplateDefaults(t *testing.T) {
 				return dt
 			},
 		},
+		{
+			desc: "provider container should be added next to other containers",
+			inDt: &crossplanev1beta1.DeploymentTemplate{
+				Spec: &appsv1.DeploymentSpec{
+					Template: v1.PodTemplateSpec{
+						Spec: v1.PodSpec{
+							Containers: []v1.Container{{Name: "sidecar"}},
+						},
+					},
+				},
+			},
+			expDt: func() *crossplanev1beta1.DeploymentTemplate {
+				dt := defaultDeployTemplate()
+				dt.Spec.Template.Spec.Containers = append([]v1.Container{{Name: "sidecar"}}, dt.Spec.Template.Spec.Containers...)
+				return dt
+			},
+		},
 	}
 
 	for _, tC := range testCases {
@@ -221,3 +241,139 @@ func TestApplyServiceAccountTemplateDefaults(t *testing.T) {
 	}
 
 }
+
+func TestApplyRuntimeConfig(t *testing.T) {
+	providerName := "provider-aws"
+	defaultSpec := func() crossplanev1beta1.DeploymentRuntimeConfigSpec {
+		return crossplanev1beta1.DeploymentRuntimeConfigSpec{
+			DeploymentTemplate:     applyDeploymentTemplateDefaults(nil),
+			ServiceAccountTemplate: applyServiceAccountTemplateDefaults(nil, providerName),
+		}
+	}
+	configuredSpec := func() crossplanev1beta1.DeploymentRuntimeConfigSpec {
+		spec := defaultSpec()
+		spec.ServiceAccountTemplate.Metadata.Annotations = map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/provider-aws"}
+		spec.DeploymentTemplate.Spec.Replicas = ptr.To[int32](2)
+		spec.DeploymentTemplate.Spec.Template.Spec.NodeSelector = map[string]string{"pool": "crossplane"}
+		spec.DeploymentTemplate.Spec.Template.Spec.Tolerations = []v1.Toleration{{Key: "dedicated", Operator: v1.TolerationOpExists}}
+		container := &spec.DeploymentTemplate.Spec.Template.Spec.Containers[0]
+		container.Args = []string{"--poll=5m"}
+		container.Resources = v1.ResourceRequirements{
+			Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
+		}
+		container.Env = []v1.EnvVar{{
+			Name: "TOKEN",
+			ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+				LocalObjectReference: v1.LocalObjectReference{Name: "provider-aws"},
+				Key:                  "token",
+			}},
+		}}
+		return spec
+	}
+	argsConfigured := map[string]string{constants.AnnotationRuntimeConfigArgs: "true"}
+
+	testCases := []struct {
+		desc           string
+		inSpec         func() crossplanev1beta1.DeploymentRuntimeConfigSpec
+		inAnnotations  map[string]string
+		rc             *v1beta1.CrossplaneProviderRuntimeConfig
+		expSpec        func() crossplanev1beta1.DeploymentRuntimeConfigSpec
+		expAnnotations map[string]string
+	}{
+		{
+			desc: "without runtime config, changed args are kept",
+			inSpec: func() crossplanev1beta1.DeploymentRuntimeConfigSpec {
+				spec := defaultSpec()
+				spec.DeploymentTemplate.Spec.Template.Spec.Containers[0].Args = []string{"--debug"}
+				return spec
+			},
+			expSpec: func() crossplanev1beta1.DeploymentRuntimeConfigSpec {
+				spec := defaultSpec()
+				spec.DeploymentTemplate.Spec.Template.Spec.Containers[0].Args = []string{"--debug"}
+				return spec
+			},
+		},
+		{
+			desc:   "runtime config is rendered",
+			inSpec: defaultSpec,
+			rc: &v1beta1.CrossplaneProviderRuntimeConfig{
+				Replicas: ptr.To[int32](2),
+				Resources: &v1.ResourceRequirements{
+					Limits: v1.ResourceList{v1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+				Args: []string{"--poll=5m"},
+				Env: []v1.EnvVar{{
+					Name: "TOKEN",
+					ValueFrom: &v1.EnvVarSource{SecretKeyRef: &v1.SecretKeySelector{
+						LocalObjectReference: v1.LocalObjectReference{Name: "provider-aws"},
+						Key:                  "token",
+					}},
+				}},
+				NodeSelector:              map[string]string{"pool": "crossplane"},
+				Tolerations:               []v1.Toleration{{Key: "dedicated", Operator: v1.TolerationOpExists}},
+				ServiceAccountAnnotations: map[string]string{"eks.amazonaws.com/role-arn": "arn:aws:iam::123456789012:role/provider-aws"},
+			},
+			expSpec:        configuredSpec,
+			expAnnotations: argsConfigured,
+		},
+		{
+			desc: "removed settings are reset, args are kept",
+			inSpec: func() crossplanev1beta1.DeploymentRuntimeConfigSpec {
+				spec := defaultSpec()
+				spec.DeploymentTemplate.Spec.Replicas = ptr.To[int32](2)
+				spec.DeploymentTemplate.Spec.Template.Spec.NodeSelector = map[string]string{"pool": "crossplane"}
+				spec.DeploymentTemplate.Spec.Template.Spec.Containers[0].Args = []string{"--debug"}
+				return spec
+			},
+			rc: &v1beta1.CrossplaneProviderRuntimeConfig{},
+			expSpec: func() crossplanev1beta1.DeploymentRuntimeConfigSpec {
+				spec := defaultSpec()
+				spec.DeploymentTemplate.Spec.Template.Spec.Containers[0].Args = []string{"--debug"}
+				return spec
+			},
+		},
+		{
+			desc: "runtime config is rendered into the provider container",
+			inSpec: func() crossplanev1beta1.DeploymentRuntimeConfigSpec {
+				spec := defaultSpec()
+				podSpec := &spec.DeploymentTemplate.Spec.Template.Spec
+				podSpec.Containers = append([]v1.Container{{Name: "sidecar"}}, podSpec.Containers...)
+				return spec
+			},
+			rc: &v1beta1.CrossplaneProviderRuntimeConfig{Args: []string{"--poll=5m"}},
+			expSpec: func() crossplanev1beta1.DeploymentRuntimeConfigSpec {
+				spec := defaultSpec()
+				podSpec := &spec.DeploymentTemplate.Spec.Template.Spec
+				podSpec.Containers[0].Args = []string{"--poll=5m"}
+				podSpec.Containers = append([]v1.Container{{Name: "sidecar"}}, podSpec.Containers...)
+				return spec
+			},
+			expAnnotations: argsConfigured,
+		},
+		{
+			desc:           "removed runtime config is reset including its args",
+			inSpec:         configuredSpec,
+			inAnnotations:  argsConfigured,
+			expSpec:        defaultSpec,
+			expAnnotations: map[string]string{},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			res := &crossplanev1beta1.DeploymentRuntimeConfig{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tC.inAnnotations},
+				Spec:       tC.inSpec(),
+			}
+			applyRuntimeConfig(res, tC.rc)
+			exp := tC.expSpec()
+
+			if !cmp.Equal(res.Spec, exp) {
+				t.Error(cmp.Diff(res.Spec, exp))
+			}
+			if !cmp.Equal(res.Annotations, tC.expAnnotations) {
+				t.Error(cmp.Diff(res.Annotations, tC.expAnnotations))
+			}
+		})
+	}
+}
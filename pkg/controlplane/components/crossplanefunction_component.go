@@ -0,0 +1,126 @@
+package components
+
+import (
+	"context"
+
+	crossplanev1 "github.com/crossplane/crossplane/apis/v2/pkg/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/crossplane"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler/object"
+)
+
+var _ object.ObjectComponent = &CrossplaneFunction{}
+var _ object.OrphanedObjectsDetector = &CrossplaneFunction{}
+var _ TargetComponent = &CrossplaneFunction{}
+var _ juggler.VersionedComponent = &CrossplaneFunction{}
+var _ juggler.VersionResolvingComponent = &CrossplaneFunction{}
+var _ UpgradableComponent = &CrossplaneFunction{}
+var _ object.InstalledVersionDetector = &CrossplaneFunction{}
+
+// functionKind describes Crossplane Function packages.
+var functionKind = &crossplanePackageKind{
+	kind:        "Function",
+	packageType: crossplane.Functions,
+	newObject:   func() crossplanev1.Package { return &crossplanev1.Function{} },
+	newList:     func() client.ObjectList { return &crossplanev1.FunctionList{} },
+	nameFor:     crossplane.FunctionNameForPackageConfig,
+	trimPrefix:  crossplane.TrimFunctionPrefix,
+	newComponent: func(config *v1beta1.CrossplanePackageConfig) juggler.Component {
+		return &CrossplaneFunction{Config: config}
+	},
+}
+
+type CrossplaneFunction struct {
+	Config  *v1beta1.CrossplanePackageConfig
+	Enabled bool
+	// ImageConfigs are the names of the ImageConfigs that are applied before the package is pulled.
+	ImageConfigs []string
+}
+
+func (c *CrossplaneFunction) pkg() *crossplanePackage {
+	return &crossplanePackage{kind: functionKind, config: c.Config, enabled: c.Enabled, imageConfigs: c.ImageConfigs}
+}
+
+// BuildObjectToReconcile implements object.ObjectComponent.
+func (c *CrossplaneFunction) BuildObjectToReconcile(ctx context.Context) (client.Object, types.NamespacedName, error) {
+	return c.pkg().buildObjectToReconcile()
+}
+
+// ReconcileObject implements object.ObjectComponent.
+func (c *CrossplaneFunction) ReconcileObject(ctx context.Context, obj client.Object) error {
+	return c.pkg().reconcileObject(ctx, obj)
+}
+
+// OrphanDetectorContext implements object.OrphanedObjectsDetector.
+func (*CrossplaneFunction) OrphanDetectorContext(_ context.Context) object.DetectorContext {
+	return functionKind.orphanDetectorContext()
+}
+
+// IsObjectHealthy implements object.ObjectComponent.
+func (c *CrossplaneFunction) IsObjectHealthy(obj client.Object) juggler.ResourceHealthiness {
+	return c.pkg().isObjectHealthy(obj)
+}
+
+// GetInstalledVersion implements object.InstalledVersionDetector.
+func (c *CrossplaneFunction) GetInstalledVersion(obj client.Object) string {
+	return c.pkg().getInstalledVersion(obj)
+}
+
+// GetNamespace implements TargetComponent.
+func (c *CrossplaneFunction) GetNamespace() string {
+	return CrossplaneNamespace
+}
+
+// IsInstallable implements Component.
+func (c *CrossplaneFunction) IsInstallable(ctx context.Context) (bool, error) {
+	return c.pkg().isInstallable(ctx)
+}
+
+// GetAvailableVersions implements Component.
+func (c *CrossplaneFunction) GetAvailableVersions(ctx context.Context) ([]string, error) {
+	return c.pkg().getAvailableVersions(ctx)
+}
+
+// GetName implements Component.
+func (c *CrossplaneFunction) GetName() string {
+	return c.pkg().name()
+}
+
+// GetDependencies implements Component.
+func (c *CrossplaneFunction) GetDependencies() []juggler.Component {
+	return c.pkg().dependencies()
+}
+
+// IsEnabled implements Component.
+func (c *CrossplaneFunction) IsEnabled() bool {
+	return c.Enabled
+}
+
+// GetVersion implements juggler.VersionedComponent.
+func (c *CrossplaneFunction) GetVersion() string {
+	return c.Config.Version
+}
+
+// GetResolvedVersion implements juggler.VersionResolvingComponent.
+func (c *CrossplaneFunction) GetResolvedVersion(ctx context.Context) (string, error) {
+	return c.pkg().getResolvedVersion(ctx)
+}
+
+// GetReleaseChannelName implements UpgradableComponent.
+func (c *CrossplaneFunction) GetReleaseChannelName() string {
+	return c.pkg().releaseChannelName()
+}
+
+// GetUpgradePolicy implements UpgradableComponent.
+func (c *CrossplaneFunction) GetUpgradePolicy() v1beta1.UpgradePolicy {
+	return c.Config.UpgradePolicy
+}
+
+// Hooks implements Component.
+func (*CrossplaneFunction) Hooks() juggler.ComponentHooks {
+	return functionKind.hooks()
+}
@@ -0,0 +1,126 @@
+package components
+
+import (
+	"context"
+	"fmt"
+
+	crossplanev1beta1 "github.com/crossplane/crossplane/apis/v2/pkg/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/crossplane"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler/object"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils"
+)
+
+var _ object.ObjectComponent = &CrossplaneImageConfig{}
+var _ object.OrphanedObjectsDetector = &CrossplaneImageConfig{}
+var _ TargetComponent = &CrossplaneImageConfig{}
+
+// CrossplaneImageConfig creates an ImageConfig, which rewrites the registry of Crossplane package images
+// or attaches a pull secret to them by prefix.
+type CrossplaneImageConfig struct {
+	Config  *v1beta1.CrossplaneImageConfig
+	Enabled bool
+}
+
+// BuildObjectToReconcile implements object.ObjectComponent.
+func (c *CrossplaneImageConfig) BuildObjectToReconcile(ctx context.Context) (client.Object, types.NamespacedName, error) {
+	return &crossplanev1beta1.ImageConfig{}, types.NamespacedName{Name: c.Config.Name}, nil
+}
+
+// ReconcileObject implements object.ObjectComponent.
+func (c *CrossplaneImageConfig) ReconcileObject(ctx context.Context, obj client.Object) error {
+	crossplane.ReconcileImageConfig(obj.(*crossplanev1beta1.ImageConfig), *c.Config)
+	return nil
+}
+
+// OrphanDetectorContext implements object.OrphanedObjectsDetector.
+func (*CrossplaneImageConfig) OrphanDetectorContext(_ context.Context) object.DetectorContext {
+	return object.DetectorContext{
+		ListType: &crossplanev1beta1.ImageConfigList{},
+		FilterCriteria: object.FilterCriteria{
+			utils.IsManaged(),
+			utils.HasComponentLabel(),
+		},
+		ConvertFunc: func(list client.ObjectList) []juggler.Component {
+			items := (list.(*crossplanev1beta1.ImageConfigList)).Items
+			imageConfigs := make([]juggler.Component, 0, len(items))
+			for _, imageConfig := range items {
+				imageConfigs = append(imageConfigs, &CrossplaneImageConfig{
+					Config: &v1beta1.CrossplaneImageConfig{Name: imageConfig.Name},
+				})
+			}
+			return imageConfigs
+		},
+		SameFunc: func(configured, detected juggler.Component) bool {
+			return configured.(*CrossplaneImageConfig).Config.Name == detected.(*CrossplaneImageConfig).Config.Name
+		},
+	}
+}
+
+// IsObjectHealthy implements object.ObjectComponent.
+func (c *CrossplaneImageConfig) IsObjectHealthy(obj client.Object) juggler.ResourceHealthiness {
+	return juggler.ResourceHealthiness{
+		// ImageConfigs have no conditions.
+		Healthy: obj.GetDeletionTimestamp() == nil,
+		Message: fmt.Sprintf("ImageConfig %s applied", c.Config.Name),
+	}
+}
+
+// GetNamespace implements TargetComponent.
+func (c *CrossplaneImageConfig) GetNamespace() string {
+	// ImageConfigs are cluster-scoped, their pull secrets are stored in the namespace of Crossplane.
+	return CrossplaneNamespace
+}
+
+// IsInstallable implements Component.
+func (c *CrossplaneImageConfig) IsInstallable(ctx context.Context) (bool, error) {
+	return true, nil
+}
+
+// GetName implements Component.
+func (c *CrossplaneImageConfig) GetName() string {
+	return "ImageConfig" + formatPackageName(c.Config.Name)
+}
+
+// GetDependencies implements Component.
+// The ImageConfig is created once Crossplane is installed and its pull secret has been copied.
+func (c *CrossplaneImageConfig) GetDependencies() []juggler.Component {
+	deps := []juggler.Component{&Crossplane{}}
+	if c.Config.PullSecretRef != nil {
+		deps = append(deps, &Secret{Target: types.NamespacedName{
+			Name:      c.Config.PullSecretRef.Name,
+			Namespace: CrossplaneNamespace,
+		}})
+	}
+	return deps
+}
+
+// MatchesDependency implements juggler.DependencyMatcher.
+// A CrossplaneImageConfig without config matches all ImageConfigs.
+func (c *CrossplaneImageConfig) MatchesDependency(registered juggler.Component) bool {
+	return c.Config == nil || registered.GetName() == c.GetName()
+}
+
+// imageConfigDependencies returns the ImageConfigs with the given names as dependencies of a package,
+// so that they apply when the package is pulled.
+func imageConfigDependencies(names []string) []juggler.Component {
+	deps := make([]juggler.Component, 0, len(names))
+	for _, name := range names {
+		deps = append(deps, &CrossplaneImageConfig{Config: &v1beta1.CrossplaneImageConfig{Name: name}})
+	}
+	return deps
+}
+
+// IsEnabled implements Component.
+func (c *CrossplaneImageConfig) IsEnabled() bool {
+	return c.Enabled
+}
+
+// Hooks implements Component.
+func (*CrossplaneImageConfig) Hooks() juggler.ComponentHooks {
+	return juggler.ComponentHooks{}
+}
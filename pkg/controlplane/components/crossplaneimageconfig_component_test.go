@@ -0,0 +1,118 @@
+//nolint:dupl,lll
+package components
+
+import (
+	"testing"
+
+	crossplanev1beta1 "github.com/crossplane/crossplane/apis/v2/pkg/v1beta1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+)
+
+var (
+	imageConfigMirror = &v1beta1.CrossplaneImageConfig{
+		Name:          "upbound-mirror",
+		MatchPrefixes: []string{"xpkg.upbound.io"},
+		RewritePrefix: "registry.example.com/upbound",
+	}
+	imageConfigPrivate = &v1beta1.CrossplaneImageConfig{
+		Name:          "private",
+		MatchPrefixes: []string{"registry.example.com/private"},
+		PullSecretRef: &corev1.LocalObjectReference{Name: "registry-credentials"},
+	}
+)
+
+func Test_CrossplaneImageConfig(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		enabled         bool
+		config          *v1beta1.CrossplaneImageConfig
+		validationFuncs []validationFunc
+	}{
+		{
+			desc:    "should be disabled",
+			enabled: false,
+			config:  imageConfigMirror,
+			validationFuncs: []validationFunc{
+				hasName("ImageConfigUpboundMirror"),
+				isEnabled(false),
+			},
+		},
+		{
+			desc:    "should depend on its pull secret",
+			enabled: true,
+			config:  imageConfigPrivate,
+			validationFuncs: []validationFunc{
+				hasName("ImageConfigPrivate"),
+				hasDependencies(2),
+			},
+		},
+		{
+			desc:    "should be enabled",
+			enabled: true,
+			config:  imageConfigMirror,
+			validationFuncs: []validationFunc{
+				hasName("ImageConfigUpboundMirror"),
+				isEnabled(true),
+				isAllowed(true),
+				hasDependencies(1),
+				hasNoHooks(),
+				isTargetComponent(
+					hasNamespace(CrossplaneNamespace),
+				),
+				isObjectComponent(
+					objectIsType(&crossplanev1beta1.ImageConfig{}),
+					canCheckHealthiness(&crossplanev1beta1.ImageConfig{}, juggler.ResourceHealthiness{
+						Healthy: true,
+						Message: "ImageConfig upbound-mirror applied",
+					}),
+					canBuildAndReconcile(nil),
+					implementsOrphanedObjectsDetector(
+						listTypeIs(&crossplanev1beta1.ImageConfigList{}),
+						hasFilterCriteria(2),
+						canConvert(&crossplanev1beta1.ImageConfigList{Items: []crossplanev1beta1.ImageConfig{
+							{ObjectMeta: metav1.ObjectMeta{Name: "upbound-mirror"}},
+						}}, 1),
+						canCheckSame(
+							&CrossplaneImageConfig{Config: imageConfigMirror},
+							&CrossplaneImageConfig{Config: &v1beta1.CrossplaneImageConfig{Name: "upbound-mirror"}},
+							true),
+						canCheckSame(
+							&CrossplaneImageConfig{Config: imageConfigMirror},
+							&CrossplaneImageConfig{Config: &v1beta1.CrossplaneImageConfig{Name: "private"}},
+							false),
+					),
+				),
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx := newContext(nil, nil, nil)
+			c := &CrossplaneImageConfig{Config: tC.config, Enabled: tC.enabled}
+			for _, vfn := range tC.validationFuncs {
+				vfn(t, ctx, c)
+			}
+		})
+	}
+}
+
+func Test_imageConfigDependencies(t *testing.T) {
+	imageConfigs := []string{imageConfigMirror.Name}
+	packages := []juggler.Component{
+		&CrossplaneProvider{Config: &v1beta1.CrossplaneProviderConfig{Name: "kubernetes"}, ImageConfigs: imageConfigs},
+		&CrossplaneConfiguration{Config: &v1beta1.CrossplanePackageConfig{Name: "sample"}, ImageConfigs: imageConfigs},
+		&CrossplaneFunction{Config: &v1beta1.CrossplanePackageConfig{Name: "sample"}, ImageConfigs: imageConfigs},
+	}
+	for _, p := range packages {
+		deps := p.GetDependencies()
+		assert.Len(t, deps, 2)
+		dep := deps[1].(*CrossplaneImageConfig)
+		assert.True(t, dep.MatchesDependency(&CrossplaneImageConfig{Config: imageConfigMirror}))
+		assert.False(t, dep.MatchesDependency(&CrossplaneImageConfig{Config: imageConfigPrivate}))
+	}
+}
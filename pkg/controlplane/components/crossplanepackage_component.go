@@ -0,0 +1,145 @@
+package components
+
+import (
+	"context"
+
+	crossplanev1 "github.com/crossplane/crossplane/apis/v2/pkg/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/crossplane"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler/object"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils/rcontext"
+)
+
+// crossplanePackageKind describes a kind of Crossplane packages that are installed from the ReleaseChannels,
+// i.e. Configurations and Functions.
+type crossplanePackageKind struct {
+	// kind is the kind of the Crossplane manifest, e.g. "Configuration".
+	kind        string
+	packageType crossplane.PackageType
+	newObject   func() crossplanev1.Package
+	newList     func() client.ObjectList
+	// nameFor returns the name of the package in the ReleaseChannels and of its manifest, i.e. the name with a prefix.
+	nameFor    func(*v1beta1.CrossplanePackageConfig) string
+	trimPrefix func(string) string
+	// newComponent returns the component of a package that has been detected in the cluster.
+	newComponent func(*v1beta1.CrossplanePackageConfig) juggler.Component
+}
+
+// packageComponent is implemented by the components of Crossplane packages.
+type packageComponent interface {
+	pkg() *crossplanePackage
+}
+
+// crossplanePackage implements the components of Crossplane packages of a kind.
+type crossplanePackage struct {
+	kind         *crossplanePackageKind
+	config       *v1beta1.CrossplanePackageConfig
+	enabled      bool
+	imageConfigs []string
+}
+
+func (p *crossplanePackage) buildObjectToReconcile() (client.Object, types.NamespacedName, error) {
+	return p.kind.newObject(), types.NamespacedName{
+		Name: p.kind.nameFor(p.config),
+	}, nil
+}
+
+func (p *crossplanePackage) reconcileObject(ctx context.Context, obj client.Object) error {
+	versionResolveFn := rcontext.VersionResolver(ctx)
+	copy := *p.config
+
+	// When uninstalling a package, we don't need to resolve the version.
+	if p.enabled {
+		// Resolve package and version by package name
+		comp, err := versionResolveFn(p.releaseChannelName(), p.config.Version)
+		if err != nil {
+			return err
+		}
+
+		copy.Package = comp.DockerRef
+		copy.Version = comp.Version
+	}
+
+	return crossplane.ReconcilePackage(obj.(crossplanev1.Package), copy)
+}
+
+func (k *crossplanePackageKind) orphanDetectorContext() object.DetectorContext {
+	return object.DetectorContext{
+		ListType: k.newList(),
+		FilterCriteria: object.FilterCriteria{
+			utils.IsManaged(),
+			utils.HasComponentLabel(),
+		},
+		ConvertFunc: func(list client.ObjectList) []juggler.Component {
+			items, _ := meta.ExtractList(list)
+			packages := make([]juggler.Component, 0, len(items))
+			for _, item := range items {
+				// since we only need the name for the SameFunc, there is no need to copy the whole object
+				packages = append(packages, k.newComponent(&v1beta1.CrossplanePackageConfig{
+					Name: k.trimPrefix(item.(metav1.Object).GetName()),
+				}))
+			}
+			return packages
+		},
+		SameFunc: func(configured, detected juggler.Component) bool {
+			configuredP := configured.(packageComponent).pkg()
+			detectedP := detected.(packageComponent).pkg()
+			return k.trimPrefix(configuredP.config.Name) == detectedP.config.Name
+		},
+	}
+}
+
+func (p *crossplanePackage) isObjectHealthy(obj client.Object) juggler.ResourceHealthiness {
+	return packageHealthiness(p.kind.kind, obj.(crossplanev1.Package))
+}
+
+func (p *crossplanePackage) getInstalledVersion(obj client.Object) string {
+	return crossplane.PackageVersion(obj.(crossplanev1.Package))
+}
+
+func (p *crossplanePackage) isInstallable(ctx context.Context) (bool, error) {
+	rfn := rcontext.VersionResolver(ctx)
+	if rfn == nil {
+		return false, ErrVersionResolverNotConfigured
+	}
+	if _, err := rfn(p.releaseChannelName(), p.config.Version); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (p *crossplanePackage) getAvailableVersions(ctx context.Context) ([]string, error) {
+	resolve := rcontext.AvailableVersionsResolver(ctx)
+	return resolve(p.releaseChannelName())
+}
+
+func (p *crossplanePackage) name() string {
+	return formatPackageName(p.kind.nameFor(p.config))
+}
+
+func (p *crossplanePackage) dependencies() []juggler.Component {
+	return append([]juggler.Component{&Crossplane{}}, imageConfigDependencies(p.imageConfigs)...)
+}
+
+func (p *crossplanePackage) getResolvedVersion(ctx context.Context) (string, error) {
+	return resolveVersion(ctx, p.releaseChannelName(), p.config.Version)
+}
+
+func (p *crossplanePackage) releaseChannelName() string {
+	return p.kind.nameFor(p.config)
+}
+
+// hooks prevent the installation of packages as long as the policy for the package type is not installed.
+func (k *crossplanePackageKind) hooks() juggler.ComponentHooks {
+	return juggler.ComponentHooks{
+		PreInstall: crossplane.CheckIfPolicyIsInstalled(k.packageType),
+		PreUpdate:  crossplane.CheckIfPolicyIsInstalled(k.packageType),
+	}
+}
@@ -0,0 +1,179 @@
+//nolint:lll
+package components
+
+import (
+	"testing"
+
+	commonv1 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	crossplanev1 "github.com/crossplane/crossplane/apis/v2/pkg/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+)
+
+var packageHealthyConditions = commonv1.ConditionedStatus{
+	Conditions: []commonv1.Condition{
+		{
+			Type:   crossplanev1.TypeInstalled,
+			Status: corev1.ConditionTrue,
+		},
+		{
+			Type:    crossplanev1.TypeHealthy,
+			Status:  corev1.ConditionTrue,
+			Reason:  "Healthy",
+			Message: "Healthy",
+		},
+	},
+}
+
+func Test_CrossplanePackage(t *testing.T) {
+	kinds := []struct {
+		kind string
+		// component returns the component of the given kind.
+		component func(config *v1beta1.CrossplanePackageConfig, enabled bool) juggler.Component
+		// name is the name of a package without prefix, componentName the name of its component.
+		name          string
+		componentName string
+		// prefix is the prefix of the manifests of the kind.
+		prefix  string
+		object  client.Object
+		healthy client.Object
+		list    client.ObjectList
+		// listWithA contains the manifest of package "a", which is named manifestName.
+		listWithA    client.ObjectList
+		manifestName string
+	}{
+		{
+			kind: "Configuration",
+			component: func(config *v1beta1.CrossplanePackageConfig, enabled bool) juggler.Component {
+				return &CrossplaneConfiguration{Config: config, Enabled: enabled}
+			},
+			name:          "platform-ref",
+			componentName: "ConfigurationPlatformRef",
+			prefix:        "configuration-",
+			object:        &crossplanev1.Configuration{},
+			healthy:       &crossplanev1.Configuration{Status: crossplanev1.ConfigurationStatus{ConditionedStatus: packageHealthyConditions}},
+			list:          &crossplanev1.ConfigurationList{},
+			listWithA:     &crossplanev1.ConfigurationList{Items: []crossplanev1.Configuration{{ObjectMeta: metav1.ObjectMeta{Name: "configuration-a"}}}},
+			manifestName:  "configuration-a",
+		},
+		{
+			kind: "Function",
+			component: func(config *v1beta1.CrossplanePackageConfig, enabled bool) juggler.Component {
+				return &CrossplaneFunction{Config: config, Enabled: enabled}
+			},
+			name:          "auto-ready",
+			componentName: "FunctionAutoReady",
+			prefix:        "function-",
+			object:        &crossplanev1.Function{},
+			healthy:       &crossplanev1.Function{Status: crossplanev1.FunctionStatus{ConditionedStatus: packageHealthyConditions}},
+			list:          &crossplanev1.FunctionList{},
+			listWithA:     &crossplanev1.FunctionList{Items: []crossplanev1.Function{{ObjectMeta: metav1.ObjectMeta{Name: "function-a"}}}},
+			manifestName:  "function-a",
+		},
+	}
+	for _, k := range kinds {
+		testCases := []struct {
+			desc            string
+			enabled         bool
+			config          *v1beta1.CrossplanePackageConfig
+			versionResolver v1beta1.VersionResolverFn
+			validationFuncs []validationFunc
+		}{
+			{
+				desc:    "should be disabled",
+				enabled: false,
+				validationFuncs: []validationFunc{
+					isEnabled(false),
+				},
+			},
+			{
+				desc:    "should not be allowed",
+				enabled: true,
+				config: &v1beta1.CrossplanePackageConfig{
+					Name: k.name,
+				},
+				versionResolver: fakeVersionResolver(true),
+				validationFuncs: []validationFunc{
+					hasName(k.componentName),
+					isAllowed(false),
+				},
+			},
+			{
+				desc:    "should be allowed with prefix",
+				enabled: true,
+				config: &v1beta1.CrossplanePackageConfig{
+					Name:    k.prefix + k.name,
+					Version: "~1.0",
+				},
+				versionResolver: func(componentName string, version string) (v1beta1.ComponentVersion, error) {
+					if componentName == k.prefix+k.name && version == "~1.0" {
+						return v1beta1.ComponentVersion{Version: "1.0.3"}, nil
+					}
+					return v1beta1.ComponentVersion{}, errFake
+				},
+				validationFuncs: []validationFunc{
+					hasName(k.componentName),
+					isAllowed(true),
+					hasResolvedVersion("1.0.3"),
+				},
+			},
+			{
+				desc:    "should be enabled",
+				enabled: true,
+				config: &v1beta1.CrossplanePackageConfig{
+					Name: k.name,
+				},
+				versionResolver: fakeVersionResolver(false),
+				validationFuncs: []validationFunc{
+					hasName(k.componentName),
+					isEnabled(true),
+					isAllowed(true),
+					hasDependencies(1),
+					hasPreInstallHook(),
+					hasPreUpdateHook(),
+					isTargetComponent(
+						hasNamespace("crossplane-system"),
+					),
+					isObjectComponent(
+						objectIsType(k.object),
+						canCheckHealthiness(k.object, juggler.ResourceHealthiness{
+							Healthy: false,
+							Message: k.kind + " installation is pending (). ",
+						}),
+						canCheckHealthiness(k.healthy, juggler.ResourceHealthiness{
+							Healthy: true,
+							Message: "Healthy: Healthy",
+						}),
+						canBuildAndReconcile(nil),
+						implementsOrphanedObjectsDetector(
+							listTypeIs(k.list),
+							hasFilterCriteria(2),
+							canConvert(k.listWithA, 1),
+							canCheckSame(
+								k.component(&v1beta1.CrossplanePackageConfig{Name: k.manifestName}, false),
+								k.component(&v1beta1.CrossplanePackageConfig{Name: "a"}, false),
+								true),
+							canCheckSame(
+								k.component(&v1beta1.CrossplanePackageConfig{Name: "a"}, false),
+								k.component(&v1beta1.CrossplanePackageConfig{Name: "b"}, false),
+								false),
+						),
+					),
+				},
+			},
+		}
+		for _, tC := range testCases {
+			t.Run(k.kind+" "+tC.desc, func(t *testing.T) {
+				ctx := newContext(nil, tC.versionResolver, nil)
+				c := k.component(tC.config, tC.enabled)
+				for _, vfn := range tC.validationFuncs {
+					vfn(t, ctx, c)
+				}
+			})
+		}
+	}
+}
@@ -23,11 +23,21 @@ import (
 var _ object.ObjectComponent = &CrossplaneProvider{}
 var _ object.OrphanedObjectsDetector = &CrossplaneProvider{}
 var _ TargetComponent = &CrossplaneProvider{}
+var _ juggler.VersionedComponent = &CrossplaneProvider{}
+var _ juggler.VersionResolvingComponent = &CrossplaneProvider{}
+var _ UpgradableComponent = &CrossplaneProvider{}
+var _ object.InstalledVersionDetector = &CrossplaneProvider{}
+var _ juggler.DependencyMatcher = &CrossplaneProvider{}
+var _ object.HealthInspector = &CrossplaneProvider{}
 
 type CrossplaneProvider struct {
 	Config      *v1beta1.CrossplaneProviderConfig
 	Enabled     bool
 	PullSecrets []corev1.LocalObjectReference
+	// ImageConfigs are the names of the ImageConfigs that are applied before the package is pulled.
+	ImageConfigs []string
+	// Revisions describes the ProviderRevisions of unhealthy providers. It may be shared by the providers of a ControlPlane.
+	Revisions *crossplane.RevisionInspector
 }
 
 // BuildObjectToReconcile implements object.ObjectComponent.
@@ -86,21 +96,31 @@ func (*CrossplaneProvider) OrphanDetectorContext(_ context.Context) object.Detec
 
 // IsObjectHealthy implements object.ObjectComponent.
 func (c *CrossplaneProvider) IsObjectHealthy(obj client.Object) juggler.ResourceHealthiness {
-	provider := obj.(*crossplanev1.Provider)
+	return packageHealthiness("Provider", obj.(*crossplanev1.Provider))
+}
 
-	installed := provider.GetCondition(crossplanev1.TypeInstalled)
-	if installed.Status != corev1.ConditionTrue {
-		return juggler.ResourceHealthiness{
-			Healthy: false,
-			Message: fmt.Sprintf("Provider installation is pending (%s). %s", installed.Reason, installed.Message),
-		}
+// InspectObjectHealth implements object.HealthInspector.
+// The health is taken from the conditions of the Provider. The message of an unhealthy Provider is extended with
+// the details of its current ProviderRevision, e.g. image pull failures or CRDs that are not established yet.
+func (c *CrossplaneProvider) InspectObjectHealth(ctx context.Context, cl client.Client, obj client.Object) juggler.ResourceHealthiness {
+	provider := obj.(*crossplanev1.Provider)
+	health := packageHealthiness("Provider", provider)
+	if health.Healthy {
+		return health
 	}
 
-	healthy := provider.GetCondition(crossplanev1.TypeHealthy)
-	return juggler.ResourceHealthiness{
-		Healthy: healthy.Status == corev1.ConditionTrue,
-		Message: fmt.Sprintf("%s: %s", healthy.Reason, healthy.Message),
+	revisions := c.Revisions
+	if revisions == nil {
+		revisions = &crossplane.RevisionInspector{Namespace: CrossplaneNamespace}
 	}
+	details := revisions.ProviderRevisionDetails(ctx, cl, provider)
+	health.Message = strings.Join(append([]string{strings.TrimSpace(health.Message)}, details...), " ")
+	return health
+}
+
+// GetInstalledVersion implements object.InstalledVersionDetector.
+func (c *CrossplaneProvider) GetInstalledVersion(obj client.Object) string {
+	return crossplane.ProviderVersion(obj.(*crossplanev1.Provider))
 }
 
 // GetNamespace implements TargetComponent.
@@ -133,7 +153,13 @@ func (c *CrossplaneProvider) GetName() string {
 
 // GetDependencies implements Component.
 func (c *CrossplaneProvider) GetDependencies() []juggler.Component {
-	return []juggler.Component{&Crossplane{}}
+	return append([]juggler.Component{&Crossplane{}}, imageConfigDependencies(c.ImageConfigs)...)
+}
+
+// MatchesDependency implements juggler.DependencyMatcher.
+// A CrossplaneProvider without config matches all providers.
+func (c *CrossplaneProvider) MatchesDependency(registered juggler.Component) bool {
+	return c.Config == nil || registered.GetName() == c.GetName()
 }
 
 // IsEnabled implements Component.
@@ -141,6 +167,26 @@ func (c *CrossplaneProvider) IsEnabled() bool {
 	return c.Enabled
 }
 
+// GetVersion implements juggler.VersionedComponent.
+func (c *CrossplaneProvider) GetVersion() string {
+	return c.Config.Version
+}
+
+// GetResolvedVersion implements juggler.VersionResolvingComponent.
+func (c *CrossplaneProvider) GetResolvedVersion(ctx context.Context) (string, error) {
+	return resolveVersion(ctx, crossplane.ProviderNameForProviderConfig(c.Config), c.GetVersion())
+}
+
+// GetReleaseChannelName implements UpgradableComponent.
+func (c *CrossplaneProvider) GetReleaseChannelName() string {
+	return crossplane.ProviderNameForProviderConfig(c.Config)
+}
+
+// GetUpgradePolicy implements UpgradableComponent.
+func (c *CrossplaneProvider) GetUpgradePolicy() v1beta1.UpgradePolicy {
+	return c.Config.UpgradePolicy
+}
+
 // Hooks implements Component.
 func (*CrossplaneProvider) Hooks() juggler.ComponentHooks {
 	return juggler.ComponentHooks{
@@ -150,10 +196,32 @@ func (*CrossplaneProvider) Hooks() juggler.ComponentHooks {
 }
 
 func formatProviderName(providerName string) string {
-	providerName = crossplane.AddProviderPrefix(providerName)
-	parts := strings.Split(providerName, "-")
+	return formatPackageName(crossplane.AddProviderPrefix(providerName))
+}
+
+// formatPackageName converts the name of a Crossplane package manifest to the name of its component,
+// e.g. `provider-kubernetes` to `ProviderKubernetes`.
+func formatPackageName(name string) string {
+	parts := strings.Split(name, "-")
 	for i, part := range parts {
 		parts[i] = cases.Title(language.English).String(part)
 	}
 	return strings.Join(parts, "")
 }
+
+// packageHealthiness reports a Crossplane package as healthy once it is installed and healthy.
+func packageHealthiness(kind string, pkg crossplanev1.Package) juggler.ResourceHealthiness {
+	installed := pkg.GetCondition(crossplanev1.TypeInstalled)
+	if installed.Status != corev1.ConditionTrue {
+		return juggler.ResourceHealthiness{
+			Healthy: false,
+			Message: fmt.Sprintf("%s installation is pending (%s). %s", kind, installed.Reason, installed.Message),
+		}
+	}
+
+	healthy := pkg.GetCondition(crossplanev1.TypeHealthy)
+	return juggler.ResourceHealthiness{
+		Healthy: healthy.Status == corev1.ConditionTrue,
+		Message: fmt.Sprintf("%s: %s", healthy.Reason, healthy.Message),
+	}
+}
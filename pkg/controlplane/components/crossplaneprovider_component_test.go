@@ -2,6 +2,7 @@
 package components
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -12,8 +13,12 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/internal/schemes"
 	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/crossplane"
 	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/secretresolver"
 	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
@@ -166,6 +171,24 @@ func Test_CrossplaneProvider(t *testing.T) {
 				isAllowed(true),
 			},
 		},
+		{
+			desc:    "should resolve version constraint",
+			enabled: true,
+			config: &v1beta1.CrossplaneProviderConfig{
+				Name:    "kubernetes",
+				Version: "~0.13",
+			},
+			versionResolver: func(componentName string, version string) (v1beta1.ComponentVersion, error) {
+				if componentName == "provider-kubernetes" && version == "~0.13" {
+					return v1beta1.ComponentVersion{Version: "0.13.2"}, nil
+				}
+				return v1beta1.ComponentVersion{}, errFake
+			},
+			validationFuncs: []validationFunc{
+				isAllowed(true),
+				hasResolvedVersion("0.13.2"),
+			},
+		},
 		{
 			desc:    "returns available versions from context resolver",
 			enabled: true,
@@ -248,3 +271,36 @@ func Test_CrossplaneProvider(t *testing.T) {
 		})
 	}
 }
+
+func Test_CrossplaneProvider_InspectObjectHealth(t *testing.T) {
+	c := fake.NewClientBuilder().WithScheme(schemes.Remote).WithObjects(
+		&crossplanev1.ProviderRevision{ObjectMeta: metav1.ObjectMeta{Name: "provider-kubernetes-abc123"}},
+	).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			t.Errorf("unexpected list of %T", list)
+			return c.List(ctx, list, opts...)
+		},
+	}).Build()
+	cp := &CrossplaneProvider{Config: &v1beta1.CrossplaneProviderConfig{Name: "kubernetes"}, Enabled: true}
+
+	// the revision of a healthy provider is not inspected
+	provider := providerHealthy.DeepCopy()
+	provider.Status.CurrentRevision = "provider-kubernetes-abc123"
+	assert.Equal(t, juggler.ResourceHealthiness{
+		Healthy: true,
+		Message: "Healthy: Healthy",
+	}, cp.InspectObjectHealth(context.Background(), c, provider))
+
+	c = fake.NewClientBuilder().WithScheme(schemes.Remote).WithObjects(
+		&crossplanev1.ProviderRevision{ObjectMeta: metav1.ObjectMeta{Name: "provider-kubernetes-abc123"}},
+	).Build()
+	provider = providerInstallPending.DeepCopy()
+	provider.Status.CurrentRevision = "provider-kubernetes-abc123"
+	assert.Equal(t, juggler.ResourceHealthiness{
+		Healthy: false,
+		Message: "Provider installation is pending (). " +
+			"RevisionHealthy of ProviderRevision provider-kubernetes-abc123 is Unknown (). " +
+			"RuntimeHealthy of ProviderRevision provider-kubernetes-abc123 is Unknown (). " +
+			"0/0 CRDs established. Runtime Deployment not found.",
+	}, cp.InspectObjectHealth(context.Background(), c, provider))
+}
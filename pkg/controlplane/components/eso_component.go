@@ -4,17 +4,12 @@ import (
 	"context"
 	"strings"
 
-	helmv2 "github.com/fluxcd/helm-controller/api/v2"
-	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
 	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
 	"github.com/openmcp-project/control-plane-operator/pkg/juggler/fluxcd"
-	"github.com/openmcp-project/control-plane-operator/pkg/juggler/hooks"
-	"github.com/openmcp-project/control-plane-operator/pkg/utils/rcontext"
 )
 
 const (
@@ -24,17 +19,22 @@ const (
 )
 
 var _ fluxcd.FluxComponent = &ExternalSecretsOperator{}
+var _ juggler.VersionedComponent = &ExternalSecretsOperator{}
+var _ juggler.VersionResolvingComponent = &ExternalSecretsOperator{}
+var _ UpgradableComponent = &ExternalSecretsOperator{}
 var _ TargetComponent = &ExternalSecretsOperator{}
 var _ PolicyRulesComponent = &ExternalSecretsOperator{}
 
-// ExternalSecretsOperator is the add-on for https://github.com/external-secrets/external-secrets.
-type ExternalSecretsOperator struct {
-	Config *v1beta1.ExternalSecretsOperatorConfig
-}
-
-// GetPolicyRules implements PolicyRulesComponent.
-func (e *ExternalSecretsOperator) GetPolicyRules() PolicyRules {
-	return PolicyRules{
+// esoDefinition is the built-in definition of the External Secrets Operator.
+var esoDefinition = v1beta1.ComponentDefinitionSpec{
+	ReleaseChannelName: esoRelease,
+	ReleaseName:        esoRelease,
+	Namespace:          esoNamespace,
+	Chart: &v1beta1.ChartSpec{
+		Repository: "https://charts.external-secrets.io",
+		Name:       "external-secrets",
+	},
+	PolicyRules: &v1beta1.ComponentPolicyRules{
 		Admin: []rbacv1.PolicyRule{
 			{
 				APIGroups: []string{"external-secrets.io"},
@@ -103,77 +103,44 @@ func (e *ExternalSecretsOperator) GetPolicyRules() PolicyRules {
 				Verbs: VerbsView,
 			},
 		},
-	}
+	},
+	ProtectedResources: []metav1.GroupVersionKind{
+		{Group: "external-secrets.io", Version: "v1beta1", Kind: "ExternalSecret"},
+		{Group: "external-secrets.io", Version: "v1beta1", Kind: "SecretStore"},
+		{Group: "external-secrets.io", Version: "v1beta1", Kind: "ClusterSecretStore"},
+	},
 }
 
-// GetNamespace implements TargetComponent.
-func (e *ExternalSecretsOperator) GetNamespace() string {
-	return esoNamespace
-}
+// ExternalSecretsOperator is the add-on for https://github.com/external-secrets/external-secrets.
+type ExternalSecretsOperator struct {
+	Config *v1beta1.ExternalSecretsOperatorConfig
+	// Definitions may adjust the built-in definition of the component.
+	Definitions Definitions
 
-func (e *ExternalSecretsOperator) IsInstallable(ctx context.Context) (bool, error) {
-	rfn := rcontext.VersionResolver(ctx)
-	if rfn == nil {
-		return false, ErrVersionResolverNotConfigured
-	}
-	if _, err := rfn(esoRelease, e.Config.Version); err != nil {
-		return false, err
-	}
-	return true, nil
+	cached lazyHelm
 }
 
-func (e *ExternalSecretsOperator) GetAvailableVersions(ctx context.Context) ([]string, error) {
-	resolve := rcontext.AvailableVersionsResolver(ctx)
-	return resolve(esoRelease)
+func (e *ExternalSecretsOperator) helm() *helmComponent {
+	return e.cached.get(func() *helmComponent {
+		h := &helmComponent{
+			resourceName: strings.ToLower(ComponentNameESO),
+			definition:   e.Definitions.builtin(esoDefinition),
+		}
+		if e.Config != nil {
+			h.config = helmConfig{Version: e.Config.Version, Chart: e.Config.Chart, Values: e.Config.Values, ValuesFrom: e.Config.ValuesFrom}
+		}
+		return h
+	})
 }
 
-func (e *ExternalSecretsOperator) BuildSourceRepository(ctx context.Context) (fluxcd.SourceAdapter, error) {
-	rfn := rcontext.VersionResolver(ctx)
-	e.applyDefaultChartSpec(rfn)
-
-	repo := &sourcev1.HelmRepository{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      strings.ToLower(ComponentNameESO),
-			Namespace: rcontext.TenantNamespace(ctx),
-		},
-		Spec: sourcev1.HelmRepositorySpec{
-			URL: e.Config.Chart.Repository,
-		},
-	}
-
-	adapter := &fluxcd.HelmRepositoryAdapter{Source: repo}
-	adapter.ApplyDefaults()
-	return adapter, nil
+// GetPolicyRules implements PolicyRulesComponent.
+func (e *ExternalSecretsOperator) GetPolicyRules() PolicyRules {
+	return e.helm().policyRules()
 }
 
-func (e *ExternalSecretsOperator) BuildManifesto(ctx context.Context) (fluxcd.Manifesto, error) {
-	release := &helmv2.HelmRelease{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      strings.ToLower(ComponentNameESO),
-			Namespace: rcontext.TenantNamespace(ctx),
-		},
-		Spec: helmv2.HelmReleaseSpec{
-			Chart: &helmv2.HelmChartTemplate{
-				Spec: helmv2.HelmChartTemplateSpec{
-					Chart:   e.Config.Chart.Name,
-					Version: e.Config.Chart.Version,
-					SourceRef: helmv2.CrossNamespaceObjectReference{
-						Kind: "HelmRepository",
-						Name: strings.ToLower(ComponentNameESO),
-					},
-				},
-			},
-			ReleaseName:      esoRelease,
-			TargetNamespace:  esoNamespace,
-			StorageNamespace: esoNamespace,
-			KubeConfig:       rcontext.FluxKubeconfigRef(ctx),
-			Values:           e.Config.Values,
-		},
-	}
-
-	adapter := &fluxcd.HelmReleaseManifesto{Manifest: release}
-	adapter.ApplyDefaults()
-	return adapter, nil
+// GetNamespace implements TargetComponent.
+func (e *ExternalSecretsOperator) GetNamespace() string {
+	return e.helm().definition.Namespace
 }
 
 func (e *ExternalSecretsOperator) GetName() string {
@@ -181,37 +148,56 @@ func (e *ExternalSecretsOperator) GetName() string {
 }
 
 func (e *ExternalSecretsOperator) GetDependencies() []juggler.Component {
-	// No dependencies
-	return []juggler.Component{}
+	return e.helm().dependencies()
 }
 
 func (e *ExternalSecretsOperator) IsEnabled() bool {
 	return e.Config != nil && e.Config.Version != ""
 }
 
-func (e *ExternalSecretsOperator) applyDefaultChartSpec(rfn v1beta1.VersionResolverFn) {
+// GetVersion implements juggler.VersionedComponent.
+func (e *ExternalSecretsOperator) GetVersion() string {
 	if e.Config == nil {
-		e.Config = &v1beta1.ExternalSecretsOperatorConfig{}
+		return ""
 	}
+	return e.Config.Version
+}
 
-	comp, _ := rfn(esoRelease, e.Config.Version)
+// GetResolvedVersion implements juggler.VersionResolvingComponent.
+func (e *ExternalSecretsOperator) GetResolvedVersion(ctx context.Context) (string, error) {
+	return e.helm().getResolvedVersion(ctx)
+}
 
-	if e.Config.Chart == nil {
-		e.Config.Chart = &v1beta1.ChartSpec{
-			Repository: "https://charts.external-secrets.io",
-			Name:       "external-secrets",
-			Version:    comp.Version,
-		}
+// GetReleaseChannelName implements UpgradableComponent.
+func (e *ExternalSecretsOperator) GetReleaseChannelName() string {
+	return e.helm().definition.ReleaseChannelName
+}
+
+// GetUpgradePolicy implements UpgradableComponent.
+func (e *ExternalSecretsOperator) GetUpgradePolicy() v1beta1.UpgradePolicy {
+	if e.Config == nil {
+		return ""
 	}
+	return e.Config.UpgradePolicy
 }
 
 // Hooks implements Component.
 func (e *ExternalSecretsOperator) Hooks() juggler.ComponentHooks {
-	return juggler.ComponentHooks{
-		PreUninstall: hooks.PreventOrphanedResources([]schema.GroupVersionKind{
-			{Group: "external-secrets.io", Version: "v1beta1", Kind: "ExternalSecret"},
-			{Group: "external-secrets.io", Version: "v1beta1", Kind: "SecretStore"},
-			{Group: "external-secrets.io", Version: "v1beta1", Kind: "ClusterSecretStore"},
-		}),
-	}
+	return e.helm().hooks()
+}
+
+func (e *ExternalSecretsOperator) IsInstallable(ctx context.Context) (bool, error) {
+	return e.helm().isInstallable(ctx)
+}
+
+func (e *ExternalSecretsOperator) GetAvailableVersions(ctx context.Context) ([]string, error) {
+	return e.helm().getAvailableVersions(ctx)
+}
+
+func (e *ExternalSecretsOperator) BuildSourceRepository(ctx context.Context) (fluxcd.SourceAdapter, error) {
+	return e.helm().buildSourceRepository(ctx), nil
+}
+
+func (e *ExternalSecretsOperator) BuildManifesto(ctx context.Context) (fluxcd.Manifesto, error) {
+	return e.helm().buildManifesto(ctx)
 }
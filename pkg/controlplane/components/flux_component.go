@@ -4,17 +4,12 @@ import (
 	"context"
 	"strings"
 
-	helmv2 "github.com/fluxcd/helm-controller/api/v2"
-	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
 	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
 	"github.com/openmcp-project/control-plane-operator/pkg/juggler/fluxcd"
-	"github.com/openmcp-project/control-plane-operator/pkg/juggler/hooks"
-	"github.com/openmcp-project/control-plane-operator/pkg/utils/rcontext"
 )
 
 const (
@@ -24,15 +19,22 @@ const (
 )
 
 var _ fluxcd.FluxComponent = &Flux{}
+var _ juggler.VersionedComponent = &Flux{}
+var _ juggler.VersionResolvingComponent = &Flux{}
+var _ UpgradableComponent = &Flux{}
 var _ TargetComponent = &Flux{}
 var _ PolicyRulesComponent = &Flux{}
 
-type Flux struct {
-	Config *v1beta1.FluxConfig
-}
-
-func (f *Flux) GetPolicyRules() PolicyRules {
-	return PolicyRules{
+// fluxDefinition is the built-in definition of Flux.
+var fluxDefinition = v1beta1.ComponentDefinitionSpec{
+	ReleaseChannelName: fluxRelease,
+	ReleaseName:        fluxRelease,
+	Namespace:          fluxNamespace,
+	Chart: &v1beta1.ChartSpec{
+		Repository: "https://fluxcd-community.github.io/helm-charts",
+		Name:       "flux2",
+	},
+	PolicyRules: &v1beta1.ComponentPolicyRules{
 		Admin: []rbacv1.PolicyRule{
 			{
 				APIGroups: []string{
@@ -63,11 +65,42 @@ func (f *Flux) GetPolicyRules() PolicyRules {
 				Verbs: VerbsView,
 			},
 		},
-	}
+	},
+	ProtectedResources: []metav1.GroupVersionKind{
+		{Group: "helm.toolkit.fluxcd.io", Version: "v2", Kind: "HelmRelease"},
+		{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Kind: "Kustomization"},
+	},
+}
+
+type Flux struct {
+	Config *v1beta1.FluxConfig
+	// Definitions may adjust the built-in definition of the component.
+	Definitions Definitions
+
+	cached lazyHelm
 }
 
+func (f *Flux) helm() *helmComponent {
+	return f.cached.get(func() *helmComponent {
+		h := &helmComponent{
+			resourceName: strings.ToLower(ComponentNameFlux),
+			definition:   f.Definitions.builtin(fluxDefinition),
+		}
+		if f.Config != nil {
+			h.config = helmConfig{Version: f.Config.Version, Chart: f.Config.Chart, Values: f.Config.Values, ValuesFrom: f.Config.ValuesFrom}
+		}
+		return h
+	})
+}
+
+// GetPolicyRules implements PolicyRulesComponent.
+func (f *Flux) GetPolicyRules() PolicyRules {
+	return f.helm().policyRules()
+}
+
+// GetNamespace implements TargetComponent.
 func (f *Flux) GetNamespace() string {
-	return fluxNamespace
+	return f.helm().definition.Namespace
 }
 
 func (f *Flux) GetName() string {
@@ -75,99 +108,56 @@ func (f *Flux) GetName() string {
 }
 
 func (f *Flux) GetDependencies() []juggler.Component {
-	return []juggler.Component{}
+	return f.helm().dependencies()
 }
 
 func (f *Flux) IsEnabled() bool {
 	return f.Config != nil && f.Config.Version != ""
 }
 
-func (f *Flux) Hooks() juggler.ComponentHooks {
-	return juggler.ComponentHooks{
-		PreUninstall: hooks.PreventOrphanedResources([]schema.GroupVersionKind{
-			{Group: "helm.toolkit.fluxcd.io", Version: "v2", Kind: "HelmRelease"},
-			{Group: "kustomize.toolkit.fluxcd.io", Version: "v1", Kind: "Kustomization"},
-		}),
+// GetVersion implements juggler.VersionedComponent.
+func (f *Flux) GetVersion() string {
+	if f.Config == nil {
+		return ""
 	}
+	return f.Config.Version
 }
 
-func (f *Flux) IsInstallable(ctx context.Context) (bool, error) {
-	rfn := rcontext.VersionResolver(ctx)
-	if rfn == nil {
-		return false, ErrVersionResolverNotConfigured
-	}
-	if _, err := rfn(fluxRelease, f.Config.Version); err != nil {
-		return false, err
-	}
-	return true, nil
+// GetResolvedVersion implements juggler.VersionResolvingComponent.
+func (f *Flux) GetResolvedVersion(ctx context.Context) (string, error) {
+	return f.helm().getResolvedVersion(ctx)
 }
 
-func (f *Flux) GetAvailableVersions(ctx context.Context) ([]string, error) {
-	resolve := rcontext.AvailableVersionsResolver(ctx)
-	return resolve(fluxRelease)
+// GetReleaseChannelName implements UpgradableComponent.
+func (f *Flux) GetReleaseChannelName() string {
+	return f.helm().definition.ReleaseChannelName
 }
 
-func (f *Flux) applyDefaultChartSpec(rfn v1beta1.VersionResolverFn) {
+// GetUpgradePolicy implements UpgradableComponent.
+func (f *Flux) GetUpgradePolicy() v1beta1.UpgradePolicy {
 	if f.Config == nil {
-		f.Config = &v1beta1.FluxConfig{}
+		return ""
 	}
+	return f.Config.UpgradePolicy
+}
 
-	comp, _ := rfn(fluxRelease, f.Config.Version)
-
-	if f.Config.Chart == nil {
-		f.Config.Chart = &v1beta1.ChartSpec{
-			Repository: "https://fluxcd-community.github.io/helm-charts",
-			Name:       "flux2",
-			Version:    comp.Version,
-		}
-	}
+// Hooks implements Component.
+func (f *Flux) Hooks() juggler.ComponentHooks {
+	return f.helm().hooks()
 }
 
-func (f *Flux) BuildSourceRepository(ctx context.Context) (fluxcd.SourceAdapter, error) {
-	rfn := rcontext.VersionResolver(ctx)
-	f.applyDefaultChartSpec(rfn)
+func (f *Flux) IsInstallable(ctx context.Context) (bool, error) {
+	return f.helm().isInstallable(ctx)
+}
 
-	repo := &sourcev1.HelmRepository{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      strings.ToLower(ComponentNameFlux),
-			Namespace: rcontext.TenantNamespace(ctx),
-		},
-		Spec: sourcev1.HelmRepositorySpec{
-			URL: f.Config.Chart.Repository,
-		},
-	}
+func (f *Flux) GetAvailableVersions(ctx context.Context) ([]string, error) {
+	return f.helm().getAvailableVersions(ctx)
+}
 
-	adapter := &fluxcd.HelmRepositoryAdapter{Source: repo}
-	adapter.ApplyDefaults()
-	return adapter, nil
+func (f *Flux) BuildSourceRepository(ctx context.Context) (fluxcd.SourceAdapter, error) {
+	return f.helm().buildSourceRepository(ctx), nil
 }
 
 func (f *Flux) BuildManifesto(ctx context.Context) (fluxcd.Manifesto, error) {
-	release := &helmv2.HelmRelease{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      strings.ToLower(ComponentNameFlux),
-			Namespace: rcontext.TenantNamespace(ctx),
-		},
-		Spec: helmv2.HelmReleaseSpec{
-			Chart: &helmv2.HelmChartTemplate{
-				Spec: helmv2.HelmChartTemplateSpec{
-					Chart:   f.Config.Chart.Name,
-					Version: f.Config.Chart.Version,
-					SourceRef: helmv2.CrossNamespaceObjectReference{
-						Kind: "HelmRepository",
-						Name: strings.ToLower(ComponentNameFlux),
-					},
-				},
-			},
-			ReleaseName:      fluxRelease,
-			TargetNamespace:  fluxNamespace,
-			StorageNamespace: fluxNamespace,
-			KubeConfig:       rcontext.FluxKubeconfigRef(ctx),
-			Values:           f.Config.Values,
-		},
-	}
-
-	adapter := &fluxcd.HelmReleaseManifesto{Manifest: release}
-	adapter.ApplyDefaults()
-	return adapter, nil
+	return f.helm().buildManifesto(ctx)
 }
@@ -0,0 +1,198 @@
+package components
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"sync"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/valuesfrom"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler/fluxcd"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler/hooks"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils/rcontext"
+)
+
+// helmConfig is the configuration of a Helm-based component in the ControlPlane.
+type helmConfig struct {
+	Version    string
+	Chart      *v1beta1.ChartSpec
+	Values     *apiextensionsv1.JSON
+	ValuesFrom []v1beta1.ValuesReference
+}
+
+// helmComponent installs the Helm chart of a ComponentDefinition with the configuration of the ControlPlane.
+// It implements the parts that are shared by all Helm-based components.
+type helmComponent struct {
+	// resourceName is the name of the Flux resources of the component.
+	resourceName string
+	definition   v1beta1.ComponentDefinitionSpec
+	config       helmConfig
+	// defaultValues are generated by the component and take the lowest precedence.
+	defaultValues map[string]any
+}
+
+// lazyHelm builds the helmComponent of a component once, on first use.
+type lazyHelm struct {
+	once sync.Once
+	helm *helmComponent
+}
+
+func (l *lazyHelm) get(build func() *helmComponent) *helmComponent {
+	l.once.Do(func() {
+		l.helm = build()
+	})
+	return l.helm
+}
+
+func (h *helmComponent) isInstallable(ctx context.Context) (bool, error) {
+	rfn := rcontext.VersionResolver(ctx)
+	if rfn == nil {
+		return false, ErrVersionResolverNotConfigured
+	}
+	if _, err := rfn(h.definition.ReleaseChannelName, h.config.Version); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (h *helmComponent) getResolvedVersion(ctx context.Context) (string, error) {
+	return resolveVersion(ctx, h.definition.ReleaseChannelName, h.config.Version)
+}
+
+func (h *helmComponent) getAvailableVersions(ctx context.Context) ([]string, error) {
+	resolve := rcontext.AvailableVersionsResolver(ctx)
+	return resolve(h.definition.ReleaseChannelName)
+}
+
+// dependencies returns the components the definition depends on.
+// Dependencies are either built-in components or additional components.
+func (h *helmComponent) dependencies() []juggler.Component {
+	deps := make([]juggler.Component, 0, len(h.definition.DependsOn))
+	for _, name := range h.definition.DependsOn {
+		if builtin := builtinComponent(name); builtin != nil {
+			deps = append(deps, builtin)
+			continue
+		}
+		deps = append(deps, &AdditionalComponent{Config: &v1beta1.AdditionalComponentConfig{Name: name}})
+	}
+	return deps
+}
+
+func (h *helmComponent) policyRules() PolicyRules {
+	if h.definition.PolicyRules == nil {
+		return PolicyRules{}
+	}
+	return PolicyRules{
+		Admin: h.definition.PolicyRules.Admin,
+		View:  h.definition.PolicyRules.View,
+	}
+}
+
+// hooks prevents the uninstallation of the component as long as its protected resources exist.
+func (h *helmComponent) hooks() juggler.ComponentHooks {
+	if len(h.definition.ProtectedResources) == 0 {
+		return juggler.ComponentHooks{}
+	}
+
+	gvks := make([]schema.GroupVersionKind, 0, len(h.definition.ProtectedResources))
+	for _, gvk := range h.definition.ProtectedResources {
+		gvks = append(gvks, schema.GroupVersionKind(gvk))
+	}
+	return juggler.ComponentHooks{
+		PreUninstall: hooks.PreventOrphanedResources(gvks),
+	}
+}
+
+// chart returns the chart of the ControlPlane or the default chart of the definition, which defaults to the
+// Helm chart in the ReleaseChannels. The version of the chart defaults to the resolved version of the component.
+func (h *helmComponent) chart(ctx context.Context) *v1beta1.ChartSpec {
+	var comp v1beta1.ComponentVersion
+	if rfn := rcontext.VersionResolver(ctx); rfn != nil && h.config.Version != "" {
+		comp, _ = rfn(h.definition.ReleaseChannelName, h.config.Version)
+	}
+
+	if h.config.Chart != nil {
+		chart := h.config.Chart.DeepCopy()
+		chart.Version = cmp.Or(chart.Version, comp.Version)
+		return chart
+	}
+
+	if h.definition.Chart != nil {
+		return &v1beta1.ChartSpec{
+			Repository: h.definition.Chart.Repository,
+			Name:       h.definition.Chart.Name,
+			Version:    cmp.Or(h.definition.Chart.Version, comp.Version),
+			URL:        cmp.Or(comp.OCIURL, h.definition.Chart.URL),
+		}
+	}
+
+	return &v1beta1.ChartSpec{
+		Repository: comp.HelmRepo,
+		Name:       comp.HelmChart,
+		Version:    comp.Version,
+		URL:        comp.OCIURL,
+	}
+}
+
+// values merges the values of the ControlPlane with the default values of the definition
+// and the default values of the component.
+func (h *helmComponent) values() (*apiextensionsv1.JSON, error) {
+	if h.defaultValues == nil && (h.definition.Values == nil || h.config.Values == nil) {
+		return cmp.Or(h.config.Values, h.definition.Values), nil
+	}
+
+	values := h.defaultValues
+	for _, v := range []*apiextensionsv1.JSON{h.definition.Values, h.config.Values} {
+		if v == nil {
+			continue
+		}
+		m := map[string]any{}
+		if err := json.Unmarshal(v.Raw, &m); err != nil {
+			return nil, err
+		}
+		values = utils.MergeMaps(values, m)
+	}
+
+	encoded, err := json.Marshal(values)
+	return &apiextensionsv1.JSON{Raw: encoded}, err
+}
+
+func (h *helmComponent) buildSourceRepository(ctx context.Context) fluxcd.SourceAdapter {
+	return buildChartSource(ctx, h.resourceName, h.chart(ctx))
+}
+
+func (h *helmComponent) buildManifesto(ctx context.Context) (fluxcd.Manifesto, error) {
+	values, err := h.values()
+	if err != nil {
+		return nil, err
+	}
+
+	release := &helmv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      h.resourceName,
+			Namespace: rcontext.TenantNamespace(ctx),
+		},
+		Spec: helmv2.HelmReleaseSpec{
+			ReleaseName:      h.definition.ReleaseName,
+			TargetNamespace:  h.definition.Namespace,
+			StorageNamespace: h.definition.Namespace,
+			KubeConfig:       rcontext.FluxKubeconfigRef(ctx),
+			Values:           values,
+			ValuesFrom:       valuesfrom.HelmReferences(h.config.ValuesFrom),
+		},
+	}
+
+	setChartSource(&release.Spec, h.resourceName, h.chart(ctx))
+
+	adapter := &fluxcd.HelmReleaseManifesto{Manifest: release}
+	adapter.ApplyDefaults()
+	return adapter, nil
+}
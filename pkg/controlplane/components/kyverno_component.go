@@ -4,17 +4,12 @@ import (
 	"context"
 	"strings"
 
-	helmv2 "github.com/fluxcd/helm-controller/api/v2"
-	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
 	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
 	"github.com/openmcp-project/control-plane-operator/pkg/juggler/fluxcd"
-	"github.com/openmcp-project/control-plane-operator/pkg/juggler/hooks"
-	"github.com/openmcp-project/control-plane-operator/pkg/utils/rcontext"
 )
 
 const (
@@ -24,16 +19,22 @@ const (
 )
 
 var _ fluxcd.FluxComponent = &Kyverno{}
+var _ juggler.VersionedComponent = &Kyverno{}
+var _ juggler.VersionResolvingComponent = &Kyverno{}
+var _ UpgradableComponent = &Kyverno{}
 var _ TargetComponent = &Kyverno{}
 var _ PolicyRulesComponent = &Kyverno{}
 
-type Kyverno struct {
-	Config *v1beta1.KyvernoConfig
-}
-
-// GetPolicyRules implements PolicyRulesComponent.
-func (k *Kyverno) GetPolicyRules() PolicyRules {
-	return PolicyRules{
+// kyvernoDefinition is the built-in definition of Kyverno.
+var kyvernoDefinition = v1beta1.ComponentDefinitionSpec{
+	ReleaseChannelName: kyvernoRelease,
+	ReleaseName:        kyvernoRelease,
+	Namespace:          kyvernoNamespace,
+	Chart: &v1beta1.ChartSpec{
+		Repository: "https://kyverno.github.io/kyverno",
+		Name:       "kyverno",
+	},
+	PolicyRules: &v1beta1.ComponentPolicyRules{
 		Admin: []rbacv1.PolicyRule{
 			{
 				APIGroups: []string{"kyverno.io"},
@@ -122,12 +123,47 @@ func (k *Kyverno) GetPolicyRules() PolicyRules {
 				Verbs: VerbsView,
 			},
 		},
-	}
+	},
+	ProtectedResources: []metav1.GroupVersionKind{
+		{Group: "kyverno.io", Version: "v1", Kind: "ClusterPolicy"},
+		{Group: "kyverno.io", Version: "v1", Kind: "Policy"},
+		{Group: "policies.kyverno.io", Version: "v1", Kind: "ValidatingPolicy"},
+		{Group: "policies.kyverno.io", Version: "v1", Kind: "MutatingPolicy"},
+		{Group: "policies.kyverno.io", Version: "v1", Kind: "GeneratingPolicy"},
+		{Group: "policies.kyverno.io", Version: "v1", Kind: "DeletingPolicy"},
+		{Group: "policies.kyverno.io", Version: "v1", Kind: "ImageValidatingPolicy"},
+	},
+}
+
+type Kyverno struct {
+	Config *v1beta1.KyvernoConfig
+	// Definitions may adjust the built-in definition of the component.
+	Definitions Definitions
+
+	cached lazyHelm
+}
+
+func (k *Kyverno) helm() *helmComponent {
+	return k.cached.get(func() *helmComponent {
+		h := &helmComponent{
+			resourceName: strings.ToLower(ComponentNameKyverno),
+			definition:   k.Definitions.builtin(kyvernoDefinition),
+		}
+		if k.Config != nil {
+			h.config = helmConfig{Version: k.Config.Version, Chart: k.Config.Chart, Values: k.Config.Values, ValuesFrom: k.Config.ValuesFrom}
+		}
+		return h
+	})
+}
+
+// GetPolicyRules implements PolicyRulesComponent.
+func (k *Kyverno) GetPolicyRules() PolicyRules {
+	return k.helm().policyRules()
 }
 
 // GetNamespace implements TargetComponent.
 func (k *Kyverno) GetNamespace() string {
-	return kyvernoNamespace
+	return k.helm().definition.Namespace
 }
 
 func (k *Kyverno) GetName() string {
@@ -135,104 +171,56 @@ func (k *Kyverno) GetName() string {
 }
 
 func (k *Kyverno) GetDependencies() []juggler.Component {
-	return []juggler.Component{}
+	return k.helm().dependencies()
 }
 
 func (k *Kyverno) IsEnabled() bool {
 	return k.Config != nil && k.Config.Version != ""
 }
 
-func (k *Kyverno) Hooks() juggler.ComponentHooks {
-	return juggler.ComponentHooks{
-		PreUninstall: hooks.PreventOrphanedResources([]schema.GroupVersionKind{
-			{Group: "kyverno.io", Version: "v1", Kind: "ClusterPolicy"},
-			{Group: "kyverno.io", Version: "v1", Kind: "Policy"},
-			{Group: "policies.kyverno.io", Version: "v1", Kind: "ValidatingPolicy"},
-			{Group: "policies.kyverno.io", Version: "v1", Kind: "MutatingPolicy"},
-			{Group: "policies.kyverno.io", Version: "v1", Kind: "GeneratingPolicy"},
-			{Group: "policies.kyverno.io", Version: "v1", Kind: "DeletingPolicy"},
-			{Group: "policies.kyverno.io", Version: "v1", Kind: "ImageValidatingPolicy"},
-		}),
+// GetVersion implements juggler.VersionedComponent.
+func (k *Kyverno) GetVersion() string {
+	if k.Config == nil {
+		return ""
 	}
+	return k.Config.Version
 }
 
-func (k *Kyverno) IsInstallable(ctx context.Context) (bool, error) {
-	rfn := rcontext.VersionResolver(ctx)
-	if rfn == nil {
-		return false, ErrVersionResolverNotConfigured
-	}
-	if _, err := rfn(kyvernoRelease, k.Config.Version); err != nil {
-		return false, err
-	}
-	return true, nil
+// GetResolvedVersion implements juggler.VersionResolvingComponent.
+func (k *Kyverno) GetResolvedVersion(ctx context.Context) (string, error) {
+	return k.helm().getResolvedVersion(ctx)
 }
 
-func (k *Kyverno) GetAvailableVersions(ctx context.Context) ([]string, error) {
-	resolve := rcontext.AvailableVersionsResolver(ctx)
-	return resolve(kyvernoRelease)
+// GetReleaseChannelName implements UpgradableComponent.
+func (k *Kyverno) GetReleaseChannelName() string {
+	return k.helm().definition.ReleaseChannelName
 }
 
-func (k *Kyverno) BuildSourceRepository(ctx context.Context) (fluxcd.SourceAdapter, error) {
-	rfn := rcontext.VersionResolver(ctx)
-	k.applyDefaultChartSpec(rfn)
-
-	repo := &sourcev1.HelmRepository{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      strings.ToLower(ComponentNameKyverno),
-			Namespace: rcontext.TenantNamespace(ctx),
-		},
-		Spec: sourcev1.HelmRepositorySpec{
-			URL: k.Config.Chart.Repository,
-		},
+// GetUpgradePolicy implements UpgradableComponent.
+func (k *Kyverno) GetUpgradePolicy() v1beta1.UpgradePolicy {
+	if k.Config == nil {
+		return ""
 	}
+	return k.Config.UpgradePolicy
+}
 
-	adapter := &fluxcd.HelmRepositoryAdapter{Source: repo}
-	adapter.ApplyDefaults()
-	return adapter, nil
+// Hooks implements Component.
+func (k *Kyverno) Hooks() juggler.ComponentHooks {
+	return k.helm().hooks()
 }
 
-func (k *Kyverno) applyDefaultChartSpec(rfn v1beta1.VersionResolverFn) {
-	if k.Config == nil {
-		k.Config = &v1beta1.KyvernoConfig{}
-	}
+func (k *Kyverno) IsInstallable(ctx context.Context) (bool, error) {
+	return k.helm().isInstallable(ctx)
+}
 
-	comp, _ := rfn(kyvernoRelease, k.Config.Version)
+func (k *Kyverno) GetAvailableVersions(ctx context.Context) ([]string, error) {
+	return k.helm().getAvailableVersions(ctx)
+}
 
-	if k.Config.Chart == nil {
-		k.Config.Chart = &v1beta1.ChartSpec{
-			Repository: "https://kyverno.github.io/kyverno",
-			Name:       "kyverno",
-			Version:    comp.Version,
-		}
-	}
+func (k *Kyverno) BuildSourceRepository(ctx context.Context) (fluxcd.SourceAdapter, error) {
+	return k.helm().buildSourceRepository(ctx), nil
 }
 
 func (k *Kyverno) BuildManifesto(ctx context.Context) (fluxcd.Manifesto, error) {
-	release := &helmv2.HelmRelease{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      strings.ToLower(ComponentNameKyverno),
-			Namespace: rcontext.TenantNamespace(ctx),
-		},
-		Spec: helmv2.HelmReleaseSpec{
-			Chart: &helmv2.HelmChartTemplate{
-				Spec: helmv2.HelmChartTemplateSpec{
-					Chart:   k.Config.Chart.Name,
-					Version: k.Config.Chart.Version,
-					SourceRef: helmv2.CrossNamespaceObjectReference{
-						Kind: "HelmRepository",
-						Name: strings.ToLower(ComponentNameKyverno),
-					},
-				},
-			},
-			ReleaseName:      kyvernoRelease,
-			TargetNamespace:  kyvernoNamespace,
-			StorageNamespace: kyvernoNamespace,
-			KubeConfig:       rcontext.FluxKubeconfigRef(ctx),
-			Values:           k.Config.Values,
-		},
-	}
-
-	adapter := &fluxcd.HelmReleaseManifesto{Manifest: release}
-	adapter.ApplyDefaults()
-	return adapter, nil
+	return k.helm().buildManifesto(ctx)
 }
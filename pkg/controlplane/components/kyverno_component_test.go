@@ -54,6 +54,26 @@ func Test_Kyverno(t *testing.T) {
 				hasAvailableVersionsError(errFake),
 			},
 		},
+		{
+			desc: "chart from OCI repository of the chart spec",
+			config: &v1beta1.KyvernoConfig{
+				Version: "1.2.3",
+				Chart: &v1beta1.ChartSpec{
+					Repository: "oci://registry.example.com/charts/",
+					Name:       "kyverno",
+					Version:    "3.2.0",
+				},
+			},
+			versionResolver: fakeVersionResolver(false),
+			validationFuncs: []validationFunc{
+				isFluxComponent(
+					returnsOCIRepo("oci://registry.example.com/charts/kyverno", "3.2.0"),
+					returnsHelmRelease(
+						hasChartRef("OCIRepository"),
+					),
+				),
+			},
+		},
 		{
 			desc: "should be enabled",
 			config: &v1beta1.KyvernoConfig{
@@ -17,7 +17,10 @@ const (
 	CrossplanePackageRestrictionName = "default"
 )
 
-func RegisterAsComponents(jug *juggler.Juggler, sourceClient client.Client, enabled bool) error {
+// RegisterAsComponents adds the CrossplanePackageRestriction and the ValidatingAdmissionPolicies enforcing it.
+// The restriction is copied from the CrossplanePackageRestriction named `sourceName` and is always
+// named CrossplanePackageRestrictionName on the target cluster.
+func RegisterAsComponents(jug *juggler.Juggler, sourceClient client.Client, sourceName string, enabled bool) error {
 	cpr := &components.GenericObjectComponent{
 		NamespacedName: types.NamespacedName{
 			Name: CrossplanePackageRestrictionName,
@@ -27,7 +30,7 @@ func RegisterAsComponents(jug *juggler.Juggler, sourceClient client.Client, enab
 		ReconcileObjectFunc: func(ctx context.Context, obj client.Object) error {
 			sourceCPR := &v1beta1.CrossplanePackageRestriction{}
 			if err := sourceClient.Get(ctx, types.NamespacedName{
-				Name: CrossplanePackageRestrictionName,
+				Name: sourceName,
 			}, sourceCPR); err != nil {
 				return err
 			}
@@ -11,7 +11,7 @@ import (
 
 func Test_RegisterAsComponents(t *testing.T) {
 	j := juggler.NewJuggler(logr.Logger{}, nil)
-	err := RegisterAsComponents(j, nil, true)
+	err := RegisterAsComponents(j, nil, CrossplanePackageRestrictionName, true)
 	assert.NoError(t, err)
 	// 1 * CrossplanePackageRestriction + (3 PackageTypes * 2 GenericObjectComponent) = 7
 	assert.Equal(t, 7, j.RegisteredComponents())
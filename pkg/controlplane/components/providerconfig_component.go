@@ -0,0 +1,183 @@
+package components
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/constants"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/crossplane"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler/hooks"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler/object"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils"
+)
+
+var _ object.ObjectComponent = &ProviderConfig{}
+var _ object.OrphanedObjectsDetector = &ProviderConfig{}
+var _ TargetComponent = &ProviderConfig{}
+
+const defaultProviderConfigKind = "ProviderConfig"
+
+// ProviderConfig creates a ProviderConfig of a Crossplane provider with the credentials of a Secret,
+// which is copied into the target cluster (see CredentialsSecret).
+// ProviderConfigs are detected as orphaned through the annotations of their copied Secrets,
+// because their kinds differ from provider to provider.
+type ProviderConfig struct {
+	// Provider is the name of the provider.
+	Provider string
+	Config   *v1beta1.CrossplaneProviderConfigTemplate
+	Enabled  bool
+}
+
+// CredentialsSecret returns the component which copies the Secret with the credentials from the source namespace
+// into the target cluster.
+func (p *ProviderConfig) CredentialsSecret(sourceClient client.Client, sourceNamespace string) *Secret {
+	return &Secret{
+		Enabled:      p.Enabled,
+		SourceClient: sourceClient,
+		Source: types.NamespacedName{
+			Name:      p.Config.SecretRef.Name,
+			Namespace: sourceNamespace,
+		},
+		Target: p.secretKey(),
+		Labels: map[string]string{
+			constants.LabelProviderConfig: "true",
+		},
+		Annotations: map[string]string{
+			constants.AnnotationProviderConfigProvider:   p.Provider,
+			constants.AnnotationProviderConfigAPIVersion: p.Config.APIVersion,
+			constants.AnnotationProviderConfigKind:       p.kind(),
+			constants.AnnotationProviderConfigName:       p.Config.Name,
+		},
+	}
+}
+
+func (p *ProviderConfig) secretKey() types.NamespacedName {
+	return types.NamespacedName{
+		Name:      crossplane.ProviderConfigSecretName(p.Provider, p.Config.Name),
+		Namespace: CrossplaneNamespace,
+	}
+}
+
+func (p *ProviderConfig) kind() string {
+	return cmp.Or(p.Config.Kind, defaultProviderConfigKind)
+}
+
+func (p *ProviderConfig) groupVersionKind() (schema.GroupVersionKind, error) {
+	gv, err := schema.ParseGroupVersion(p.Config.APIVersion)
+	if err != nil {
+		return schema.GroupVersionKind{}, err
+	}
+	return gv.WithKind(p.kind()), nil
+}
+
+// BuildObjectToReconcile implements object.ObjectComponent.
+func (p *ProviderConfig) BuildObjectToReconcile(ctx context.Context) (client.Object, types.NamespacedName, error) {
+	gvk, err := p.groupVersionKind()
+	if err != nil {
+		return nil, types.NamespacedName{}, err
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	return obj, types.NamespacedName{Name: p.Config.Name}, nil
+}
+
+// ReconcileObject implements object.ObjectComponent.
+func (p *ProviderConfig) ReconcileObject(ctx context.Context, obj client.Object) error {
+	return crossplane.ReconcileProviderConfig(obj.(*unstructured.Unstructured), *p.Config, p.secretKey())
+}
+
+// OrphanDetectorContext implements object.OrphanedObjectsDetector.
+func (*ProviderConfig) OrphanDetectorContext(_ context.Context) object.DetectorContext {
+	return object.DetectorContext{
+		ListType: &corev1.SecretList{},
+		FilterCriteria: object.FilterCriteria{
+			utils.IsManaged(),
+			utils.HasComponentLabel(),
+			client.MatchingLabels{constants.LabelProviderConfig: "true"},
+		},
+		ConvertFunc: func(list client.ObjectList) []juggler.Component {
+			items := (list.(*corev1.SecretList)).Items
+			providerConfigs := make([]juggler.Component, 0, len(items))
+			for _, secret := range items {
+				annotations := secret.GetAnnotations()
+				providerConfigs = append(providerConfigs, &ProviderConfig{
+					Provider: annotations[constants.AnnotationProviderConfigProvider],
+					Config: &v1beta1.CrossplaneProviderConfigTemplate{
+						Name:       annotations[constants.AnnotationProviderConfigName],
+						APIVersion: annotations[constants.AnnotationProviderConfigAPIVersion],
+						Kind:       annotations[constants.AnnotationProviderConfigKind],
+					},
+				})
+			}
+			return providerConfigs
+		},
+		SameFunc: func(configured, detected juggler.Component) bool {
+			configuredP := configured.(*ProviderConfig)
+			detectedP := detected.(*ProviderConfig)
+			return configuredP.Config.APIVersion == detectedP.Config.APIVersion &&
+				configuredP.kind() == detectedP.kind() &&
+				configuredP.Config.Name == detectedP.Config.Name
+		},
+	}
+}
+
+// IsObjectHealthy implements object.ObjectComponent.
+func (p *ProviderConfig) IsObjectHealthy(obj client.Object) juggler.ResourceHealthiness {
+	return juggler.ResourceHealthiness{
+		// ProviderConfigs have no conditions.
+		Healthy: obj.GetDeletionTimestamp() == nil,
+		Message: fmt.Sprintf("%s %s applied", p.kind(), p.Config.Name),
+	}
+}
+
+// GetNamespace implements TargetComponent.
+func (p *ProviderConfig) GetNamespace() string {
+	// ProviderConfigs are cluster-scoped, their Secrets are stored in the namespace of Crossplane.
+	return CrossplaneNamespace
+}
+
+// IsInstallable implements Component.
+func (p *ProviderConfig) IsInstallable(ctx context.Context) (bool, error) {
+	if _, err := p.groupVersionKind(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetName implements Component.
+func (p *ProviderConfig) GetName() string {
+	// the names are separated like in the name of the Secret, so that they are unique
+	return "ProviderConfig" + formatPackageName(crossplane.AddProviderPrefix(p.Provider)) + "." + formatPackageName(p.Config.Name)
+}
+
+// GetDependencies implements Component.
+// The ProviderConfig is created once the provider is healthy and the credentials have been copied.
+// When it is uninstalled, the credentials are kept until the ProviderConfig is gone.
+func (p *ProviderConfig) GetDependencies() []juggler.Component {
+	return []juggler.Component{
+		&CrossplaneProvider{Config: &v1beta1.CrossplaneProviderConfig{Name: p.Provider}},
+		&Secret{Target: p.secretKey()},
+	}
+}
+
+// IsEnabled implements Component.
+func (p *ProviderConfig) IsEnabled() bool {
+	return p.Enabled
+}
+
+// Hooks implements Component.
+func (p *ProviderConfig) Hooks() juggler.ComponentHooks {
+	gvk, _ := p.groupVersionKind()
+	return juggler.ComponentHooks{
+		PreInstall: hooks.RequireCRD(gvk),
+	}
+}
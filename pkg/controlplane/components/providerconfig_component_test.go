@@ -0,0 +1,130 @@
+//nolint:dupl,lll
+package components
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/constants"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+)
+
+var providerConfigDefault = &v1beta1.CrossplaneProviderConfigTemplate{
+	Name:       "default",
+	APIVersion: "aws.upbound.io/v1beta1",
+	SecretRef:  v1beta1.ProviderConfigSecretReference{Name: "aws-credentials", Key: "credentials"},
+}
+
+func Test_ProviderConfig(t *testing.T) {
+	testCases := []struct {
+		desc            string
+		enabled         bool
+		config          *v1beta1.CrossplaneProviderConfigTemplate
+		validationFuncs []validationFunc
+	}{
+		{
+			desc:    "should be disabled",
+			enabled: false,
+			config:  providerConfigDefault,
+			validationFuncs: []validationFunc{
+				hasName("ProviderConfigProviderAws.Default"),
+				isEnabled(false),
+			},
+		},
+		{
+			desc:    "should not be allowed with invalid apiVersion",
+			enabled: true,
+			config:  &v1beta1.CrossplaneProviderConfigTemplate{Name: "default", APIVersion: "aws.upbound.io/v1/beta1"},
+			validationFuncs: []validationFunc{
+				isAllowed(false),
+			},
+		},
+		{
+			desc:    "should be enabled",
+			enabled: true,
+			config:  providerConfigDefault,
+			validationFuncs: []validationFunc{
+				hasName("ProviderConfigProviderAws.Default"),
+				isEnabled(true),
+				isAllowed(true),
+				hasDependencies(2),
+				hasPreInstallHook(),
+				isTargetComponent(
+					hasNamespace(CrossplaneNamespace),
+				),
+				isObjectComponent(
+					objectIsType(&unstructured.Unstructured{}),
+					canCheckHealthiness(&unstructured.Unstructured{}, juggler.ResourceHealthiness{
+						Healthy: true,
+						Message: "ProviderConfig default applied",
+					}),
+					canBuildAndReconcile(nil),
+					implementsOrphanedObjectsDetector(
+						listTypeIs(&corev1.SecretList{}),
+						hasFilterCriteria(3),
+						canConvert(&corev1.SecretList{Items: []corev1.Secret{*secretA}}, 1),
+						canCheckSame(
+							&ProviderConfig{Provider: "aws", Config: providerConfigDefault},
+							&ProviderConfig{Provider: "aws", Config: &v1beta1.CrossplaneProviderConfigTemplate{Name: "default", APIVersion: "aws.upbound.io/v1beta1", Kind: "ProviderConfig"}},
+							true),
+						canCheckSame(
+							&ProviderConfig{Provider: "aws", Config: providerConfigDefault},
+							&ProviderConfig{Provider: "aws", Config: &v1beta1.CrossplaneProviderConfigTemplate{Name: "default", APIVersion: "aws.upbound.io/v1beta1", Kind: "ClusterProviderConfig"}},
+							false),
+					),
+				),
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx := newContext(nil, nil, nil)
+			c := &ProviderConfig{Provider: "aws", Config: tC.config, Enabled: tC.enabled}
+			for _, vfn := range tC.validationFuncs {
+				vfn(t, ctx, c)
+			}
+		})
+	}
+}
+
+func Test_ProviderConfig_CredentialsSecret(t *testing.T) {
+	ctx := context.Background()
+	source := &corev1.Secret{}
+	source.SetName("aws-credentials")
+	source.SetNamespace("cp-test")
+	source.Data = map[string][]byte{"credentials": []byte("[default]")}
+	sourceClient := fake.NewClientBuilder().WithObjects(source).Build()
+
+	pc := &ProviderConfig{Provider: "provider-aws", Config: providerConfigDefault, Enabled: true}
+	s := pc.CredentialsSecret(sourceClient, "cp-test")
+	assert.Equal(t, types.NamespacedName{Namespace: CrossplaneNamespace, Name: "provider-aws.default"}, s.Target)
+
+	obj, _, err := s.BuildObjectToReconcile(ctx)
+	require.NoError(t, err)
+	require.NoError(t, s.ReconcileObject(ctx, obj))
+	secret := obj.(*corev1.Secret)
+	assert.Equal(t, source.Data, secret.Data)
+	assert.Equal(t, "true", secret.Labels[constants.LabelProviderConfig])
+
+	// the orphaned ProviderConfig is restored from the annotations of its Secret
+	dc := pc.OrphanDetectorContext(ctx)
+	detected := dc.ConvertFunc(&corev1.SecretList{Items: []corev1.Secret{*secret}})
+	require.Len(t, detected, 1)
+	assert.True(t, dc.SameFunc(pc, detected[0]))
+	assert.Equal(t, pc.GetName(), detected[0].GetName())
+
+	// the ProviderConfig is installed after and uninstalled before its provider and Secret
+	deps := pc.GetDependencies()
+	assert.True(t, deps[0].(juggler.DependencyMatcher).MatchesDependency(&CrossplaneProvider{Config: &v1beta1.CrossplaneProviderConfig{Name: "aws"}}))
+	assert.False(t, deps[0].(juggler.DependencyMatcher).MatchesDependency(&CrossplaneProvider{Config: &v1beta1.CrossplaneProviderConfig{Name: "gcp"}}))
+	assert.True(t, deps[1].(juggler.DependencyMatcher).MatchesDependency(s))
+	assert.False(t, deps[1].(juggler.DependencyMatcher).MatchesDependency(&Secret{Target: types.NamespacedName{Namespace: CrossplaneNamespace, Name: "other"}}))
+}
@@ -26,11 +26,15 @@ var _ object.ObjectComponent = &Secret{}
 var _ object.OrphanedObjectsDetector = &Secret{}
 var _ TargetComponent = &Secret{}
 var _ juggler.StatusVisibility = &Secret{}
+var _ juggler.DependencyMatcher = &Secret{}
 
 type Secret struct {
 	SourceClient   client.Client
 	Source, Target types.NamespacedName
 	Enabled        bool
+
+	// Labels and Annotations are added to the copied Secret.
+	Labels, Annotations map[string]string
 }
 
 // BuildObjectToReconcile implements object.ObjectComponent.
@@ -59,6 +63,12 @@ func (s *Secret) ReconcileObject(ctx context.Context, obj client.Object) error {
 
 	metav1.SetMetaDataLabel(&objSecret.ObjectMeta, constants.LabelCopySourceName, s.Source.Name)
 	metav1.SetMetaDataLabel(&objSecret.ObjectMeta, constants.LabelCopySourceNamespace, s.Source.Namespace)
+	for k, v := range s.Labels {
+		metav1.SetMetaDataLabel(&objSecret.ObjectMeta, k, v)
+	}
+	for k, v := range s.Annotations {
+		metav1.SetMetaDataAnnotation(&objSecret.ObjectMeta, k, v)
+	}
 
 	objSecret.Type = sourceSecret.Type
 	objSecret.Data = sourceSecret.Data
@@ -93,6 +103,12 @@ func (s *Secret) GetDependencies() []juggler.Component {
 	return []juggler.Component{}
 }
 
+// MatchesDependency implements juggler.DependencyMatcher.
+// A Secret without target matches all Secrets.
+func (s *Secret) MatchesDependency(registered juggler.Component) bool {
+	return s.Target == types.NamespacedName{} || registered.(*Secret).Target == s.Target
+}
+
 // GetName implements object.ObjectComponent.
 func (s *Secret) GetName() string {
 	return formatSecretName(s.Target.Name)
@@ -0,0 +1,240 @@
+package components
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler/fluxcd"
+)
+
+const (
+	telemetryRelease       = "opentelemetry-collector"
+	telemetryNamespace     = "telemetry-system"
+	ComponentNameTelemetry = "Telemetry"
+)
+
+var _ fluxcd.FluxComponent = &Telemetry{}
+var _ juggler.VersionedComponent = &Telemetry{}
+var _ juggler.VersionResolvingComponent = &Telemetry{}
+var _ UpgradableComponent = &Telemetry{}
+var _ TargetComponent = &Telemetry{}
+
+// telemetryDefinition is the built-in definition of the OpenTelemetry Collector.
+var telemetryDefinition = v1beta1.ComponentDefinitionSpec{
+	ReleaseChannelName: telemetryRelease,
+	ReleaseName:        telemetryRelease,
+	Namespace:          telemetryNamespace,
+	Chart: &v1beta1.ChartSpec{
+		Repository: "https://open-telemetry.github.io/opentelemetry-helm-charts",
+		Name:       "opentelemetry-collector",
+	},
+}
+
+// Telemetry installs an OpenTelemetry Collector, which scrapes the metrics of the components
+// and exports them via OTLP/HTTP.
+type Telemetry struct {
+	Config *v1beta1.TelemetryConfig
+	// DefaultEndpoint is the exporter endpoint used if the ControlPlane does not configure one.
+	DefaultEndpoint string
+	// Definitions may adjust the built-in definition of the component.
+	Definitions Definitions
+	// ScrapeNamespaces are the namespaces of the components whose metrics are scraped by the collector,
+	// see ComponentNamespaces.
+	ScrapeNamespaces []string
+
+	cached lazyHelm
+}
+
+// ComponentNamespaces returns the sorted namespaces of the Helm components, i.e. of the built-in
+// components as adjusted by their ComponentDefinitions and of the additional components.
+// The namespace of telemetry itself is not included.
+func ComponentNamespaces(comps []juggler.Component) []string {
+	namespaces := []string{}
+	for _, comp := range comps {
+		if _, ok := comp.(*Telemetry); ok {
+			continue
+		}
+		if _, ok := comp.(fluxcd.FluxComponent); !ok {
+			continue
+		}
+		if tc, ok := comp.(TargetComponent); ok && tc.GetNamespace() != "" {
+			namespaces = append(namespaces, tc.GetNamespace())
+		}
+	}
+	slices.Sort(namespaces)
+	return slices.Compact(namespaces)
+}
+
+func (t *Telemetry) helm() *helmComponent {
+	return t.cached.get(func() *helmComponent {
+		h := &helmComponent{
+			resourceName:  strings.ToLower(ComponentNameTelemetry),
+			definition:    t.Definitions.builtin(telemetryDefinition),
+			defaultValues: t.defaultValues(),
+		}
+		if t.Config != nil {
+			h.config = helmConfig{Version: t.GetVersion(), Chart: t.Config.Chart, Values: t.Config.Values, ValuesFrom: t.Config.ValuesFrom}
+		}
+		return h
+	})
+}
+
+// GetNamespace implements TargetComponent.
+func (t *Telemetry) GetNamespace() string {
+	return t.helm().definition.Namespace
+}
+
+func (t *Telemetry) GetName() string {
+	return ComponentNameTelemetry
+}
+
+func (t *Telemetry) GetDependencies() []juggler.Component {
+	return t.helm().dependencies()
+}
+
+// IsEnabled returns true if telemetry is enabled with a version and an exporter endpoint is configured.
+// Without an endpoint the collector has nowhere to export to and is therefore not installed.
+func (t *Telemetry) IsEnabled() bool {
+	return t.Config != nil && t.Config.Enabled && t.Config.Version != "" && t.endpoint() != ""
+}
+
+// GetVersion implements juggler.VersionedComponent.
+func (t *Telemetry) GetVersion() string {
+	if t.Config == nil {
+		return ""
+	}
+	return t.Config.Version
+}
+
+// GetResolvedVersion implements juggler.VersionResolvingComponent.
+func (t *Telemetry) GetResolvedVersion(ctx context.Context) (string, error) {
+	return t.helm().getResolvedVersion(ctx)
+}
+
+// GetReleaseChannelName implements UpgradableComponent.
+func (t *Telemetry) GetReleaseChannelName() string {
+	return t.helm().definition.ReleaseChannelName
+}
+
+// GetUpgradePolicy implements UpgradableComponent.
+func (t *Telemetry) GetUpgradePolicy() v1beta1.UpgradePolicy {
+	if t.Config == nil {
+		return ""
+	}
+	return t.Config.UpgradePolicy
+}
+
+func (t *Telemetry) Hooks() juggler.ComponentHooks {
+	return t.helm().hooks()
+}
+
+func (t *Telemetry) IsInstallable(ctx context.Context) (bool, error) {
+	return t.helm().isInstallable(ctx)
+}
+
+func (t *Telemetry) GetAvailableVersions(ctx context.Context) ([]string, error) {
+	return t.helm().getAvailableVersions(ctx)
+}
+
+// endpoint returns the exporter endpoint of the ControlPlane or the default endpoint.
+func (t *Telemetry) endpoint() string {
+	if t.Config == nil {
+		return t.DefaultEndpoint
+	}
+	return cmp.Or(t.Config.Endpoint, t.DefaultEndpoint)
+}
+
+func (t *Telemetry) BuildSourceRepository(ctx context.Context) (fluxcd.SourceAdapter, error) {
+	return t.helm().buildSourceRepository(ctx), nil
+}
+
+func (t *Telemetry) BuildManifesto(ctx context.Context) (fluxcd.Manifesto, error) {
+	return t.helm().buildManifesto(ctx)
+}
+
+// defaultValues configure the collector to scrape all pods with Prometheus annotations
+// in the namespaces of the components and to export the metrics to the endpoint.
+func (t *Telemetry) defaultValues() map[string]any {
+	return map[string]any{
+		"mode": "deployment",
+		"image": map[string]any{
+			"repository": "otel/opentelemetry-collector-k8s",
+		},
+		"clusterRole": map[string]any{
+			"create": true,
+			"rules": []any{
+				map[string]any{
+					"apiGroups": []any{""},
+					"resources": []any{"pods"},
+					"verbs":     []any{"get", "list", "watch"},
+				},
+			},
+		},
+		"config": map[string]any{
+			"receivers": map[string]any{
+				"prometheus": map[string]any{
+					"config": map[string]any{
+						"scrape_configs": []any{
+							map[string]any{
+								"job_name": "controlplane-components",
+								"kubernetes_sd_configs": []any{
+									map[string]any{
+										"role":       "pod",
+										"namespaces": map[string]any{"names": toAnySlice(t.ScrapeNamespaces)},
+									},
+								},
+								"relabel_configs": []any{
+									map[string]any{
+										"source_labels": []any{"__meta_kubernetes_pod_annotation_prometheus_io_scrape"},
+										"action":        "keep",
+										"regex":         "true",
+									},
+									map[string]any{
+										"source_labels": []any{"__address__", "__meta_kubernetes_pod_annotation_prometheus_io_port"},
+										"action":        "replace",
+										"regex":         `([^:]+)(?::\d+)?;(\d+)`,
+										"replacement":   "$$1:$$2",
+										"target_label":  "__address__",
+									},
+									map[string]any{
+										"source_labels": []any{"__meta_kubernetes_namespace"},
+										"target_label":  "namespace",
+									},
+									map[string]any{
+										"source_labels": []any{"__meta_kubernetes_pod_name"},
+										"target_label":  "pod",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"exporters": map[string]any{
+				"otlphttp": map[string]any{
+					"endpoint": t.endpoint(),
+				},
+			},
+			"service": map[string]any{
+				"pipelines": map[string]any{
+					"metrics": map[string]any{
+						"receivers": []any{"prometheus"},
+						"exporters": []any{"otlphttp"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func toAnySlice(s []string) []any {
+	result := make([]any, 0, len(s))
+	for _, v := range s {
+		result = append(result, v)
+	}
+	return result
+}
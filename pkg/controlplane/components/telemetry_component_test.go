@@ -0,0 +1,167 @@
+package components
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler/fluxcd"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils"
+)
+
+func hasScrapeNamespaces(expected ...string) helmReleaseValidationFunc {
+	return func(t *testing.T, ctx context.Context, h *fluxcd.HelmReleaseManifesto) {
+		scrapeConfigs, err := utils.GetNestedValue(h.Manifest.GetValues(), "config", "receivers", "prometheus", "config", "scrape_configs")
+		require.NoError(t, err)
+		sdConfigs := scrapeConfigs.([]any)[0].(map[string]any)["kubernetes_sd_configs"].([]any)
+		assert.EqualValues(t, toAnySlice(expected), sdConfigs[0].(map[string]any)["namespaces"].(map[string]any)["names"])
+	}
+}
+
+func Test_Telemetry(t *testing.T) {
+	testCases := []struct {
+		desc                      string
+		config                    *v1beta1.TelemetryConfig
+		defaultEndpoint           string
+		scrapeNamespaces          []string
+		versionResolver           v1beta1.VersionResolverFn
+		availableVersionsResolver v1beta1.AvailableVersionsResolverFn
+		validationFuncs           []validationFunc
+	}{
+		{
+			desc: "should be disabled",
+			validationFuncs: []validationFunc{
+				hasName("Telemetry"),
+				isEnabled(false),
+			},
+		},
+		{
+			desc:            "should be disabled if not enabled explicitly",
+			config:          &v1beta1.TelemetryConfig{Endpoint: "https://otlp.example.com"},
+			versionResolver: fakeVersionResolver(false),
+			validationFuncs: []validationFunc{
+				hasName("Telemetry"),
+				isEnabled(false),
+			},
+		},
+		{
+			desc:            "should be disabled without endpoint",
+			config:          &v1beta1.TelemetryConfig{Enabled: true},
+			versionResolver: fakeVersionResolver(false),
+			validationFuncs: []validationFunc{
+				hasName("Telemetry"),
+				isEnabled(false),
+			},
+		},
+		{
+			desc:            "should be disabled without version",
+			config:          &v1beta1.TelemetryConfig{Enabled: true},
+			defaultEndpoint: "https://otlp.example.com",
+			versionResolver: fakeVersionResolver(false),
+			validationFuncs: []validationFunc{
+				hasName("Telemetry"),
+				isEnabled(false),
+			},
+		},
+		{
+			desc:            "should not be allowed",
+			config:          &v1beta1.TelemetryConfig{Enabled: true, Version: "1.2.3"},
+			defaultEndpoint: "https://otlp.example.com",
+			versionResolver: fakeVersionResolver(true),
+			validationFuncs: []validationFunc{
+				hasName("Telemetry"),
+				isEnabled(true),
+				isAllowed(false),
+			},
+		},
+		{
+			desc:                      "returns available versions from context resolver",
+			config:                    &v1beta1.TelemetryConfig{},
+			availableVersionsResolver: fakeAvailableVersionsResolver(false),
+			validationFuncs: []validationFunc{
+				hasName("Telemetry"),
+				hasAvailableVersions([]string{"1.1.0", "1.2.0"}),
+			},
+		},
+		{
+			desc:             "should be enabled with default endpoint",
+			config:           &v1beta1.TelemetryConfig{Enabled: true, Version: "1.2.3"},
+			scrapeNamespaces: []string{"cert-manager", "crossplane-system"},
+			defaultEndpoint:  "https://otlp.example.com",
+			versionResolver:  fakeVersionResolver(false),
+			validationFuncs: []validationFunc{
+				hasName("Telemetry"),
+				isEnabled(true),
+				isAllowed(true),
+				hasNoHooks(),
+				hasDependencies(0),
+				isTargetComponent(
+					hasNamespace("telemetry-system"),
+				),
+				isFluxComponent(
+					returnsHelmRepo(),
+					returnsHelmRelease(
+						hasKubeconfigRef(),
+						hasHelmValue("deployment", "mode"),
+						hasHelmValue("https://otlp.example.com", "config", "exporters", "otlphttp", "endpoint"),
+						hasHelmValue([]any{"prometheus"}, "config", "service", "pipelines", "metrics", "receivers"),
+						hasScrapeNamespaces("cert-manager", "crossplane-system"),
+					),
+				),
+			},
+		},
+		{
+			desc: "should be enabled",
+			config: &v1beta1.TelemetryConfig{
+				Enabled:  true,
+				Version:  "1.2.3",
+				Endpoint: "https://otlp.example.com/tenant",
+				Values:   &apiextensionsv1.JSON{Raw: []byte(`{"mode":"daemonset"}`)},
+			},
+			defaultEndpoint: "https://otlp.example.com",
+			versionResolver: fakeVersionResolver(false),
+			validationFuncs: []validationFunc{
+				isEnabled(true),
+				isAllowed(true),
+				isFluxComponent(
+					returnsHelmRepo(),
+					returnsHelmRelease(
+						hasKubeconfigRef(),
+						hasHelmValue("daemonset", "mode"),
+						hasHelmValue("https://otlp.example.com/tenant", "config", "exporters", "otlphttp", "endpoint"),
+					),
+				),
+			},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			ctx := newContext(nil, tC.versionResolver, tC.availableVersionsResolver)
+			c := &Telemetry{Config: tC.config, DefaultEndpoint: tC.defaultEndpoint, ScrapeNamespaces: tC.scrapeNamespaces}
+			for _, vfn := range tC.validationFuncs {
+				vfn(t, ctx, c)
+			}
+		})
+	}
+}
+
+func TestComponentNamespaces(t *testing.T) {
+	definitions := Definitions{
+		kyvernoRelease: {Namespace: "policies"},
+		"web":          {Namespace: "web-system"},
+	}
+	comps := []juggler.Component{
+		&Crossplane{Definitions: definitions},
+		&CrossplaneProvider{},
+		&ClusterRole{},
+		&Kyverno{Definitions: definitions},
+		&Telemetry{Definitions: definitions},
+		&AdditionalComponent{Config: &v1beta1.AdditionalComponentConfig{Name: "frontend", Definition: "web"}, Definitions: definitions},
+	}
+	assert.Equal(t, []string{CrossplaneNamespace, "policies", "web-system"}, ComponentNamespaces(comps))
+}
@@ -42,6 +42,16 @@ func fakeVersionResolver(shouldFail bool) v1beta1.VersionResolverFn {
 	}
 }
 
+func fakeOCIVersionResolver() v1beta1.VersionResolverFn {
+	return func(componentName string, channelName string) (v1beta1.ComponentVersion, error) {
+		return v1beta1.ComponentVersion{
+			Version:   "v1.0.0",
+			HelmChart: strings.ToLower(componentName),
+			OCIURL:    "oci://registry.example.com/charts/" + strings.ToLower(componentName),
+		}, nil
+	}
+}
+
 func fakeAvailableVersionsResolver(shouldFail bool) v1beta1.AvailableVersionsResolverFn {
 	return func(componentName string) ([]string, error) {
 		if shouldFail {
@@ -138,6 +148,24 @@ func hasAvailableVersionsError(expected error) validationFunc {
 	}
 }
 
+func hasResolvedVersion(expected string) validationFunc {
+	return func(t *testing.T, ctx context.Context, c juggler.Component) {
+		vrc := c.(juggler.VersionResolvingComponent)
+		version, err := vrc.GetResolvedVersion(ctx)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, version)
+	}
+}
+
+func hasResolvedVersionError(expected error) validationFunc {
+	return func(t *testing.T, ctx context.Context, c juggler.Component) {
+		vrc := c.(juggler.VersionResolvingComponent)
+		version, err := vrc.GetResolvedVersion(ctx)
+		assert.ErrorIs(t, err, expected)
+		assert.Empty(t, version)
+	}
+}
+
 func newContext(fn secretresolver.ResolveFunc, fn2 v1beta1.VersionResolverFn, fn3 v1beta1.AvailableVersionsResolverFn) context.Context {
 	ctx := context.Background()
 	ctx = rcontext.WithTenantNamespace(ctx, tenantNamespace)
@@ -193,6 +221,54 @@ func returnsHelmRepo() fluxValidationFunc {
 	}
 }
 
+func returnsOCIRepo(url, tag string) fluxValidationFunc {
+	return func(t *testing.T, ctx context.Context, c fluxcd.FluxComponent) {
+		s, err := c.BuildSourceRepository(ctx)
+		assert.NoError(t, err)
+
+		o, ok := s.(*fluxcd.OCIRepositoryAdapter)
+		if !assert.True(t, ok, "not an OCIRepositoryAdapter") {
+			return
+		}
+		assert.Equal(t, url, o.Source.Spec.URL)
+		if assert.NotNil(t, o.Source.Spec.Reference) {
+			assert.Equal(t, tag, o.Source.Spec.Reference.Tag)
+		}
+	}
+}
+
+func returnsGitRepo(url, tag string) fluxValidationFunc {
+	return func(t *testing.T, ctx context.Context, c fluxcd.FluxComponent) {
+		s, err := c.BuildSourceRepository(ctx)
+		assert.NoError(t, err)
+
+		g, ok := s.(*fluxcd.GitRepositoryAdapter)
+		if !assert.True(t, ok, "not a GitRepositoryAdapter") {
+			return
+		}
+		assert.Equal(t, url, g.Source.Spec.URL)
+		if assert.NotNil(t, g.Source.Spec.Reference) {
+			assert.Equal(t, tag, g.Source.Spec.Reference.Tag)
+		}
+	}
+}
+
+func returnsKustomization(sourceKind, path string) fluxValidationFunc {
+	return func(t *testing.T, ctx context.Context, c fluxcd.FluxComponent) {
+		m, err := c.BuildManifesto(ctx)
+		assert.NoError(t, err)
+
+		k, ok := m.(*fluxcd.KustomizationManifesto)
+		if !assert.True(t, ok, "not a KustomizationManifesto") {
+			return
+		}
+		assert.Equal(t, sourceKind, k.Manifest.Spec.SourceRef.Kind)
+		assert.Equal(t, k.Manifest.Name, k.Manifest.Spec.SourceRef.Name)
+		assert.Equal(t, path, k.Manifest.Spec.Path)
+		assert.NotNil(t, k.Manifest.Spec.KubeConfig)
+	}
+}
+
 func returnsHelmRelease(additionalValidations ...helmReleaseValidationFunc) fluxValidationFunc {
 	return func(t *testing.T, ctx context.Context, c fluxcd.FluxComponent) {
 		m, err := c.BuildManifesto(ctx)
@@ -217,6 +293,16 @@ func hasKubeconfigRef() helmReleaseValidationFunc {
 	}
 }
 
+func hasChartRef(kind string) helmReleaseValidationFunc {
+	return func(t *testing.T, ctx context.Context, h *fluxcd.HelmReleaseManifesto) {
+		assert.Nil(t, h.Manifest.Spec.Chart, "chart template is set")
+		if assert.NotNil(t, h.Manifest.Spec.ChartRef, "chartRef is nil") {
+			assert.Equal(t, kind, h.Manifest.Spec.ChartRef.Kind)
+			assert.Equal(t, h.Manifest.Name, h.Manifest.Spec.ChartRef.Name)
+		}
+	}
+}
+
 func hasHelmValue(expected any, path ...string) helmReleaseValidationFunc {
 	return func(t *testing.T, ctx context.Context, h *fluxcd.HelmReleaseManifesto) {
 		if assert.NotNil(t, h.Manifest.Spec.Values, "values are nil") {
@@ -0,0 +1,33 @@
+package crossplane
+
+import (
+	crossplanev1beta1 "github.com/crossplane/crossplane/apis/v2/pkg/v1beta1"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils"
+)
+
+// ReconcileImageConfig configures an ImageConfig with the prefixes, the rewrite and the pull secret of the config.
+func ReconcileImageConfig(imageConfig *crossplanev1beta1.ImageConfig, config v1beta1.CrossplaneImageConfig) {
+	utils.SetManagedBy(imageConfig)
+
+	imageConfig.Spec.MatchImages = make([]crossplanev1beta1.ImageMatch, 0, len(config.MatchPrefixes))
+	for _, prefix := range config.MatchPrefixes {
+		imageConfig.Spec.MatchImages = append(imageConfig.Spec.MatchImages, crossplanev1beta1.ImageMatch{
+			Type:   crossplanev1beta1.Prefix,
+			Prefix: prefix,
+		})
+	}
+
+	imageConfig.Spec.RewriteImage = nil
+	if config.RewritePrefix != "" {
+		imageConfig.Spec.RewriteImage = &crossplanev1beta1.ImageRewrite{Prefix: config.RewritePrefix}
+	}
+
+	imageConfig.Spec.Registry = nil
+	if config.PullSecretRef != nil {
+		imageConfig.Spec.Registry = &crossplanev1beta1.RegistryConfig{
+			Authentication: &crossplanev1beta1.RegistryAuthentication{PullSecretRef: *config.PullSecretRef},
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package crossplane
+
+import (
+	"testing"
+
+	crossplanev1beta1 "github.com/crossplane/crossplane/apis/v2/pkg/v1beta1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+)
+
+func TestReconcileImageConfig(t *testing.T) {
+	tt := []struct {
+		desc   string
+		config v1beta1.CrossplaneImageConfig
+		exp    crossplanev1beta1.ImageConfigSpec
+	}{
+		{
+			desc: "registry mirror",
+			config: v1beta1.CrossplaneImageConfig{
+				Name:          "mirror",
+				MatchPrefixes: []string{"xpkg.upbound.io/crossplane-contrib", "xpkg.upbound.io/upbound"},
+				RewritePrefix: "registry.example.com/crossplane",
+			},
+			exp: crossplanev1beta1.ImageConfigSpec{
+				MatchImages: []crossplanev1beta1.ImageMatch{
+					{Type: crossplanev1beta1.Prefix, Prefix: "xpkg.upbound.io/crossplane-contrib"},
+					{Type: crossplanev1beta1.Prefix, Prefix: "xpkg.upbound.io/upbound"},
+				},
+				RewriteImage: &crossplanev1beta1.ImageRewrite{Prefix: "registry.example.com/crossplane"},
+			},
+		},
+		{
+			desc: "pull secret",
+			config: v1beta1.CrossplaneImageConfig{
+				Name:          "private",
+				MatchPrefixes: []string{"registry.example.com/private"},
+				PullSecretRef: &corev1.LocalObjectReference{Name: "registry-credentials"},
+			},
+			exp: crossplanev1beta1.ImageConfigSpec{
+				MatchImages: []crossplanev1beta1.ImageMatch{
+					{Type: crossplanev1beta1.Prefix, Prefix: "registry.example.com/private"},
+				},
+				Registry: &crossplanev1beta1.RegistryConfig{
+					Authentication: &crossplanev1beta1.RegistryAuthentication{
+						PullSecretRef: corev1.LocalObjectReference{Name: "registry-credentials"},
+					},
+				},
+			},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			imageConfig := &crossplanev1beta1.ImageConfig{
+				Spec: crossplanev1beta1.ImageConfigSpec{
+					MatchImages:  []crossplanev1beta1.ImageMatch{{Prefix: "removed"}},
+					RewriteImage: &crossplanev1beta1.ImageRewrite{Prefix: "removed"},
+				},
+			}
+			ReconcileImageConfig(imageConfig, tc.config)
+			assert.Equal(t, tc.exp, imageConfig.Spec)
+			assert.Equal(t, "control-plane-operator", imageConfig.Labels["app.kubernetes.io/managed-by"])
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package crossplane
+
+import (
+	"fmt"
+	"strings"
+
+	crossplanev1 "github.com/crossplane/crossplane/apis/v2/pkg/v1"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils"
+)
+
+const (
+	configurationPrefix = "configuration-"
+	functionPrefix      = "function-"
+)
+
+// ReconcilePackage configures a Crossplane Configuration or Function with the given package configuration.
+func ReconcilePackage(pkg crossplanev1.Package, config v1beta1.CrossplanePackageConfig) error {
+	utils.SetManagedBy(pkg)
+	pkg.SetSource(config.Package)
+	pkg.SetPackagePullPolicy(config.PackagePullPolicy)
+	pkg.SetPackagePullSecrets(config.PackagePullSecrets)
+	return nil
+}
+
+// PackageVersion returns the version (i.e. the tag) of the package that is installed by a Crossplane package.
+// If the package has not resolved its current package yet, the version of the desired package is returned.
+func PackageVersion(pkg crossplanev1.Package) string {
+	ref := pkg.GetCurrentIdentifier()
+	if ref == "" {
+		ref = pkg.GetSource()
+	}
+	if i := strings.LastIndex(ref, ":"); i > strings.LastIndex(ref, "/") {
+		return ref[i+1:]
+	}
+	return ""
+}
+
+func addPrefix(prefix, name string) string {
+	if strings.HasPrefix(name, prefix) {
+		return name
+	}
+	return fmt.Sprintf("%s%s", prefix, name)
+}
+
+// TrimConfigurationPrefix returns the name of a configuration without the prefix of its Configuration manifest.
+func TrimConfigurationPrefix(name string) string {
+	return strings.TrimPrefix(name, configurationPrefix)
+}
+
+// TrimFunctionPrefix returns the name of a function without the prefix of its Function manifest.
+func TrimFunctionPrefix(name string) string {
+	return strings.TrimPrefix(name, functionPrefix)
+}
+
+// ConfigurationNameForPackageConfig returns the name of a Configuration crossplane manifest for a package config.
+// It consists of the name of the configuration with a prefix.
+func ConfigurationNameForPackageConfig(p *v1beta1.CrossplanePackageConfig) string {
+	return addPrefix(configurationPrefix, p.Name)
+}
+
+// FunctionNameForPackageConfig returns the name of a Function crossplane manifest for a package config.
+// It consists of the name of the function with a prefix.
+func FunctionNameForPackageConfig(p *v1beta1.CrossplanePackageConfig) string {
+	return addPrefix(functionPrefix, p.Name)
+}
@@ -0,0 +1,127 @@
+package crossplane
+
+import (
+	"testing"
+
+	crossplanev1 "github.com/crossplane/crossplane/apis/v2/pkg/v1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+)
+
+func TestReconcilePackage(t *testing.T) {
+	input := v1beta1.CrossplanePackageConfig{
+		Name:              "patch-and-transform",
+		Version:           "v0.8.2",
+		Package:           "xpkg.crossplane.io/crossplane-contrib/function-patch-and-transform:v0.8.2",
+		PackagePullPolicy: ptr.To(corev1.PullAlways),
+		PackagePullSecrets: []corev1.LocalObjectReference{
+			{Name: "my-secret"},
+		},
+	}
+
+	expected := &crossplanev1.Function{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "function-patch-and-transform",
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "control-plane-operator",
+			},
+		},
+		Spec: crossplanev1.FunctionSpec{
+			PackageSpec: crossplanev1.PackageSpec{
+				Package:           "xpkg.crossplane.io/crossplane-contrib/function-patch-and-transform:v0.8.2",
+				PackagePullPolicy: ptr.To(corev1.PullAlways),
+				PackagePullSecrets: []corev1.LocalObjectReference{
+					{Name: "my-secret"},
+				},
+			},
+		},
+	}
+
+	function := &crossplanev1.Function{}
+	function.SetName(FunctionNameForPackageConfig(&input))
+
+	err := ReconcilePackage(function, input)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, function)
+}
+
+func TestPackageNames(t *testing.T) {
+	tt := []struct {
+		desc   string
+		nameFn func(*v1beta1.CrossplanePackageConfig) string
+		trimFn func(string) string
+		in     string
+		exp    string
+	}{
+		{
+			desc:   "configuration without prefix",
+			nameFn: ConfigurationNameForPackageConfig,
+			trimFn: TrimConfigurationPrefix,
+			in:     "platform-ref",
+			exp:    configurationPrefix + "platform-ref",
+		},
+		{
+			desc:   "configuration with prefix",
+			nameFn: ConfigurationNameForPackageConfig,
+			trimFn: TrimConfigurationPrefix,
+			in:     configurationPrefix + "platform-ref",
+			exp:    configurationPrefix + "platform-ref",
+		},
+		{
+			desc:   "function without prefix",
+			nameFn: FunctionNameForPackageConfig,
+			trimFn: TrimFunctionPrefix,
+			in:     "auto-ready",
+			exp:    functionPrefix + "auto-ready",
+		},
+		{
+			desc:   "function with prefix",
+			nameFn: FunctionNameForPackageConfig,
+			trimFn: TrimFunctionPrefix,
+			in:     functionPrefix + "auto-ready",
+			exp:    functionPrefix + "auto-ready",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			actual := tc.nameFn(&v1beta1.CrossplanePackageConfig{Name: tc.in})
+			assert.Equal(t, tc.exp, actual)
+			assert.Equal(t, tc.exp, tc.nameFn(&v1beta1.CrossplanePackageConfig{Name: tc.trimFn(actual)}))
+		})
+	}
+}
+
+func TestPackageVersion(t *testing.T) {
+	tt := []struct {
+		desc string
+		pkg  crossplanev1.Package
+		exp  string
+	}{
+		{
+			desc: "current configuration package",
+			pkg: &crossplanev1.Configuration{
+				Spec:   crossplanev1.ConfigurationSpec{PackageSpec: crossplanev1.PackageSpec{Package: "xpkg.upbound.io/upbound/platform-ref-aws:v1.1.0"}},
+				Status: crossplanev1.ConfigurationStatus{PackageStatus: crossplanev1.PackageStatus{CurrentIdentifier: "xpkg.upbound.io/upbound/platform-ref-aws:v1.0.0"}},
+			},
+			exp: "v1.0.0",
+		},
+		{
+			desc: "desired function package",
+			pkg: &crossplanev1.Function{
+				Spec: crossplanev1.FunctionSpec{PackageSpec: crossplanev1.PackageSpec{Package: "localhost:5000/function-auto-ready:v0.2.1"}},
+			},
+			exp: "v0.2.1",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.exp, PackageVersion(tc.pkg))
+		})
+	}
+}
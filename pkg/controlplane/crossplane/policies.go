@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 
 	crossplanev1 "github.com/crossplane/crossplane/apis/v2/pkg/v1"
@@ -101,6 +102,20 @@ func ReconcilePolicy(pt PackageType, policy *arv1.ValidatingAdmissionPolicy) err
 	return nil
 }
 
+// IsPackageAllowed returns if the package is allowed by the restriction.
+// It mirrors the validation of the ValidatingAdmissionPolicy configured by ReconcilePolicy.
+func IsPackageAllowed(restriction v1beta1.PackageRestriction, pkg string) bool {
+	if slices.Contains(restriction.Packages, "*") || slices.Contains(restriction.Registries, "*") {
+		return true
+	}
+	if slices.Contains(restriction.Packages, pkg) || slices.Contains(restriction.Packages, strings.SplitN(pkg, ":", 2)[0]) {
+		return true
+	}
+	return slices.ContainsFunc(restriction.Registries, func(registry string) bool {
+		return strings.HasPrefix(pkg, registry+"/")
+	})
+}
+
 func ReconcilePolicyBinding(cprName, policyName string, binding *arv1.ValidatingAdmissionPolicyBinding) error {
 	// Reconcile ValidatingAdmissionPolicyBinding
 	binding.Spec.PolicyName = policyName
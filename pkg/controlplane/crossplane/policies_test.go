@@ -14,6 +14,8 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
 )
 
 var (
@@ -47,6 +49,64 @@ func Test_ReconcilePolicyBinding(t *testing.T) {
 	assert.Contains(t, binding.Spec.ValidationActions, arv1.Deny)
 }
 
+func Test_IsPackageAllowed(t *testing.T) {
+	tests := []struct {
+		name        string
+		restriction v1beta1.PackageRestriction
+		pkg         string
+		want        bool
+	}{
+		{name: "nothing allowed", pkg: "xpkg.upbound.io/upbound/provider-aws:v1.0.0", want: false},
+		{
+			name:        "all packages allowed",
+			restriction: v1beta1.PackageRestriction{Packages: []string{"*"}},
+			pkg:         "xpkg.upbound.io/upbound/provider-aws:v1.0.0",
+			want:        true,
+		},
+		{
+			name:        "all registries allowed",
+			restriction: v1beta1.PackageRestriction{Registries: []string{"*"}},
+			pkg:         "xpkg.upbound.io/upbound/provider-aws:v1.0.0",
+			want:        true,
+		},
+		{
+			name:        "package with version allowed",
+			restriction: v1beta1.PackageRestriction{Packages: []string{"xpkg.upbound.io/upbound/provider-aws:v1.0.0"}},
+			pkg:         "xpkg.upbound.io/upbound/provider-aws:v1.0.0",
+			want:        true,
+		},
+		{
+			name:        "other version not allowed",
+			restriction: v1beta1.PackageRestriction{Packages: []string{"xpkg.upbound.io/upbound/provider-aws:v1.0.0"}},
+			pkg:         "xpkg.upbound.io/upbound/provider-aws:v1.1.0",
+			want:        false,
+		},
+		{
+			name:        "package without version allowed",
+			restriction: v1beta1.PackageRestriction{Packages: []string{"xpkg.upbound.io/upbound/provider-aws"}},
+			pkg:         "xpkg.upbound.io/upbound/provider-aws:v1.1.0",
+			want:        true,
+		},
+		{
+			name:        "registry allowed",
+			restriction: v1beta1.PackageRestriction{Registries: []string{"xpkg.upbound.io"}},
+			pkg:         "xpkg.upbound.io/upbound/provider-aws:v1.1.0",
+			want:        true,
+		},
+		{
+			name:        "registry prefix not allowed",
+			restriction: v1beta1.PackageRestriction{Registries: []string{"xpkg.upbound"}},
+			pkg:         "xpkg.upbound.io/upbound/provider-aws:v1.1.0",
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsPackageAllowed(tt.restriction, tt.pkg))
+		})
+	}
+}
+
 func Test_CheckIfPolicyIsInstalled(t *testing.T) {
 	testCases := []struct {
 		desc             string
@@ -0,0 +1,46 @@
+package crossplane
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils"
+)
+
+// ProviderConfigSecretName returns the name of the Secret with the credentials of a ProviderConfig in the target cluster.
+// It consists of the name of the Provider crossplane manifest and the name of the ProviderConfig, separated by a dot,
+// because both names may contain dashes, e.g. `provider-aws.s3-default` and `provider-aws-s3.default`.
+func ProviderConfigSecretName(providerName, providerConfigName string) string {
+	return fmt.Sprintf("%s.%s", AddProviderPrefix(providerName), providerConfigName)
+}
+
+// ReconcileProviderConfig configures a ProviderConfig with the given template.
+// Unless the template configures the credentials, they are taken from the given key of the Secret.
+func ReconcileProviderConfig(obj *unstructured.Unstructured, config v1beta1.CrossplaneProviderConfigTemplate, secret types.NamespacedName) error {
+	utils.SetManagedBy(obj)
+
+	spec := map[string]any{}
+	if config.Spec != nil {
+		if err := json.Unmarshal(config.Spec.Raw, &spec); err != nil {
+			return err
+		}
+	}
+	if spec == nil {
+		spec = map[string]any{}
+	}
+	if _, ok := spec["credentials"]; !ok {
+		spec["credentials"] = map[string]any{
+			"source": "Secret",
+			"secretRef": map[string]any{
+				"namespace": secret.Namespace,
+				"name":      secret.Name,
+				"key":       config.SecretRef.Key,
+			},
+		}
+	}
+	return unstructured.SetNestedMap(obj.Object, spec, "spec")
+}
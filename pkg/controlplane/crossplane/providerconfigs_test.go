@@ -0,0 +1,80 @@
+package crossplane
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+)
+
+func TestProviderConfigSecretName(t *testing.T) {
+	assert.Equal(t, "provider-aws.default", ProviderConfigSecretName("aws", "default"))
+	assert.Equal(t, "provider-aws.default", ProviderConfigSecretName("provider-aws", "default"))
+	assert.NotEqual(t, ProviderConfigSecretName("aws", "s3-default"), ProviderConfigSecretName("aws-s3", "default"))
+}
+
+func TestReconcileProviderConfig(t *testing.T) {
+	secret := types.NamespacedName{Namespace: "crossplane-system", Name: "provider-aws.default"}
+
+	tt := []struct {
+		desc string
+		spec *apiextensionsv1.JSON
+		exp  map[string]any
+	}{
+		{
+			desc: "credentials from the copied secret",
+			exp: map[string]any{
+				"credentials": map[string]any{
+					"source": "Secret",
+					"secretRef": map[string]any{
+						"namespace": "crossplane-system",
+						"name":      "provider-aws.default",
+						"key":       "credentials",
+					},
+				},
+			},
+		},
+		{
+			desc: "spec is extended with the credentials",
+			spec: &apiextensionsv1.JSON{Raw: []byte(`{"assumeRoleChain":[{"roleARN":"arn:aws:iam::123456789012:role/crossplane"}]}`)},
+			exp: map[string]any{
+				"assumeRoleChain": []any{map[string]any{"roleARN": "arn:aws:iam::123456789012:role/crossplane"}},
+				"credentials": map[string]any{
+					"source": "Secret",
+					"secretRef": map[string]any{
+						"namespace": "crossplane-system",
+						"name":      "provider-aws.default",
+						"key":       "credentials",
+					},
+				},
+			},
+		},
+		{
+			desc: "credentials of the spec are kept",
+			spec: &apiextensionsv1.JSON{Raw: []byte(`{"credentials":{"source":"IRSA"}}`)},
+			exp: map[string]any{
+				"credentials": map[string]any{"source": "IRSA"},
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			obj := &unstructured.Unstructured{Object: map[string]any{}}
+			err := ReconcileProviderConfig(obj, v1beta1.CrossplaneProviderConfigTemplate{
+				Name:       "default",
+				APIVersion: "aws.upbound.io/v1beta1",
+				Kind:       "ProviderConfig",
+				SecretRef:  v1beta1.ProviderConfigSecretReference{Name: "aws-credentials", Key: "credentials"},
+				Spec:       tc.spec,
+			}, secret)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.exp, obj.Object["spec"])
+			assert.Equal(t, "control-plane-operator", obj.GetLabels()["app.kubernetes.io/managed-by"])
+		})
+	}
+}
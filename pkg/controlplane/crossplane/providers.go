@@ -1,7 +1,6 @@
 package crossplane
 
 import (
-	"fmt"
 	"strings"
 
 	crossplanev1 "github.com/crossplane/crossplane/apis/v2/pkg/v1"
@@ -34,11 +33,14 @@ func ReconcileProvider(provider *crossplanev1.Provider, config v1beta1.Crossplan
 	return nil
 }
 
+// ProviderVersion returns the version (i.e. the tag) of the package that is installed by the Provider.
+// If the Provider has not resolved its current package yet, the version of the desired package is returned.
+func ProviderVersion(provider *crossplanev1.Provider) string {
+	return PackageVersion(provider)
+}
+
 func AddProviderPrefix(providerName string) string {
-	if strings.HasPrefix(providerName, providerPrefix) {
-		return providerName
-	}
-	return fmt.Sprintf("%s%s", providerPrefix, providerName)
+	return addPrefix(providerPrefix, providerName)
 }
 
 func TrimProviderPrefix(providerName string) string {
@@ -112,3 +112,40 @@ func TestTrimProviderPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestProviderVersion(t *testing.T) {
+	tt := []struct {
+		desc     string
+		provider *crossplanev1.Provider
+		exp      string
+	}{
+		{
+			desc: "current package",
+			provider: &crossplanev1.Provider{
+				Spec:   crossplanev1.ProviderSpec{PackageSpec: crossplanev1.PackageSpec{Package: "xpkg.upbound.io/upbound/provider-aws:v1.1.0"}},
+				Status: crossplanev1.ProviderStatus{PackageStatus: crossplanev1.PackageStatus{CurrentIdentifier: "xpkg.upbound.io/upbound/provider-aws:v1.0.0"}},
+			},
+			exp: "v1.0.0",
+		},
+		{
+			desc: "desired package",
+			provider: &crossplanev1.Provider{
+				Spec: crossplanev1.ProviderSpec{PackageSpec: crossplanev1.PackageSpec{Package: "localhost:5000/provider-aws:v1.1.0"}},
+			},
+			exp: "v1.1.0",
+		},
+		{
+			desc: "no tag",
+			provider: &crossplanev1.Provider{
+				Spec: crossplanev1.ProviderSpec{PackageSpec: crossplanev1.PackageSpec{Package: "localhost:5000/provider-aws"}},
+			},
+			exp: "",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			assert.Equal(t, tc.exp, ProviderVersion(tc.provider))
+		})
+	}
+}
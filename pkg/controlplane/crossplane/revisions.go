@@ -0,0 +1,164 @@
+package crossplane
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	crossplanev1 "github.com/crossplane/crossplane/apis/v2/pkg/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var imagePullReasons = []string{"ErrImagePull", "ImagePullBackOff", "InvalidImageName"}
+
+// RevisionInspector describes the current ProviderRevisions of Providers.
+// The runtime Deployments and Pods are listed once and shared by all Providers,
+// therefore an inspector should only be used for a single reconciliation.
+// CRDs are read one by one, because lists of CRDs contain their full schemas.
+type RevisionInspector struct {
+	// Namespace is the namespace of the provider runtimes.
+	Namespace string
+
+	deployments cachedList[*appsv1.DeploymentList]
+	pods        cachedList[*corev1.PodList]
+}
+
+// cachedList lists objects on first use and returns the same list afterwards.
+// Failed lists are retried on the next use.
+type cachedList[L client.ObjectList] struct {
+	mu   sync.Mutex
+	list L
+	done bool
+}
+
+func (l *cachedList[L]) get(ctx context.Context, c client.Client, list L, opts ...client.ListOption) (L, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.done {
+		return l.list, nil
+	}
+	if err := c.List(ctx, list, opts...); err != nil {
+		return list, err
+	}
+	l.list, l.done = list, true
+	return list, nil
+}
+
+// ProviderRevisionDetails describes the current ProviderRevision of a Provider: its unhealthy conditions,
+// its dependencies, the CRDs it owns and the availability of its runtime.
+// Objects that cannot be read are reported in the details instead of failing the inspection.
+func (i *RevisionInspector) ProviderRevisionDetails(ctx context.Context, c client.Client, provider *crossplanev1.Provider) []string {
+	name := provider.Status.CurrentRevision
+	if name == "" {
+		return []string{"No ProviderRevision has been created yet."}
+	}
+
+	rev := &crossplanev1.ProviderRevision{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, rev); err != nil {
+		return []string{fmt.Sprintf("Cannot get ProviderRevision %s: %s.", name, err)}
+	}
+
+	details := revisionConditionDetails(rev)
+	if found, installed, invalid := rev.GetDependencyStatus(); found > 0 {
+		details = append(details, fmt.Sprintf("Dependencies: %d found, %d installed, %d invalid.", found, installed, invalid))
+	}
+	details = append(details, i.crdDetails(ctx, c, rev.GetObjects()))
+	return append(details, i.runtimeDetails(ctx, c, name)...)
+}
+
+// revisionConditionDetails reports the health conditions of the revision that are not true.
+// Image pull and dependency resolution errors are reported in their messages.
+func revisionConditionDetails(rev *crossplanev1.ProviderRevision) []string {
+	details := []string{}
+	for _, ct := range []xpv2.ConditionType{crossplanev1.TypeRevisionHealthy, crossplanev1.TypeRuntimeHealthy} {
+		cond := rev.GetCondition(ct)
+		if cond.Status == corev1.ConditionTrue {
+			continue
+		}
+		details = append(details, strings.TrimSpace(fmt.Sprintf("%s of ProviderRevision %s is %s (%s). %s",
+			ct, rev.Name, cond.Status, cond.Reason, cond.Message)))
+	}
+	return details
+}
+
+// crdDetails reports how many of the CRDs owned by the revision are established.
+func (i *RevisionInspector) crdDetails(ctx context.Context, c client.Client, refs []xpv2.TypedReference) string {
+	total := 0
+	pending := []string{}
+	for _, ref := range refs {
+		if ref.Kind != "CustomResourceDefinition" {
+			continue
+		}
+		total++
+		crd := &apiextensionsv1.CustomResourceDefinition{}
+		if err := c.Get(ctx, types.NamespacedName{Name: ref.Name}, crd); client.IgnoreNotFound(err) != nil {
+			return fmt.Sprintf("Cannot get CRD %s: %s.", ref.Name, err)
+		}
+		if !crdEstablished(crd) {
+			pending = append(pending, ref.Name)
+		}
+	}
+
+	details := fmt.Sprintf("%d/%d CRDs established.", total-len(pending), total)
+	if len(pending) > 0 {
+		details += fmt.Sprintf(" Not established: %s.", strings.Join(pending, ", "))
+	}
+	return details
+}
+
+func crdEstablished(crd *apiextensionsv1.CustomResourceDefinition) bool {
+	for _, cond := range crd.Status.Conditions {
+		if cond.Type == apiextensionsv1.Established {
+			return cond.Status == apiextensionsv1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// runtimeDetails reports the availability of the runtime Deployment of the revision
+// and the containers of its Pods that cannot pull their image.
+func (i *RevisionInspector) runtimeDetails(ctx context.Context, c client.Client, revision string) []string {
+	deployments, err := i.deployments.get(ctx, c, &appsv1.DeploymentList{}, client.InNamespace(i.Namespace))
+	if err != nil {
+		return []string{fmt.Sprintf("Cannot list runtime Deployments: %s.", err)}
+	}
+	idx := slices.IndexFunc(deployments.Items, func(d appsv1.Deployment) bool {
+		return d.Spec.Selector != nil && d.Spec.Selector.MatchLabels[crossplanev1.LabelRevision] == revision
+	})
+	if idx < 0 {
+		return []string{"Runtime Deployment not found."}
+	}
+
+	deployment := deployments.Items[idx]
+	desired := int32(1)
+	if deployment.Spec.Replicas != nil {
+		desired = *deployment.Spec.Replicas
+	}
+	details := []string{fmt.Sprintf("Runtime Deployment %s: %d/%d replicas available.",
+		deployment.Name, deployment.Status.AvailableReplicas, desired)}
+
+	pods, err := i.pods.get(ctx, c, &corev1.PodList{}, client.InNamespace(i.Namespace), client.HasLabels{crossplanev1.LabelRevision})
+	if err != nil {
+		return append(details, fmt.Sprintf("Cannot list runtime Pods: %s.", err))
+	}
+	for _, pod := range pods.Items {
+		if pod.Labels[crossplanev1.LabelRevision] != revision {
+			continue
+		}
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Waiting == nil || !slices.Contains(imagePullReasons, status.State.Waiting.Reason) {
+				continue
+			}
+			details = append(details, strings.TrimSpace(fmt.Sprintf("Pod %s cannot pull image %s (%s). %s",
+				pod.Name, status.Image, status.State.Waiting.Reason, status.State.Waiting.Message)))
+		}
+	}
+	return details
+}
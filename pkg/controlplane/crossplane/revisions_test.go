@@ -0,0 +1,197 @@
+package crossplane
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	xpv2 "github.com/crossplane/crossplane/apis/v2/core/v2"
+	crossplanev1 "github.com/crossplane/crossplane/apis/v2/pkg/v1"
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/openmcp-project/control-plane-operator/internal/schemes"
+)
+
+func TestProviderRevisionDetails(t *testing.T) {
+	const revision = "provider-kubernetes-abc123"
+
+	crd := func(name string, established apiextensionsv1.ConditionStatus) *apiextensionsv1.CustomResourceDefinition {
+		return &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: apiextensionsv1.CustomResourceDefinitionStatus{
+				Conditions: []apiextensionsv1.CustomResourceDefinitionCondition{
+					{Type: apiextensionsv1.Established, Status: established},
+				},
+			},
+		}
+	}
+	providerRevision := func(conditions ...xpv2.Condition) *crossplanev1.ProviderRevision {
+		rev := &crossplanev1.ProviderRevision{
+			ObjectMeta: metav1.ObjectMeta{Name: revision},
+			Status: crossplanev1.ProviderRevisionStatus{
+				PackageRevisionStatus: crossplanev1.PackageRevisionStatus{
+					ObjectRefs: []xpv2.TypedReference{
+						{APIVersion: "apiextensions.k8s.io/v1", Kind: "CustomResourceDefinition", Name: "objects.kubernetes.crossplane.io"},
+						{APIVersion: "apiextensions.k8s.io/v1", Kind: "CustomResourceDefinition", Name: "providerconfigs.kubernetes.crossplane.io"},
+						{APIVersion: "admissionregistration.k8s.io/v1", Kind: "ValidatingWebhookConfiguration", Name: "provider-kubernetes"},
+					},
+				},
+			},
+		}
+		rev.SetConditions(conditions...)
+		return rev
+	}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: revision, Namespace: "crossplane-system"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To[int32](2),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{crossplanev1.LabelRevision: revision}},
+		},
+		Status: appsv1.DeploymentStatus{AvailableReplicas: 1},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      revision + "-xyz",
+			Namespace: "crossplane-system",
+			Labels:    map[string]string{crossplanev1.LabelRevision: revision},
+		},
+		Status: corev1.PodStatus{
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Image: "xpkg.upbound.io/crossplane-contrib/provider-kubernetes:v0.15.0",
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+			}},
+		},
+	}
+
+	tt := []struct {
+		desc     string
+		revision string
+		objects  []client.Object
+		exp      []string
+	}{
+		{
+			desc: "no revision yet",
+			exp:  []string{"No ProviderRevision has been created yet."},
+		},
+		{
+			desc:     "revision not found",
+			revision: revision,
+			exp:      []string{`Cannot get ProviderRevision provider-kubernetes-abc123: providerrevisions.pkg.crossplane.io "provider-kubernetes-abc123" not found.`},
+		},
+		{
+			desc:     "healthy revision",
+			revision: revision,
+			objects: []client.Object{
+				providerRevision(crossplanev1.RevisionHealthy(), crossplanev1.RuntimeHealthy()),
+				crd("objects.kubernetes.crossplane.io", apiextensionsv1.ConditionTrue),
+				crd("providerconfigs.kubernetes.crossplane.io", apiextensionsv1.ConditionTrue),
+				&appsv1.Deployment{
+					ObjectMeta: deployment.ObjectMeta,
+					Spec:       deployment.Spec,
+					Status:     appsv1.DeploymentStatus{AvailableReplicas: 2},
+				},
+			},
+			exp: []string{
+				"2/2 CRDs established.",
+				"Runtime Deployment provider-kubernetes-abc123: 2/2 replicas available.",
+			},
+		},
+		{
+			desc:     "unhealthy revision",
+			revision: revision,
+			objects: []client.Object{
+				providerRevision(
+					crossplanev1.RevisionUnhealthy().WithMessage("cannot resolve package dependencies: incompatible dependencies"),
+					crossplanev1.RuntimeUnknownHealth(),
+				),
+				crd("objects.kubernetes.crossplane.io", apiextensionsv1.ConditionTrue),
+				crd("providerconfigs.kubernetes.crossplane.io", apiextensionsv1.ConditionFalse),
+				deployment,
+				pod,
+			},
+			exp: []string{
+				"RevisionHealthy of ProviderRevision provider-kubernetes-abc123 is False (UnhealthyPackageRevision). cannot resolve package dependencies: incompatible dependencies",
+				"RuntimeHealthy of ProviderRevision provider-kubernetes-abc123 is Unknown (UnknownPackageRevisionHealth).",
+				"1/2 CRDs established. Not established: providerconfigs.kubernetes.crossplane.io.",
+				"Runtime Deployment provider-kubernetes-abc123: 1/2 replicas available.",
+				"Pod provider-kubernetes-abc123-xyz cannot pull image xpkg.upbound.io/crossplane-contrib/provider-kubernetes:v0.15.0 (ImagePullBackOff).",
+			},
+		},
+		{
+			desc:     "runtime not found",
+			revision: revision,
+			objects: []client.Object{
+				providerRevision(crossplanev1.RevisionHealthy(), crossplanev1.RuntimeHealthy()),
+			},
+			exp: []string{
+				"0/2 CRDs established. Not established: objects.kubernetes.crossplane.io, providerconfigs.kubernetes.crossplane.io.",
+				"Runtime Deployment not found.",
+			},
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.desc, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithScheme(schemes.Remote).WithObjects(tc.objects...).Build()
+			provider := &crossplanev1.Provider{}
+			provider.Status.CurrentRevision = tc.revision
+
+			revisions := &RevisionInspector{Namespace: "crossplane-system"}
+			assert.Equal(t, tc.exp, revisions.ProviderRevisionDetails(context.Background(), c, provider))
+		})
+	}
+}
+
+func TestRevisionInspector_listsOnce(t *testing.T) {
+	revisionNames := []string{"provider-a-123", "provider-b-456"}
+	objects := []client.Object{}
+	for _, name := range revisionNames {
+		rev := &crossplanev1.ProviderRevision{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		rev.SetObjects([]xpv2.TypedReference{{APIVersion: "apiextensions.k8s.io/v1", Kind: "CustomResourceDefinition", Name: name + ".example.com"}})
+		objects = append(objects,
+			rev,
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "crossplane-system"},
+				Spec: appsv1.DeploymentSpec{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{crossplanev1.LabelRevision: name}},
+				},
+			},
+		)
+	}
+
+	lists := map[string]int{}
+	crdGets := 0
+	c := fake.NewClientBuilder().WithScheme(schemes.Remote).WithObjects(objects...).WithInterceptorFuncs(interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			lists[fmt.Sprintf("%T", list)]++
+			return c.List(ctx, list, opts...)
+		},
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if _, ok := obj.(*apiextensionsv1.CustomResourceDefinition); ok {
+				crdGets++
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+	}).Build()
+
+	revisions := &RevisionInspector{Namespace: "crossplane-system"}
+	for _, name := range revisionNames {
+		provider := &crossplanev1.Provider{}
+		provider.Status.CurrentRevision = name
+		revisions.ProviderRevisionDetails(context.Background(), c, provider)
+	}
+
+	assert.Equal(t, map[string]int{
+		"*v1.DeploymentList": 1,
+		"*v1.PodList":        1,
+	}, lists)
+	// only the CRDs owned by the revisions are read
+	assert.Equal(t, len(revisionNames), crdGets)
+}
@@ -0,0 +1,188 @@
+// Package profiles applies the defaults of a ControlPlaneProfile to the ControlPlanes that reference it.
+package profiles
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"slices"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/components/policies"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils"
+)
+
+// Get returns the ControlPlaneProfile referenced by the ControlPlane or nil if it does not exist.
+func Get(ctx context.Context, c client.Client, cp *v1beta1.ControlPlane) (*v1beta1.ControlPlaneProfile, error) {
+	if cp.Spec.CoreReference.Name == "" {
+		return nil, nil
+	}
+	profile := &v1beta1.ControlPlaneProfile{}
+	if err := c.Get(ctx, types.NamespacedName{Name: cp.Spec.CoreReference.Name}, profile); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return profile, nil
+}
+
+// CrossplanePackageRestrictionName returns the name of the CrossplanePackageRestriction for ControlPlanes of the profile.
+func CrossplanePackageRestrictionName(profile *v1beta1.ControlPlaneProfile) string {
+	if profile == nil {
+		return policies.CrossplanePackageRestrictionName
+	}
+	return cmp.Or(profile.Spec.CrossplanePackageRestriction, policies.CrossplanePackageRestrictionName)
+}
+
+// DeploymentRuntimeConfigProtection returns if DeploymentRuntimeConfigs of ControlPlanes of the profile are protected.
+// If the profile does not configure it, `defaultEnabled` is returned.
+func DeploymentRuntimeConfigProtection(profile *v1beta1.ControlPlaneProfile, defaultEnabled bool) bool {
+	if profile == nil || profile.Spec.DeploymentRuntimeConfigProtection == nil {
+		return defaultEnabled
+	}
+	return *profile.Spec.DeploymentRuntimeConfigProtection
+}
+
+// Apply merges the defaults of the profile into the spec of the ControlPlane.
+// Fields that are set in the ControlPlane take precedence. The profile may be nil.
+func Apply(profile *v1beta1.ControlPlaneProfile, cp *v1beta1.ControlPlane) error {
+	if profile == nil {
+		return nil
+	}
+	defaults := profile.Spec.DeepCopy()
+	spec := &cp.Spec
+
+	for _, ps := range defaults.PullSecrets {
+		if !slices.Contains(spec.PullSecrets, ps) {
+			spec.PullSecrets = append(spec.PullSecrets, ps)
+		}
+	}
+
+	var err error
+	if spec.Crossplane, err = mergeCrossplane(defaults.Crossplane, spec.Crossplane); err != nil {
+		return err
+	}
+	if spec.CertManager, err = mergeHelm(defaults.CertManager, spec.CertManager, func(c *v1beta1.CertManagerConfig) helmFields {
+		return helmFields{&c.Version, &c.UpgradePolicy, &c.Chart, &c.Values, &c.ValuesFrom}
+	}); err != nil {
+		return err
+	}
+	if spec.BTPServiceOperator, err = mergeHelm(defaults.BTPServiceOperator, spec.BTPServiceOperator, func(c *v1beta1.BTPServiceOperatorConfig) helmFields {
+		return helmFields{&c.Version, &c.UpgradePolicy, &c.Chart, &c.Values, &c.ValuesFrom}
+	}); err != nil {
+		return err
+	}
+	if spec.ExternalSecretsOperator, err = mergeHelm(defaults.ExternalSecretsOperator, spec.ExternalSecretsOperator, func(c *v1beta1.ExternalSecretsOperatorConfig) helmFields {
+		return helmFields{&c.Version, &c.UpgradePolicy, &c.Chart, &c.Values, &c.ValuesFrom}
+	}); err != nil {
+		return err
+	}
+	if spec.Kyverno, err = mergeHelm(defaults.Kyverno, spec.Kyverno, func(c *v1beta1.KyvernoConfig) helmFields {
+		return helmFields{&c.Version, &c.UpgradePolicy, &c.Chart, &c.Values, &c.ValuesFrom}
+	}); err != nil {
+		return err
+	}
+	if spec.Flux, err = mergeHelm(defaults.Flux, spec.Flux, func(c *v1beta1.FluxConfig) helmFields {
+		return helmFields{&c.Version, &c.UpgradePolicy, &c.Chart, &c.Values, &c.ValuesFrom}
+	}); err != nil {
+		return err
+	}
+	spec.AdditionalComponents = mergeAdditionalComponents(defaults.AdditionalComponents, spec.AdditionalComponents)
+	return nil
+}
+
+// mergeCrossplane merges the Crossplane configuration of the profile and the ControlPlane.
+// Providers, configurations, functions and ImageConfigs of the ControlPlane replace those of the profile with the same name,
+// the remaining ones of the profile are appended.
+func mergeCrossplane(d, c *v1beta1.CrossplaneConfig) (*v1beta1.CrossplaneConfig, error) {
+	if c == nil || d == nil {
+		return cmp.Or(c, d), nil
+	}
+	if _, err := mergeHelm(d, c, func(c *v1beta1.CrossplaneConfig) helmFields {
+		return helmFields{&c.Version, &c.UpgradePolicy, &c.Chart, &c.Values, &c.ValuesFrom}
+	}); err != nil {
+		return nil, err
+	}
+
+	c.Providers = mergeByName(d.Providers, c.Providers, func(p *v1beta1.CrossplaneProviderConfig) string { return p.Name })
+	c.Configurations = mergeByName(d.Configurations, c.Configurations, packageName)
+	c.Functions = mergeByName(d.Functions, c.Functions, packageName)
+	c.ImageConfigs = mergeByName(d.ImageConfigs, c.ImageConfigs, func(ic v1beta1.CrossplaneImageConfig) string { return ic.Name })
+	return c, nil
+}
+
+// helmFields points to the fields shared by the Helm-based components.
+type helmFields struct {
+	version       *string
+	upgradePolicy *v1beta1.UpgradePolicy
+	chart         **v1beta1.ChartSpec
+	values        **apiextensionsv1.JSON
+	valuesFrom    *[]v1beta1.ValuesReference
+}
+
+// mergeHelm merges the version, upgrade policy, chart and values of a Helm-based component of the profile
+// and the ControlPlane. `fields` returns the fields of the component.
+func mergeHelm[T any](d, c *T, fields func(*T) helmFields) (*T, error) {
+	if c == nil || d == nil {
+		return cmp.Or(c, d), nil
+	}
+	df, cf := fields(d), fields(c)
+	*cf.version = cmp.Or(*cf.version, *df.version)
+	*cf.upgradePolicy = cmp.Or(*cf.upgradePolicy, *df.upgradePolicy)
+	*cf.chart = cmp.Or(*cf.chart, *df.chart)
+
+	var err error
+	if *cf.values, err = mergeValues(*df.values, *cf.values); err != nil {
+		return nil, err
+	}
+	*cf.valuesFrom = append(*df.valuesFrom, *cf.valuesFrom...)
+	return c, nil
+}
+
+// mergeAdditionalComponents appends the additional components of the profile
+// that are not replaced by an additional component of the ControlPlane with the same name.
+func mergeAdditionalComponents(d, c []v1beta1.AdditionalComponentConfig) []v1beta1.AdditionalComponentConfig {
+	return mergeByName(d, c, func(ac v1beta1.AdditionalComponentConfig) string { return ac.Name })
+}
+
+// mergeByName appends the entries of the profile whose name is not configured in the ControlPlane.
+func mergeByName[T any](d, c []T, name func(T) string) []T {
+	for _, dc := range d {
+		overridden := slices.ContainsFunc(c, func(cc T) bool {
+			return name(cc) == name(dc)
+		})
+		if !overridden {
+			c = append(c, dc)
+		}
+	}
+	return c
+}
+
+func packageName(p *v1beta1.CrossplanePackageConfig) string {
+	return p.Name
+}
+
+// mergeValues deep merges the Helm values of the profile and the ControlPlane, the ControlPlane takes precedence.
+func mergeValues(d, c *apiextensionsv1.JSON) (*apiextensionsv1.JSON, error) {
+	if c == nil || d == nil {
+		return cmp.Or(c, d), nil
+	}
+
+	defaults := map[string]any{}
+	if err := json.Unmarshal(d.Raw, &defaults); err != nil {
+		return nil, err
+	}
+	values := map[string]any{}
+	if err := json.Unmarshal(c.Raw, &values); err != nil {
+		return nil, err
+	}
+
+	encoded, err := json.Marshal(utils.MergeMaps(defaults, values))
+	return &apiextensionsv1.JSON{Raw: encoded}, err
+}
@@ -0,0 +1,183 @@
+package profiles
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/internal/schemes"
+	"github.com/openmcp-project/control-plane-operator/pkg/controlplane/components/policies"
+)
+
+func TestGet(t *testing.T) {
+	profile := &v1beta1.ControlPlaneProfile{ObjectMeta: metav1.ObjectMeta{Name: "default"}}
+	c := fake.NewClientBuilder().WithScheme(schemes.Local).WithObjects(profile).Build()
+
+	cp := &v1beta1.ControlPlane{}
+	cp.Spec.CoreReference.Name = "default"
+	got, err := Get(context.Background(), c, cp)
+	require.NoError(t, err)
+	assert.Equal(t, "default", got.Name)
+
+	cp.Spec.CoreReference.Name = "missing"
+	got, err = Get(context.Background(), c, cp)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+
+	cp.Spec.CoreReference.Name = ""
+	got, err = Get(context.Background(), c, cp)
+	require.NoError(t, err)
+	assert.Nil(t, got)
+}
+
+func TestCrossplanePackageRestrictionName(t *testing.T) {
+	assert.Equal(t, policies.CrossplanePackageRestrictionName, CrossplanePackageRestrictionName(nil))
+	assert.Equal(t, policies.CrossplanePackageRestrictionName, CrossplanePackageRestrictionName(&v1beta1.ControlPlaneProfile{}))
+	assert.Equal(t, "strict", CrossplanePackageRestrictionName(&v1beta1.ControlPlaneProfile{
+		Spec: v1beta1.ControlPlaneProfileSpec{CrossplanePackageRestriction: "strict"},
+	}))
+}
+
+func TestDeploymentRuntimeConfigProtection(t *testing.T) {
+	assert.True(t, DeploymentRuntimeConfigProtection(nil, true))
+	assert.False(t, DeploymentRuntimeConfigProtection(&v1beta1.ControlPlaneProfile{}, false))
+	assert.False(t, DeploymentRuntimeConfigProtection(&v1beta1.ControlPlaneProfile{
+		Spec: v1beta1.ControlPlaneProfileSpec{DeploymentRuntimeConfigProtection: ptr.To(false)},
+	}, true))
+}
+
+func TestApply(t *testing.T) {
+	profile := &v1beta1.ControlPlaneProfile{
+		Spec: v1beta1.ControlPlaneProfileSpec{
+			PullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+			ComponentsConfig: v1beta1.ComponentsConfig{
+				Crossplane: &v1beta1.CrossplaneConfig{
+					Version:       "1.16.0",
+					UpgradePolicy: v1beta1.UpgradePolicyPatch,
+					Values:        &apiextensionsv1.JSON{Raw: []byte(`{"replicas":2,"resources":{"cpu":"100m"}}`)},
+					Providers: []*v1beta1.CrossplaneProviderConfig{
+						{Name: "provider-helm", Version: "0.19.0"},
+						{Name: "provider-kubernetes", Version: "0.13.0"},
+					},
+					Functions: []*v1beta1.CrossplanePackageConfig{
+						{Name: "function-auto-ready", Version: "0.2.1"},
+						{Name: "function-patch-and-transform", Version: "0.7.0"},
+					},
+					ImageConfigs: []v1beta1.CrossplaneImageConfig{
+						{Name: "mirror", MatchPrefixes: []string{"xpkg.upbound.io"}, RewritePrefix: "registry.example.com/upbound"},
+					},
+				},
+				CertManager: &v1beta1.CertManagerConfig{Version: "1.16.1"},
+				Flux:        &v1beta1.FluxConfig{Version: "2.4.0"},
+				AdditionalComponents: []v1beta1.AdditionalComponentConfig{
+					{Name: "metrics-server", Version: "3.12.0", TargetNamespace: "kube-system"},
+					{Name: "reloader", Version: "1.0.0", TargetNamespace: "reloader"},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name  string
+		spec  v1beta1.ControlPlaneSpec
+		check func(t *testing.T, spec v1beta1.ControlPlaneSpec)
+	}{
+		{
+			name: "components of the profile are added",
+			check: func(t *testing.T, spec v1beta1.ControlPlaneSpec) {
+				assert.Equal(t, profile.Spec.Crossplane, spec.Crossplane)
+				assert.Equal(t, profile.Spec.CertManager, spec.CertManager)
+				assert.Nil(t, spec.Kyverno)
+				assert.Equal(t, []corev1.LocalObjectReference{{Name: "registry"}}, spec.PullSecrets)
+				assert.Equal(t, profile.Spec.AdditionalComponents, spec.AdditionalComponents)
+			},
+		},
+		{
+			name: "ControlPlane takes precedence",
+			spec: v1beta1.ControlPlaneSpec{
+				PullSecrets: []corev1.LocalObjectReference{{Name: "registry"}, {Name: "other"}},
+				ComponentsConfig: v1beta1.ComponentsConfig{
+					Crossplane: &v1beta1.CrossplaneConfig{
+						Values:     &apiextensionsv1.JSON{Raw: []byte(`{"resources":{"memory":"1Gi"}}`)},
+						ValuesFrom: []v1beta1.ValuesReference{{Kind: "Secret", Name: "crossplane", Namespace: "cp-test"}},
+						Providers:  []*v1beta1.CrossplaneProviderConfig{{Name: "provider-helm", Version: "0.20.0"}},
+						Configurations: []*v1beta1.CrossplanePackageConfig{
+							{Name: "configuration-platform-ref", Version: "1.0.0"},
+						},
+						Functions: []*v1beta1.CrossplanePackageConfig{
+							{Name: "function-patch-and-transform", Version: "0.8.0"},
+						},
+						ImageConfigs: []v1beta1.CrossplaneImageConfig{
+							{Name: "private", MatchPrefixes: []string{"registry.example.com/private"}, PullSecretRef: &corev1.LocalObjectReference{Name: "private"}},
+						},
+					},
+					CertManager: &v1beta1.CertManagerConfig{Version: "1.17.0", UpgradePolicy: v1beta1.UpgradePolicyManual},
+					AdditionalComponents: []v1beta1.AdditionalComponentConfig{
+						{Name: "reloader", Version: "1.1.0", TargetNamespace: "reloader"},
+					},
+				},
+			},
+			check: func(t *testing.T, spec v1beta1.ControlPlaneSpec) {
+				assert.Equal(t, []corev1.LocalObjectReference{{Name: "registry"}, {Name: "other"}}, spec.PullSecrets)
+				assert.Equal(t, "1.16.0", spec.Crossplane.Version)
+				assert.Equal(t, v1beta1.UpgradePolicyPatch, spec.Crossplane.UpgradePolicy)
+				assert.JSONEq(t, `{"replicas":2,"resources":{"cpu":"100m","memory":"1Gi"}}`, string(spec.Crossplane.Values.Raw))
+				assert.Equal(t, []*v1beta1.CrossplaneProviderConfig{
+					{Name: "provider-helm", Version: "0.20.0"},
+					{Name: "provider-kubernetes", Version: "0.13.0"},
+				}, spec.Crossplane.Providers)
+				assert.Equal(t, []*v1beta1.CrossplanePackageConfig{
+					{Name: "configuration-platform-ref", Version: "1.0.0"},
+				}, spec.Crossplane.Configurations)
+				assert.Equal(t, []*v1beta1.CrossplanePackageConfig{
+					{Name: "function-patch-and-transform", Version: "0.8.0"},
+					{Name: "function-auto-ready", Version: "0.2.1"},
+				}, spec.Crossplane.Functions)
+				assert.Equal(t, []v1beta1.CrossplaneImageConfig{
+					{Name: "private", MatchPrefixes: []string{"registry.example.com/private"}, PullSecretRef: &corev1.LocalObjectReference{Name: "private"}},
+					{Name: "mirror", MatchPrefixes: []string{"xpkg.upbound.io"}, RewritePrefix: "registry.example.com/upbound"},
+				}, spec.Crossplane.ImageConfigs)
+				assert.Equal(t, "1.17.0", spec.CertManager.Version)
+				assert.Equal(t, v1beta1.UpgradePolicyManual, spec.CertManager.UpgradePolicy)
+				assert.Equal(t, "2.4.0", spec.Flux.Version)
+				assert.Equal(t, []v1beta1.AdditionalComponentConfig{
+					{Name: "reloader", Version: "1.1.0", TargetNamespace: "reloader"},
+					{Name: "metrics-server", Version: "3.12.0", TargetNamespace: "kube-system"},
+				}, spec.AdditionalComponents)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp := &v1beta1.ControlPlane{Spec: tt.spec}
+			require.NoError(t, Apply(profile, cp))
+			tt.check(t, cp.Spec)
+
+			// the profile must not be modified
+			cp.Spec.Crossplane.Version = "changed"
+			assert.Equal(t, "1.16.0", profile.Spec.Crossplane.Version)
+		})
+	}
+}
+
+func TestApply_InvalidValues(t *testing.T) {
+	profile := &v1beta1.ControlPlaneProfile{
+		Spec: v1beta1.ControlPlaneProfileSpec{
+			ComponentsConfig: v1beta1.ComponentsConfig{
+				Kyverno: &v1beta1.KyvernoConfig{Values: &apiextensionsv1.JSON{Raw: []byte(`{"replicas":2}`)}},
+			},
+		},
+	}
+	cp := &v1beta1.ControlPlane{}
+	cp.Spec.Kyverno = &v1beta1.KyvernoConfig{Version: "3.2.0", Values: &apiextensionsv1.JSON{Raw: []byte(`[]`)}}
+	assert.Error(t, Apply(profile, cp))
+	assert.NoError(t, Apply(nil, cp))
+}
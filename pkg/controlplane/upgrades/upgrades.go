@@ -0,0 +1,125 @@
+package upgrades
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+)
+
+var (
+	ErrInvalidWeekday  = errors.New("invalid weekday")
+	ErrInvalidTime     = errors.New("invalid time, expected HH:MM")
+	ErrInvalidTimeZone = errors.New("invalid time zone")
+)
+
+var weekdays = map[string]time.Weekday{
+	"Sunday":    time.Sunday,
+	"Monday":    time.Monday,
+	"Tuesday":   time.Tuesday,
+	"Wednesday": time.Wednesday,
+	"Thursday":  time.Thursday,
+	"Friday":    time.Friday,
+	"Saturday":  time.Saturday,
+}
+
+// InMaintenanceWindow returns if `now` is within one of the maintenance windows.
+// It always returns true if no maintenance windows are configured.
+func InMaintenanceWindow(windows []v1beta1.MaintenanceWindow, now time.Time) (bool, error) {
+	if len(windows) == 0 {
+		return true, nil
+	}
+
+	var errs error
+	for _, w := range windows {
+		in, err := Contains(w, now)
+		if err != nil {
+			errs = errors.Join(errs, err)
+			continue
+		}
+		if in {
+			return true, nil
+		}
+	}
+	return false, errs
+}
+
+// Contains returns if `now` is within the maintenance window.
+func Contains(w v1beta1.MaintenanceWindow, now time.Time) (bool, error) {
+	if err := Validate(w); err != nil {
+		return false, err
+	}
+	loc, _ := location(w.TimeZone)
+	start, _ := time.Parse("15:04", w.Start)
+	end, _ := time.Parse("15:04", w.End)
+
+	now = now.In(loc)
+	// a window that started on the previous day may still be open
+	for _, offset := range []int{0, -1} {
+		day := now.AddDate(0, 0, offset)
+		if day.Weekday() != weekdays[w.Weekday] {
+			continue
+		}
+		from := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, loc)
+		to := time.Date(day.Year(), day.Month(), day.Day(), end.Hour(), end.Minute(), 0, 0, loc)
+		if !to.After(from) {
+			to = to.AddDate(0, 0, 1)
+		}
+		if !now.Before(from) && now.Before(to) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Validate returns an error if the weekday, the times or the time zone of the maintenance window are invalid.
+func Validate(w v1beta1.MaintenanceWindow) error {
+	if _, ok := weekdays[w.Weekday]; !ok {
+		return fmt.Errorf("%w: %s", ErrInvalidWeekday, w.Weekday)
+	}
+	for _, t := range []string{w.Start, w.End} {
+		if _, err := time.Parse("15:04", t); err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidTime, t)
+		}
+	}
+	if _, err := location(w.TimeZone); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidTimeZone, err)
+	}
+	return nil
+}
+
+func location(timeZone string) (*time.Location, error) {
+	if timeZone == "" {
+		return time.UTC, nil
+	}
+	return time.LoadLocation(timeZone)
+}
+
+// NextVersion returns the newest of the available versions the current version may be upgraded to by the policy.
+// The current version is returned if there is no newer version or the policy does not allow upgrades.
+// Prereleases and versions that are not valid semantic versions are never selected.
+func NextVersion(policy v1beta1.UpgradePolicy, current string, available []string) string {
+	if policy != v1beta1.UpgradePolicyPatch && policy != v1beta1.UpgradePolicyMinor {
+		return current
+	}
+	cur, err := semver.NewVersion(current)
+	if err != nil {
+		return current
+	}
+
+	next, highest := current, cur
+	for _, version := range available {
+		v, err := semver.NewVersion(version)
+		if err != nil || v.Prerelease() != "" || !v.GreaterThan(highest) {
+			continue
+		}
+		if v.Major() != cur.Major() || (policy == v1beta1.UpgradePolicyPatch && v.Minor() != cur.Minor()) {
+			continue
+		}
+		next, highest = version, v
+	}
+	return next
+}
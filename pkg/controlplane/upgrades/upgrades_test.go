@@ -0,0 +1,117 @@
+package upgrades
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+)
+
+func TestInMaintenanceWindow(t *testing.T) {
+	// 2024-01-01 is a Monday
+	monday := func(hour, minute int) time.Time {
+		return time.Date(2024, 1, 1, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name    string
+		windows []v1beta1.MaintenanceWindow
+		now     time.Time
+		want    bool
+		wantErr error
+	}{
+		{
+			name: "no windows",
+			now:  monday(12, 0),
+			want: true,
+		},
+		{
+			name:    "within window",
+			windows: []v1beta1.MaintenanceWindow{{Weekday: "Monday", Start: "10:00", End: "14:00"}},
+			now:     monday(12, 0),
+			want:    true,
+		},
+		{
+			name:    "end is exclusive",
+			windows: []v1beta1.MaintenanceWindow{{Weekday: "Monday", Start: "10:00", End: "14:00"}},
+			now:     monday(14, 0),
+			want:    false,
+		},
+		{
+			name:    "other weekday",
+			windows: []v1beta1.MaintenanceWindow{{Weekday: "Tuesday", Start: "10:00", End: "14:00"}},
+			now:     monday(12, 0),
+			want:    false,
+		},
+		{
+			name:    "window of the previous day ends after midnight",
+			windows: []v1beta1.MaintenanceWindow{{Weekday: "Sunday", Start: "22:00", End: "02:00"}},
+			now:     monday(1, 0),
+			want:    true,
+		},
+		{
+			name:    "time zone",
+			windows: []v1beta1.MaintenanceWindow{{Weekday: "Monday", Start: "10:00", End: "11:00", TimeZone: "Europe/Berlin"}},
+			now:     monday(9, 30),
+			want:    true,
+		},
+		{
+			name: "any window matches",
+			windows: []v1beta1.MaintenanceWindow{
+				{Weekday: "Saturday", Start: "00:00", End: "00:00"},
+				{Weekday: "Monday", Start: "00:00", End: "06:00"},
+			},
+			now:  monday(5, 59),
+			want: true,
+		},
+		{
+			name:    "invalid time zone",
+			windows: []v1beta1.MaintenanceWindow{{Weekday: "Monday", Start: "10:00", End: "14:00", TimeZone: "Mars/Olympus"}},
+			now:     monday(12, 0),
+			want:    false,
+			wantErr: ErrInvalidTimeZone,
+		},
+		{
+			name:    "invalid weekday",
+			windows: []v1beta1.MaintenanceWindow{{Weekday: "Someday", Start: "10:00", End: "14:00"}},
+			now:     monday(12, 0),
+			want:    false,
+			wantErr: ErrInvalidWeekday,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := InMaintenanceWindow(tt.windows, tt.now)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNextVersion(t *testing.T) {
+	available := []string{"1.15.0", "1.15.3", "1.16.0", "1.16.2", "1.17.0-rc.1", "2.0.0", "invalid"}
+	tests := []struct {
+		name    string
+		policy  v1beta1.UpgradePolicy
+		current string
+		want    string
+	}{
+		{name: "manual", policy: v1beta1.UpgradePolicyManual, current: "1.15.0", want: "1.15.0"},
+		{name: "no policy", policy: "", current: "1.15.0", want: "1.15.0"},
+		{name: "patch", policy: v1beta1.UpgradePolicyPatch, current: "1.15.0", want: "1.15.3"},
+		{name: "minor", policy: v1beta1.UpgradePolicyMinor, current: "1.15.0", want: "1.16.2"},
+		{name: "already newest", policy: v1beta1.UpgradePolicyMinor, current: "2.0.0", want: "2.0.0"},
+		{name: "current is not available", policy: v1beta1.UpgradePolicyPatch, current: "1.16.1", want: "1.16.2"},
+		{name: "invalid current version", policy: v1beta1.UpgradePolicyMinor, current: "invalid", want: "invalid"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NextVersion(tt.policy, tt.current, available))
+		})
+	}
+}
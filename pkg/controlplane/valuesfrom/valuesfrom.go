@@ -0,0 +1,235 @@
+// Package valuesfrom copies the ConfigMaps and Secrets referenced in the `valuesFrom` of the components
+// into the namespace of the ControlPlane, where they are read by the HelmReleases.
+package valuesfrom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	"github.com/fluxcd/pkg/apis/meta"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/pkg/constants"
+	"github.com/openmcp-project/control-plane-operator/pkg/utils"
+)
+
+const (
+	KindConfigMap = "ConfigMap"
+	KindSecret    = "Secret"
+)
+
+var (
+	ErrUnsupportedKind     = errors.New("unsupported kind of values reference")
+	ErrNamespaceNotAllowed = errors.New("namespace of values reference is not allowed")
+)
+
+// IsNamespaceAllowed returns true if referents may be read from the namespace.
+// Only the namespaces configured for the operator are allowed, so that a ControlPlane cannot
+// copy arbitrary Secrets of the core cluster into its target cluster.
+func IsNamespaceAllowed(allowedNamespaces []string, namespace string) bool {
+	return slices.Contains(allowedNamespaces, namespace)
+}
+
+// References returns the `valuesFrom` references of all components of the ControlPlane.
+func References(cp *v1beta1.ControlPlane) []v1beta1.ValuesReference {
+	refs := ComponentsReferences(&cp.Spec.ComponentsConfig)
+	if c := cp.Spec.Telemetry; c != nil {
+		refs = append(refs, c.ValuesFrom...)
+	}
+	return refs
+}
+
+// ComponentsReferences returns the `valuesFrom` references of the components, e.g. of a ControlPlaneProfile.
+func ComponentsReferences(cc *v1beta1.ComponentsConfig) []v1beta1.ValuesReference {
+	var refs []v1beta1.ValuesReference
+	if c := cc.Crossplane; c != nil {
+		refs = append(refs, c.ValuesFrom...)
+	}
+	if c := cc.CertManager; c != nil {
+		refs = append(refs, c.ValuesFrom...)
+	}
+	if c := cc.BTPServiceOperator; c != nil {
+		refs = append(refs, c.ValuesFrom...)
+	}
+	if c := cc.ExternalSecretsOperator; c != nil {
+		refs = append(refs, c.ValuesFrom...)
+	}
+	if c := cc.Kyverno; c != nil {
+		refs = append(refs, c.ValuesFrom...)
+	}
+	if c := cc.Flux; c != nil {
+		refs = append(refs, c.ValuesFrom...)
+	}
+	for _, c := range cc.AdditionalComponents {
+		refs = append(refs, c.ValuesFrom...)
+	}
+	return refs
+}
+
+// IndexKey returns the key of a referent in a field index of the objects that reference it.
+func IndexKey(kind string, key types.NamespacedName) string {
+	return kind + "/" + key.Namespace + "/" + key.Name
+}
+
+// IndexKeys returns the distinct index keys of the referents.
+func IndexKeys(refs []v1beta1.ValuesReference) []string {
+	keys := []string{}
+	for _, ref := range refs {
+		key := IndexKey(ref.Kind, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace})
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// IsReferenced returns true if a component of the ControlPlane references the ConfigMap or Secret.
+func IsReferenced(cp *v1beta1.ControlPlane, kind string, key types.NamespacedName) bool {
+	return slices.ContainsFunc(References(cp), func(ref v1beta1.ValuesReference) bool {
+		return ref.Kind == kind && ref.Name == key.Name && ref.Namespace == key.Namespace
+	})
+}
+
+// CopyName returns the name of the copy of the referent in the namespace of the ControlPlane.
+// Namespaces must not contain dots, so the name is unique for every referent.
+func CopyName(ref v1beta1.ValuesReference) string {
+	return ref.Namespace + "." + ref.Name
+}
+
+// HelmReferences converts the references to references to their copies, which can be used in a HelmRelease.
+func HelmReferences(refs []v1beta1.ValuesReference) []helmv2.ValuesReference {
+	if len(refs) == 0 {
+		return nil
+	}
+	result := make([]helmv2.ValuesReference, 0, len(refs))
+	for _, ref := range refs {
+		result = append(result, helmv2.ValuesReference{
+			Kind:       ref.Kind,
+			Name:       CopyName(ref),
+			ValuesKey:  ref.ValuesKey,
+			TargetPath: ref.TargetPath,
+			Optional:   ref.Optional,
+		})
+	}
+	return result
+}
+
+// Sync copies the referents of all components of the ControlPlane into the namespace and deletes copies that are
+// no longer referenced. Missing referents are skipped if the reference is optional. Referents in namespaces that
+// are not in `allowedNamespaces` are not copied and fail the sync.
+// The copies are labeled to be watched by Flux, so that a change of the values upgrades the HelmRelease.
+func Sync(ctx context.Context, c client.Client, cp *v1beta1.ControlPlane, namespace string, allowedNamespaces []string) error {
+	copies := map[string]bool{}
+	for _, ref := range References(cp) {
+		if !IsNamespaceAllowed(allowedNamespaces, ref.Namespace) {
+			return fmt.Errorf("failed to copy %s %s/%s: %w", ref.Kind, ref.Namespace, ref.Name, ErrNamespaceNotAllowed)
+		}
+		found, err := copyReferent(ctx, c, ref, namespace)
+		if err != nil {
+			return fmt.Errorf("failed to copy %s %s/%s: %w", ref.Kind, ref.Namespace, ref.Name, err)
+		}
+		if found {
+			copies[ref.Kind+"/"+CopyName(ref)] = true
+		}
+	}
+
+	configMaps := &corev1.ConfigMapList{}
+	if err := c.List(ctx, configMaps, client.InNamespace(namespace), client.MatchingLabels{constants.LabelValuesFrom: "true"}); err != nil {
+		return err
+	}
+	for _, cm := range configMaps.Items {
+		if err := deleteUnreferenced(ctx, c, &cm, copies[KindConfigMap+"/"+cm.Name]); err != nil {
+			return err
+		}
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := c.List(ctx, secrets, client.InNamespace(namespace), client.MatchingLabels{constants.LabelValuesFrom: "true"}); err != nil {
+		return err
+	}
+	for _, s := range secrets.Items {
+		if err := deleteUnreferenced(ctx, c, &s, copies[KindSecret+"/"+s.Name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyReferent copies the referent into the namespace. It returns false if the referent does not exist
+// and the reference is optional.
+func copyReferent(ctx context.Context, c client.Client, ref v1beta1.ValuesReference, namespace string) (bool, error) {
+	key := types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}
+	objMeta := metav1.ObjectMeta{Name: CopyName(ref), Namespace: namespace}
+
+	var src, dst client.Object
+	var mutate func()
+	switch ref.Kind {
+	case KindConfigMap:
+		srcCM, dstCM := &corev1.ConfigMap{}, &corev1.ConfigMap{ObjectMeta: objMeta}
+		src, dst = srcCM, dstCM
+		mutate = func() {
+			dstCM.Data = srcCM.Data
+			dstCM.BinaryData = srcCM.BinaryData
+		}
+	case KindSecret:
+		srcSecret, dstSecret := &corev1.Secret{}, &corev1.Secret{ObjectMeta: objMeta}
+		src, dst = srcSecret, dstSecret
+		mutate = func() {
+			dstSecret.Type = srcSecret.Type
+			dstSecret.Data = srcSecret.Data
+		}
+	default:
+		return false, ErrUnsupportedKind
+	}
+
+	if err := c.Get(ctx, key, src); err != nil {
+		if apierrors.IsNotFound(err) && ref.Optional {
+			return false, nil
+		}
+		return false, err
+	}
+
+	// the type of a Secret is immutable, so a copy with another type is recreated
+	if srcSecret, ok := src.(*corev1.Secret); ok {
+		if err := deleteOnTypeChange(ctx, c, client.ObjectKeyFromObject(dst), srcSecret.Type); err != nil {
+			return false, err
+		}
+	}
+
+	_, err := controllerutil.CreateOrUpdate(ctx, c, dst, func() error {
+		mutate()
+		utils.SetLabel(dst, constants.LabelValuesFrom, "true")
+		utils.SetLabel(dst, meta.LabelKeyWatch, meta.LabelValueWatchEnabled)
+		utils.SetManagedBy(dst)
+		return nil
+	})
+	return true, err
+}
+
+// deleteOnTypeChange deletes the Secret if it exists with a type other than `secretType`.
+func deleteOnTypeChange(ctx context.Context, c client.Client, key types.NamespacedName, secretType corev1.SecretType) error {
+	existing := &corev1.Secret{}
+	if err := c.Get(ctx, key, existing); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if existing.Type == secretType {
+		return nil
+	}
+	return client.IgnoreNotFound(c.Delete(ctx, existing))
+}
+
+func deleteUnreferenced(ctx context.Context, c client.Client, obj client.Object, referenced bool) error {
+	if referenced {
+		return nil
+	}
+	return client.IgnoreNotFound(c.Delete(ctx, obj))
+}
@@ -0,0 +1,150 @@
+package valuesfrom
+
+import (
+	"context"
+	"testing"
+
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	"github.com/fluxcd/pkg/apis/meta"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+	"github.com/openmcp-project/control-plane-operator/internal/schemes"
+	"github.com/openmcp-project/control-plane-operator/pkg/constants"
+)
+
+func Test_HelmReferences(t *testing.T) {
+	assert.Nil(t, HelmReferences(nil))
+	assert.Equal(t, []helmv2.ValuesReference{
+		{Kind: "Secret", Name: "default.btp", ValuesKey: "clientSecret", TargetPath: "manager.secret.clientsecret"},
+		{Kind: "ConfigMap", Name: "shared.values", Optional: true},
+	}, HelmReferences([]v1beta1.ValuesReference{
+		{Kind: "Secret", Name: "btp", Namespace: "default", ValuesKey: "clientSecret", TargetPath: "manager.secret.clientsecret"},
+		{Kind: "ConfigMap", Name: "values", Namespace: "shared", Optional: true},
+	}))
+}
+
+func Test_IsReferenced(t *testing.T) {
+	cp := &v1beta1.ControlPlane{}
+	cp.Spec.Crossplane = &v1beta1.CrossplaneConfig{ValuesFrom: []v1beta1.ValuesReference{{Kind: "ConfigMap", Name: "values", Namespace: "default"}}}
+	cp.Spec.Telemetry = &v1beta1.TelemetryConfig{ValuesFrom: []v1beta1.ValuesReference{{Kind: "Secret", Name: "token", Namespace: "default"}}}
+
+	assert.True(t, IsReferenced(cp, KindConfigMap, types.NamespacedName{Name: "values", Namespace: "default"}))
+	assert.True(t, IsReferenced(cp, KindSecret, types.NamespacedName{Name: "token", Namespace: "default"}))
+	assert.False(t, IsReferenced(cp, KindSecret, types.NamespacedName{Name: "values", Namespace: "default"}))
+	assert.False(t, IsReferenced(cp, KindConfigMap, types.NamespacedName{Name: "values", Namespace: "other"}))
+}
+
+func Test_IndexKeys(t *testing.T) {
+	assert.Equal(t, []string{"ConfigMap/default/values", "Secret/default/values"}, IndexKeys([]v1beta1.ValuesReference{
+		{Kind: "ConfigMap", Name: "values", Namespace: "default"},
+		{Kind: "Secret", Name: "values", Namespace: "default"},
+		{Kind: "ConfigMap", Name: "values", Namespace: "default", ValuesKey: "other.yaml"},
+	}))
+	assert.Equal(t, "Secret/default/token", IndexKey(KindSecret, types.NamespacedName{Name: "token", Namespace: "default"}))
+}
+
+func Test_Sync(t *testing.T) {
+	ctx := context.Background()
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "btp", Namespace: "default"},
+		Data:       map[string][]byte{"values.yaml": []byte("manager:\n  secret: {}\n")},
+	}
+	sourceCM := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "values", Namespace: "default"},
+		Data:       map[string]string{"values.yaml": "replicas: 2\n"},
+	}
+	stale := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "default.removed",
+			Namespace: "cp-test",
+			Labels:    map[string]string{constants.LabelValuesFrom: "true"},
+		},
+	}
+	unrelated := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "unrelated", Namespace: "cp-test"}}
+	c := fake.NewClientBuilder().WithScheme(schemes.Local).WithObjects(source, sourceCM, stale, unrelated).Build()
+
+	cp := &v1beta1.ControlPlane{}
+	cp.Spec.BTPServiceOperator = &v1beta1.BTPServiceOperatorConfig{ValuesFrom: []v1beta1.ValuesReference{
+		{Kind: KindSecret, Name: "btp", Namespace: "default"},
+		{Kind: KindSecret, Name: "missing", Namespace: "default", Optional: true},
+	}}
+	cp.Spec.Kyverno = &v1beta1.KyvernoConfig{ValuesFrom: []v1beta1.ValuesReference{
+		{Kind: KindConfigMap, Name: "values", Namespace: "default"},
+	}}
+	require.NoError(t, Sync(ctx, c, cp, "cp-test", []string{"default"}))
+
+	copied := &corev1.Secret{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "default.btp", Namespace: "cp-test"}, copied))
+	assert.Equal(t, source.Data, copied.Data)
+	assert.Equal(t, meta.LabelValueWatchEnabled, copied.Labels[meta.LabelKeyWatch])
+
+	copiedCM := &corev1.ConfigMap{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "default.values", Namespace: "cp-test"}, copiedCM))
+	assert.Equal(t, sourceCM.Data, copiedCM.Data)
+
+	err := c.Get(ctx, client.ObjectKeyFromObject(stale), &corev1.ConfigMap{})
+	assert.True(t, apierrors.IsNotFound(err), "stale copy has not been deleted")
+	assert.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(unrelated), &corev1.ConfigMap{}))
+
+	// updates of the source are copied
+	source.Data = map[string][]byte{"values.yaml": []byte("manager:\n  replica_count: 2\n")}
+	require.NoError(t, c.Update(ctx, source))
+	require.NoError(t, Sync(ctx, c, cp, "cp-test", []string{"default"}))
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "default.btp", Namespace: "cp-test"}, copied))
+	assert.Equal(t, source.Data, copied.Data)
+
+	// missing references that are not optional fail
+	cp.Spec.BTPServiceOperator.ValuesFrom[1].Optional = false
+	assert.Error(t, Sync(ctx, c, cp, "cp-test", []string{"default"}))
+
+	// references to namespaces that are not allowed fail
+	cp.Spec.BTPServiceOperator.ValuesFrom = []v1beta1.ValuesReference{{Kind: KindSecret, Name: "btp", Namespace: "default"}}
+	assert.ErrorIs(t, Sync(ctx, c, cp, "cp-test", []string{"shared"}), ErrNamespaceNotAllowed)
+}
+
+func Test_Sync_SecretTypeChanged(t *testing.T) {
+	ctx := context.Background()
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "btp", Namespace: "default"},
+		Type:       corev1.SecretTypeOpaque,
+		Data:       map[string][]byte{"values.yaml": []byte("manager: {}\n")},
+	}
+	c := fake.NewClientBuilder().WithScheme(schemes.Local).WithObjects(source).WithInterceptorFuncs(interceptor.Funcs{
+		// the type of a Secret is immutable
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if s, ok := obj.(*corev1.Secret); ok {
+				existing := &corev1.Secret{}
+				if err := c.Get(ctx, client.ObjectKeyFromObject(s), existing); err == nil && existing.Type != s.Type {
+					return apierrors.NewInvalid(s.GroupVersionKind().GroupKind(), s.Name, nil)
+				}
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+	}).Build()
+
+	cp := &v1beta1.ControlPlane{}
+	cp.Spec.BTPServiceOperator = &v1beta1.BTPServiceOperatorConfig{ValuesFrom: []v1beta1.ValuesReference{
+		{Kind: KindSecret, Name: "btp", Namespace: "default"},
+	}}
+	require.NoError(t, Sync(ctx, c, cp, "cp-test", []string{"default"}))
+
+	source.Type = "example.com/values"
+	require.NoError(t, c.Delete(ctx, source))
+	source.ResourceVersion = ""
+	require.NoError(t, c.Create(ctx, source))
+	require.NoError(t, Sync(ctx, c, cp, "cp-test", []string{"default"}))
+
+	copied := &corev1.Secret{}
+	require.NoError(t, c.Get(ctx, client.ObjectKey{Name: "default.btp", Namespace: "cp-test"}, copied))
+	assert.Equal(t, source.Type, copied.Type)
+	assert.Equal(t, source.Data, copied.Data)
+}
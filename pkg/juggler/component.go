@@ -3,8 +3,10 @@ package juggler
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -56,11 +58,42 @@ type KeepOnUninstall interface {
 	KeepOnUninstall() bool
 }
 
+// DependencyMatcher can be implemented by components of which several instances may be registered,
+// e.g. user-defined components. By default, a dependency matches all registered components of its type.
+type DependencyMatcher interface {
+	// MatchesDependency returns true if the registered component (of the same type) is the dependency.
+	MatchesDependency(registered Component) bool
+}
+
+// isDependency returns true if the registered component satisfies the dependency.
+func isDependency(registered, dependency Component) bool {
+	if reflect.TypeOf(registered) != reflect.TypeOf(dependency) {
+		return false
+	}
+	if dm, ok := dependency.(DependencyMatcher); ok {
+		return dm.MatchesDependency(registered)
+	}
+	return true
+}
+
 // GetAvailableVersions can be implemented by components that need a release-channel version lookup.
 type GetAvailableVersions interface {
 	GetAvailableVersions(ctx context.Context) ([]string, error)
 }
 
+// VersionedComponent can be implemented by components that are installed in a configurable version.
+type VersionedComponent interface {
+	// GetVersion returns the desired version of the component.
+	GetVersion() string
+}
+
+// VersionResolvingComponent can be implemented by versioned components whose desired version may be a
+// constraint (e.g. `~1.16`) that is resolved to a concrete version.
+type VersionResolvingComponent interface {
+	// GetResolvedVersion returns the concrete version the desired version resolves to.
+	GetResolvedVersion(ctx context.Context) (string, error)
+}
+
 // ComponentStatus indicates the status of a component.
 type ComponentStatus struct {
 	Name       string
@@ -68,50 +101,83 @@ type ComponentStatus struct {
 	EmitsEvent ComponentEventType
 }
 
+// statusesByName contains all statuses defined below, keyed by their name.
+var statusesByName = map[string]ComponentStatus{}
+
+// registerStatus adds a status to statusesByName, so it can be looked up by its name.
+func registerStatus(s ComponentStatus) ComponentStatus {
+	statusesByName[s.Name] = s
+	return s
+}
+
+// IsReadyPhase returns if `phase`, the name of a ComponentStatus as stored in the status
+// of a ControlPlane, represents a ready component.
+func IsReadyPhase(phase string) bool {
+	return statusesByName[phase].IsReady
+}
+
 //nolint:lll
 var (
 	// StatusReconcilerNotFound states that no registered reconciler was able to handle the component.
-	StatusReconcilerNotFound = ComponentStatus{Name: "ReconcilerNotFound", IsReady: false, EmitsEvent: ComponentEventWarning}
+	StatusReconcilerNotFound = registerStatus(ComponentStatus{Name: "ReconcilerNotFound", IsReady: false, EmitsEvent: ComponentEventWarning})
 
 	// StatusObservationFailed states that the current state (installed, healthy, etc.)
 	// of the component could not be determined.
-	StatusObservationFailed = ComponentStatus{Name: "ObservationFailed", IsReady: false, EmitsEvent: ComponentEventWarning}
+	StatusObservationFailed = registerStatus(ComponentStatus{Name: "ObservationFailed", IsReady: false, EmitsEvent: ComponentEventWarning})
 
 	// StatusUninstallFailed states that a component could not be uninstalled.
-	StatusUninstallFailed = ComponentStatus{Name: "UninstallFailed", IsReady: false, EmitsEvent: ComponentEventWarning}
+	StatusUninstallFailed = registerStatus(ComponentStatus{Name: "UninstallFailed", IsReady: false, EmitsEvent: ComponentEventWarning})
 
 	// StatusUninstalled states that a component has been uninstalled.
-	StatusUninstalled = ComponentStatus{Name: "Uninstalled", IsReady: false, EmitsEvent: ComponentEventNormal}
+	StatusUninstalled = registerStatus(ComponentStatus{Name: "Uninstalled", IsReady: false, EmitsEvent: ComponentEventNormal})
 
 	// StatusDisabled states that a component is disabled and no action has been taken.
-	StatusDisabled = ComponentStatus{Name: "Disabled", IsReady: false, EmitsEvent: ComponentEventNone}
+	StatusDisabled = registerStatus(ComponentStatus{Name: "Disabled", IsReady: false, EmitsEvent: ComponentEventNone})
 
 	// StatusComponentNotAllowed states that a component is not allowed to be installed.
-	StatusComponentNotAllowed = ComponentStatus{Name: "ComponentNotAllowed", IsReady: false, EmitsEvent: ComponentEventWarning}
+	StatusComponentNotAllowed = registerStatus(ComponentStatus{Name: "ComponentNotAllowed", IsReady: false, EmitsEvent: ComponentEventWarning})
 
 	// StatusDependencyCheckFailed states that a dependency check failed (e.g. dependency not enabled)
-	StatusDependencyCheckFailed = ComponentStatus{Name: "DependencyCheckFailed", IsReady: false, EmitsEvent: ComponentEventWarning}
+	StatusDependencyCheckFailed = registerStatus(ComponentStatus{Name: "DependencyCheckFailed", IsReady: false, EmitsEvent: ComponentEventWarning})
+
+	// StatusDependencyNotReady states that a dependency is enabled but has not become ready (yet).
+	StatusDependencyNotReady = registerStatus(ComponentStatus{Name: "DependencyNotReady", IsReady: false, EmitsEvent: ComponentEventNone})
+
+	// StatusRetryBackoff states that a component has failed before and is not reconciled until its next retry.
+	StatusRetryBackoff = registerStatus(ComponentStatus{Name: "RetryBackoff", IsReady: false, EmitsEvent: ComponentEventNone})
 
 	// StatusInstallFailed states that a component could not be installed.
-	StatusInstallFailed = ComponentStatus{Name: "InstallFailed", IsReady: false, EmitsEvent: ComponentEventWarning}
+	StatusInstallFailed = registerStatus(ComponentStatus{Name: "InstallFailed", IsReady: false, EmitsEvent: ComponentEventWarning})
 
 	// StatusInstalled states that a component has been installed.
-	StatusInstalled = ComponentStatus{Name: "Installed", IsReady: false, EmitsEvent: ComponentEventNormal}
+	StatusInstalled = registerStatus(ComponentStatus{Name: "Installed", IsReady: false, EmitsEvent: ComponentEventNormal})
 
 	// StatusUpdateFailed states that a component could not be updated.
-	StatusUpdateFailed = ComponentStatus{Name: "UpdateFailed", IsReady: false, EmitsEvent: ComponentEventWarning}
+	StatusUpdateFailed = registerStatus(ComponentStatus{Name: "UpdateFailed", IsReady: false, EmitsEvent: ComponentEventWarning})
+
+	// StatusPostUninstallHookFailed states that a component has been uninstalled but its post-uninstall hook failed.
+	StatusPostUninstallHookFailed = registerStatus(ComponentStatus{Name: "PostUninstallHookFailed", IsReady: false, EmitsEvent: ComponentEventWarning})
+
+	// StatusPostInstallHookFailed states that a component has been installed but its post-install hook failed.
+	StatusPostInstallHookFailed = registerStatus(ComponentStatus{Name: "PostInstallHookFailed", IsReady: false, EmitsEvent: ComponentEventWarning})
+
+	// StatusPostUpdateHookFailed states that a component has been updated but its post-update hook failed.
+	StatusPostUpdateHookFailed = registerStatus(ComponentStatus{Name: "PostUpdateHookFailed", IsReady: false, EmitsEvent: ComponentEventWarning})
+
+	// StatusPostHealthyHookFailed states that a component is healthy but its post-healthy hook failed.
+	StatusPostHealthyHookFailed = registerStatus(ComponentStatus{Name: "PostHealthyHookFailed", IsReady: false, EmitsEvent: ComponentEventWarning})
 
 	// StatusUnhealthyReconciliationSkipped states that a component is unhealthy and the reconciliation is skipped.
-	StatusUnhealthyReconciliationSkipped = ComponentStatus{Name: "ReconciliationSkipped", IsReady: false, EmitsEvent: ComponentEventNormal}
+	StatusUnhealthyReconciliationSkipped = registerStatus(ComponentStatus{Name: "ReconciliationSkipped", IsReady: false, EmitsEvent: ComponentEventNormal})
 
 	// StatusUnhealthy states that a component is unhealthy.
-	StatusUnhealthy = ComponentStatus{Name: "Unhealthy", IsReady: false, EmitsEvent: ComponentEventNone}
+	StatusUnhealthy = registerStatus(ComponentStatus{Name: "Unhealthy", IsReady: false, EmitsEvent: ComponentEventNone})
 
 	// StatusHealthyReconciliationSkipped states that a component is healthy but reconciliation is skipped.
-	StatusHealthyReconciliationSkipped = ComponentStatus{Name: "ReconciliationSkipped", IsReady: true, EmitsEvent: ComponentEventNormal}
+	StatusHealthyReconciliationSkipped = registerStatus(ComponentStatus{Name: "ReconciliationSkipped", IsReady: true, EmitsEvent: ComponentEventNormal})
 
 	// StatusHealthy states that a component is healthy and no action has been taken.
-	StatusHealthy = ComponentStatus{Name: "Healthy", IsReady: true, EmitsEvent: ComponentEventNone}
+	StatusHealthy = registerStatus(ComponentStatus{Name: "Healthy", IsReady: true, EmitsEvent: ComponentEventNone})
 )
 
 // ComponentResult contains information about an operation performed by the component manager.
@@ -119,13 +185,39 @@ type ComponentResult struct {
 	Component Component
 	Result    ComponentStatus
 	Message   string
+	// Retry is only set for failed components if the Juggler is configured with a RetryBackoff.
+	Retry RetryState
+	// ObservedVersion is the installed version of the component as observed during the reconciliation.
+	ObservedVersion string
+	// ResolvedVersion is the concrete version the desired version resolved to during the reconciliation,
+	// if the component implements the optional `VersionResolvingComponent` interface.
+	ResolvedVersion string
 }
 
 // ComponentHooks defines hooks for a Component.
+// Post* hooks are only called after the corresponding action has been successful.
 type ComponentHooks struct {
 	PreUninstall func(ctx context.Context, c client.Client) error
 	PreInstall   func(ctx context.Context, c client.Client) error
 	PreUpdate    func(ctx context.Context, c client.Client) error
+
+	PostUninstall func(ctx context.Context, c client.Client) error
+	PostInstall   func(ctx context.Context, c client.Client) error
+	// PostUpdate is called after every update, which is also performed if the component is already up-to-date.
+	PostUpdate func(ctx context.Context, c client.Client) error
+	// PostHealthy is called once a component turns healthy, i.e. when it is healthy but has not been ready
+	// after the previous reconciliation (see Juggler.WithReadinessHistory).
+	PostHealthy func(ctx context.Context, c client.Client) error
+}
+
+// ReadinessHistory reports whether a component has been ready after the previous reconciliation.
+type ReadinessHistory func(component Component) bool
+
+// ReadinessFromConditions returns a ReadinessHistory based on conditions created by ComponentResult.ToCondition.
+func ReadinessFromConditions(conditions []metav1.Condition) ReadinessHistory {
+	return func(component Component) bool {
+		return meta.IsStatusConditionTrue(conditions, ComponentResult{Component: component}.conditionType())
+	}
 }
 
 // ToCondition converts a ComponentResult to a Kubernetes Condition.
@@ -150,6 +242,48 @@ func (r ComponentResult) ToCondition() metav1.Condition {
 	}
 }
 
+// Kind returns the kind of the component, i.e. the name of its type.
+func (r ComponentResult) Kind() string {
+	return ComponentKind(r.Component)
+}
+
+// ComponentKind returns the name of the type of a component, e.g. "Crossplane" for `*components.Crossplane`.
+func ComponentKind(component Component) string {
+	t := reflect.TypeOf(component)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// DesiredVersion returns the desired version of the component,
+// if the component implements the optional `VersionedComponent` interface.
+func (r ComponentResult) DesiredVersion() string {
+	if vc, ok := r.Component.(VersionedComponent); ok {
+		return vc.GetVersion()
+	}
+	return ""
+}
+
+// resolvedVersion returns the concrete version of an enabled component that implements the optional
+// `VersionResolvingComponent` interface. An empty string is returned if the version cannot be resolved.
+func resolvedVersion(ctx context.Context, component Component) string {
+	vrc, ok := component.(VersionResolvingComponent)
+	if !ok || !component.IsEnabled() {
+		return ""
+	}
+	version, err := vrc.GetResolvedVersion(ctx)
+	if err != nil {
+		return ""
+	}
+	return version
+}
+
+// IsInternal returns if the component is internal (see `StatusVisibility`).
+func (r ComponentResult) IsInternal() bool {
+	return isComponentInternal(r.Component)
+}
+
 // conditionType is a helper method. It calculates the Condition.Type
 // field. For internal components, the value starts with a lowercase
 // letter.
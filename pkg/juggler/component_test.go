@@ -1,6 +1,8 @@
 package juggler
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -28,6 +30,28 @@ func (c test_internalImplementType) IsStatusInternal() bool {
 	return true
 }
 
+type test_versionedType struct {
+	FakeComponent
+}
+
+var _ VersionedComponent = &test_versionedType{}
+
+func (c *test_versionedType) GetVersion() string {
+	return "1.0.0"
+}
+
+type test_resolvingType struct {
+	FakeComponent
+	resolved string
+	err      error
+}
+
+var _ VersionResolvingComponent = &test_resolvingType{}
+
+func (c *test_resolvingType) GetResolvedVersion(ctx context.Context) (string, error) {
+	return c.resolved, c.err
+}
+
 func Test_isComponentInternal(t *testing.T) {
 	// externalImplement implements StatusVisibility but reports
 	// external
@@ -131,3 +155,40 @@ func TestComponentResult_ToCondition(t *testing.T) {
 		})
 	}
 }
+
+func TestReadinessFromConditions(t *testing.T) {
+	history := ReadinessFromConditions([]v1.Condition{
+		{Type: "ReadyComponentReady", Status: v1.ConditionTrue},
+		{Type: "NotReadyComponentReady", Status: v1.ConditionFalse},
+		{Type: "internalComponentReady", Status: v1.ConditionTrue},
+	})
+	assert.True(t, history(FakeComponent{Name: "ReadyComponent"}))
+	assert.False(t, history(FakeComponent{Name: "NotReadyComponent"}))
+	assert.False(t, history(FakeComponent{Name: "UnknownComponent"}))
+	assert.True(t, history(FakeComponent{Name: "InternalComponent", Internal: true}))
+}
+
+func TestComponentResult_Kind(t *testing.T) {
+	assert.Equal(t, "FakeComponent", ComponentResult{Component: FakeComponent{}}.Kind())
+	assert.Equal(t, "test_versionedType", ComponentResult{Component: &test_versionedType{}}.Kind())
+}
+
+func TestComponentResult_DesiredVersion(t *testing.T) {
+	assert.Equal(t, "", ComponentResult{Component: FakeComponent{}}.DesiredVersion())
+	assert.Equal(t, "1.0.0", ComponentResult{Component: &test_versionedType{}}.DesiredVersion())
+}
+
+func Test_resolvedVersion(t *testing.T) {
+	ctx := context.TODO()
+	assert.Equal(t, "", resolvedVersion(ctx, FakeComponent{Enabled: true}))
+	assert.Equal(t, "1.16.2", resolvedVersion(ctx, &test_resolvingType{FakeComponent: FakeComponent{Enabled: true}, resolved: "1.16.2"}))
+	assert.Equal(t, "", resolvedVersion(ctx, &test_resolvingType{FakeComponent: FakeComponent{Enabled: false}, resolved: "1.16.2"}))
+	assert.Equal(t, "", resolvedVersion(ctx, &test_resolvingType{FakeComponent: FakeComponent{Enabled: true}, err: errors.New("not found")}))
+}
+
+func TestIsReadyPhase(t *testing.T) {
+	assert.True(t, IsReadyPhase(StatusHealthy.Name))
+	assert.True(t, IsReadyPhase(StatusHealthyReconciliationSkipped.Name))
+	assert.False(t, IsReadyPhase(StatusUnhealthy.Name))
+	assert.False(t, IsReadyPhase("unknown"))
+}
@@ -0,0 +1,74 @@
+package juggler
+
+// dependencyGraph is a directed graph of registered components.
+// An edge from component A to component B means that B depends on A.
+// Dependencies are matched by type (see `DependencyMatcher`), the same way as in `Juggler.checkDependencies`.
+type dependencyGraph struct {
+	components   []Component
+	dependencies [][]int
+	dependents   [][]int
+	inDegree     []int
+}
+
+// newDependencyGraph builds a dependencyGraph from the dependencies declared by the given components.
+// Dependencies that are not registered are ignored here, they are reported by `Juggler.checkDependencies`.
+func newDependencyGraph(components []Component) *dependencyGraph {
+	g := &dependencyGraph{
+		components:   components,
+		dependencies: make([][]int, len(components)),
+		dependents:   make([][]int, len(components)),
+		inDegree:     make([]int, len(components)),
+	}
+
+	for i, component := range components {
+		for _, dep := range component.GetDependencies() {
+			for j, candidate := range components {
+				if !isDependency(candidate, dep) {
+					continue
+				}
+				g.dependencies[i] = append(g.dependencies[i], j)
+				g.dependents[j] = append(g.dependents[j], i)
+				g.inDegree[i]++
+			}
+		}
+	}
+
+	return g
+}
+
+// sort returns the indices of all components in topological order, i.e. every component comes after
+// all of its dependencies. Components which are independent of each other keep their registration order.
+// Indices of components that are part of a dependency cycle (or depend on one) are returned separately.
+func (g *dependencyGraph) sort() (sorted []int, cyclic []int) {
+	inDegree := make([]int, len(g.inDegree))
+	copy(inDegree, g.inDegree)
+	done := make([]bool, len(g.components))
+
+	sorted = make([]int, 0, len(g.components))
+	for len(sorted) < len(g.components) {
+		next := -1
+		for i := range g.components {
+			if !done[i] && inDegree[i] == 0 {
+				next = i
+				break
+			}
+		}
+		if next < 0 {
+			break
+		}
+
+		done[next] = true
+		sorted = append(sorted, next)
+		for _, dependent := range g.dependents[next] {
+			inDegree[dependent]--
+		}
+	}
+
+	for i := range g.components {
+		if !done[i] {
+			cyclic = append(cyclic, i)
+		}
+	}
+
+	return sorted, cyclic
+}
@@ -0,0 +1,82 @@
+package juggler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_dependencyGraph_sort(t *testing.T) {
+	tests := []struct {
+		name       string
+		components []Component
+		wantSorted []int
+		wantCyclic []int
+	}{
+		{
+			name:       "no components",
+			components: []Component{},
+			wantSorted: []int{},
+		},
+		{
+			name: "independent components keep registration order",
+			components: []Component{
+				FakeComponent{Name: "A"},
+				FakeComponent2{Name: "B"},
+			},
+			wantSorted: []int{0, 1},
+		},
+		{
+			name: "dependency is sorted before dependent",
+			components: []Component{
+				FakeComponent{Name: "A", Dependencies: []Component{FakeComponent2{}}},
+				FakeComponent2{Name: "B"},
+			},
+			wantSorted: []int{1, 0},
+		},
+		{
+			name: "all components of the dependency type are sorted before dependent",
+			components: []Component{
+				FakeComponent{Name: "A1", Dependencies: []Component{FakeComponent2{}}},
+				FakeComponent2{Name: "B1"},
+				FakeComponent{Name: "A2", Dependencies: []Component{FakeComponent2{}}},
+				FakeComponent2{Name: "B2"},
+			},
+			wantSorted: []int{1, 3, 0, 2},
+		},
+		{
+			name: "named dependency only matches the component with the same name",
+			components: []Component{
+				FakeNamedComponent{FakeComponent{Name: "A", Dependencies: []Component{FakeNamedComponent{FakeComponent{Name: "C"}}}}},
+				FakeNamedComponent{FakeComponent{Name: "B"}},
+				FakeNamedComponent{FakeComponent{Name: "C", Dependencies: []Component{FakeComponent2{}}}},
+				FakeComponent2{Name: "D"},
+			},
+			wantSorted: []int{1, 3, 2, 0},
+		},
+		{
+			name: "unregistered dependency is ignored",
+			components: []Component{
+				FakeComponent{Name: "A", Dependencies: []Component{FakeComponent2{}}},
+			},
+			wantSorted: []int{0},
+		},
+		{
+			name: "cycle is detected",
+			components: []Component{
+				FakeComponent{Name: "A", Dependencies: []Component{FakeComponent2{}}},
+				FakeComponent2{Name: "B", Dependencies: []Component{FakeComponent{}}},
+				FakeComponent3{FakeComponent: FakeComponent{Name: "C"}},
+			},
+			wantSorted: []int{2},
+			wantCyclic: []int{0, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorted, cyclic := newDependencyGraph(tt.components).sort()
+			assert.Equal(t, tt.wantSorted, sorted)
+			assert.Equal(t, tt.wantCyclic, cyclic)
+		})
+	}
+}
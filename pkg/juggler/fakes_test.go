@@ -110,6 +110,20 @@ func (f FakeComponent3) GetAvailableVersions(context.Context) ([]string, error)
 
 // ---------------------------------------------------------------------------------------------------
 
+var _ DependencyMatcher = FakeNamedComponent{}
+
+// FakeNamedComponent is a component of which several instances are registered, dependencies are matched by name.
+type FakeNamedComponent struct {
+	FakeComponent
+}
+
+// MatchesDependency implements DependencyMatcher.
+func (f FakeNamedComponent) MatchesDependency(registered Component) bool {
+	return registered.GetName() == f.GetName()
+}
+
+// ---------------------------------------------------------------------------------------------------
+
 var _ ComponentReconciler = FakeReconciler{}
 var _ OrphanedComponentsDetector = FakeReconciler{}
 
@@ -121,6 +135,10 @@ type FakeReconciler struct {
 	PreUninstallFunc             func(ctx context.Context, component Component) error
 	PreInstallFunc               func(ctx context.Context, component Component) error
 	PreUpdateFunc                func(ctx context.Context, component Component) error
+	PostUninstallFunc            func(ctx context.Context, component Component) error
+	PostInstallFunc              func(ctx context.Context, component Component) error
+	PostUpdateFunc               func(ctx context.Context, component Component) error
+	PostHealthyFunc              func(ctx context.Context, component Component) error
 	KnownTypesFunc               func() []reflect.Type
 	DetectOrphanedComponentsFunc func(_ context.Context, configuredComponents []Component) ([]Component, error)
 }
@@ -159,6 +177,38 @@ func (f FakeReconciler) PreUpdate(ctx context.Context, component Component) erro
 	return f.PreUpdateFunc(ctx, component)
 }
 
+// PostUninstall implements juggler.ComponentReconciler.
+func (f FakeReconciler) PostUninstall(ctx context.Context, component Component) error {
+	if f.PostUninstallFunc == nil {
+		return nil
+	}
+	return f.PostUninstallFunc(ctx, component)
+}
+
+// PostInstall implements juggler.ComponentReconciler.
+func (f FakeReconciler) PostInstall(ctx context.Context, component Component) error {
+	if f.PostInstallFunc == nil {
+		return nil
+	}
+	return f.PostInstallFunc(ctx, component)
+}
+
+// PostUpdate implements juggler.ComponentReconciler.
+func (f FakeReconciler) PostUpdate(ctx context.Context, component Component) error {
+	if f.PostUpdateFunc == nil {
+		return nil
+	}
+	return f.PostUpdateFunc(ctx, component)
+}
+
+// PostHealthy implements juggler.ComponentReconciler.
+func (f FakeReconciler) PostHealthy(ctx context.Context, component Component) error {
+	if f.PostHealthyFunc == nil {
+		return nil
+	}
+	return f.PostHealthyFunc(ctx, component)
+}
+
 func (f FakeReconciler) Observe(ctx context.Context, component Component) (ComponentObservation, error) {
 	return f.ObserverFunc(ctx, component)
 }
@@ -177,3 +227,17 @@ func (f FakeReconciler) Update(ctx context.Context, component Component) error {
 func (f FakeReconciler) Install(ctx context.Context, component Component) error {
 	return f.InstallFunc(ctx, component)
 }
+
+// ---------------------------------------------------------------------------------------------------
+
+var _ ComponentDiffer = FakeDifferReconciler{}
+
+type FakeDifferReconciler struct {
+	FakeReconciler
+	DiffFunc func(ctx context.Context, component Component) (string, error)
+}
+
+// Diff implements ComponentDiffer.
+func (f FakeDifferReconciler) Diff(ctx context.Context, component Component) (string, error) {
+	return f.DiffFunc(ctx, component)
+}
@@ -6,6 +6,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler/object"
 )
 
 type FluxComponent interface {
@@ -15,6 +16,13 @@ type FluxComponent interface {
 	BuildManifesto(ctx context.Context) (Manifesto, error)
 }
 
+// OrphanedManifestosDetector can be implemented by FluxComponents of which any number can be configured.
+// The DetectorContexts are used to find the Flux resources of components that are no longer configured,
+// so that these components can be uninstalled.
+type OrphanedManifestosDetector interface {
+	OrphanDetectorContexts(ctx context.Context) []object.DetectorContext
+}
+
 type FluxResource interface {
 	GetObject() client.Object
 	GetObjectKey() client.ObjectKey
@@ -40,4 +48,7 @@ type Manifesto interface {
 	// It contains an empty (non-nil) client.Object retaining only the name and namespace.
 	// This is useful when working with controllerutil.CreateOrUpdate(...).
 	Empty() Manifesto
+
+	// GetInstalledVersion returns the version that has been installed by Flux, if known.
+	GetInstalledVersion() string
 }
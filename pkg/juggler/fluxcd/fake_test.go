@@ -4,9 +4,15 @@ import (
 	"context"
 	"reflect"
 
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler/object"
 )
 
+var fakeOrphanLabel = "fake.flux.component/orphan"
+
 var _ juggler.Component = FakeComponent{}
 
 type FakeComponent struct {
@@ -83,17 +89,49 @@ func (f FakeFluxComponent) Hooks() juggler.ComponentHooks {
 
 // ---------------------------------------------------------------------------------------------------
 
+var _ OrphanedManifestosDetector = FakeOrphanFluxComponent{}
+
+// FakeOrphanFluxComponent is a FluxComponent of which any number can be configured.
+// Each component is represented by a HelmRelease with the fakeOrphanLabel.
+type FakeOrphanFluxComponent struct {
+	FakeFluxComponent
+}
+
+// OrphanDetectorContexts implements OrphanedManifestosDetector.
+func (FakeOrphanFluxComponent) OrphanDetectorContexts(_ context.Context) []object.DetectorContext {
+	return []object.DetectorContext{{
+		ListType:       &helmv2.HelmReleaseList{},
+		FilterCriteria: object.FilterCriteria{client.HasLabels{fakeOrphanLabel}},
+		ConvertFunc: func(list client.ObjectList) []juggler.Component {
+			comps := []juggler.Component{}
+			for _, hr := range list.(*helmv2.HelmReleaseList).Items {
+				comps = append(comps, FakeOrphanFluxComponent{FakeFluxComponent{GetNameFunc: hr.Name}})
+			}
+			return comps
+		},
+		SameFunc: func(configured, detected juggler.Component) bool {
+			return configured.GetName() == detected.GetName()
+		},
+	}}
+}
+
+// ---------------------------------------------------------------------------------------------------
+
 var _ juggler.ComponentReconciler = FakeReconciler{}
 
 type FakeReconciler struct {
-	ObserverFunc     func(ctx context.Context, component juggler.Component) (juggler.ComponentObservation, error)
-	UninstallFunc    func(ctx context.Context, component juggler.Component) error
-	UpdateFunc       func(ctx context.Context, component juggler.Component) error
-	InstallFunc      func(ctx context.Context, component juggler.Component) error
-	PreUninstallFunc func(ctx context.Context, component juggler.Component) error
-	PreInstallFunc   func(ctx context.Context, component juggler.Component) error
-	PreUpdateFunc    func(ctx context.Context, component juggler.Component) error
-	KnownTypesFunc   func() []reflect.Type
+	ObserverFunc      func(ctx context.Context, component juggler.Component) (juggler.ComponentObservation, error)
+	UninstallFunc     func(ctx context.Context, component juggler.Component) error
+	UpdateFunc        func(ctx context.Context, component juggler.Component) error
+	InstallFunc       func(ctx context.Context, component juggler.Component) error
+	PreUninstallFunc  func(ctx context.Context, component juggler.Component) error
+	PreInstallFunc    func(ctx context.Context, component juggler.Component) error
+	PreUpdateFunc     func(ctx context.Context, component juggler.Component) error
+	PostUninstallFunc func(ctx context.Context, component juggler.Component) error
+	PostInstallFunc   func(ctx context.Context, component juggler.Component) error
+	PostUpdateFunc    func(ctx context.Context, component juggler.Component) error
+	PostHealthyFunc   func(ctx context.Context, component juggler.Component) error
+	KnownTypesFunc    func() []reflect.Type
 }
 
 // KnownTypes implements juggler.ComponentReconciler.
@@ -125,6 +163,38 @@ func (f FakeReconciler) PreUpdate(ctx context.Context, component juggler.Compone
 	return f.PreUpdateFunc(ctx, component)
 }
 
+// PostUninstall implements juggler.ComponentReconciler.
+func (f FakeReconciler) PostUninstall(ctx context.Context, component juggler.Component) error {
+	if f.PostUninstallFunc == nil {
+		return nil
+	}
+	return f.PostUninstallFunc(ctx, component)
+}
+
+// PostInstall implements juggler.ComponentReconciler.
+func (f FakeReconciler) PostInstall(ctx context.Context, component juggler.Component) error {
+	if f.PostInstallFunc == nil {
+		return nil
+	}
+	return f.PostInstallFunc(ctx, component)
+}
+
+// PostUpdate implements juggler.ComponentReconciler.
+func (f FakeReconciler) PostUpdate(ctx context.Context, component juggler.Component) error {
+	if f.PostUpdateFunc == nil {
+		return nil
+	}
+	return f.PostUpdateFunc(ctx, component)
+}
+
+// PostHealthy implements juggler.ComponentReconciler.
+func (f FakeReconciler) PostHealthy(ctx context.Context, component juggler.Component) error {
+	if f.PostHealthyFunc == nil {
+		return nil
+	}
+	return f.PostHealthyFunc(ctx, component)
+}
+
 //nolint:lll
 func (f FakeReconciler) Observe(ctx context.Context, component juggler.Component) (juggler.ComponentObservation, error) {
 	return f.ObserverFunc(ctx, component)
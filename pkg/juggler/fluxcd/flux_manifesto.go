@@ -5,6 +5,7 @@ import (
 	"time"
 
 	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
 	fluxmeta "github.com/fluxcd/pkg/apis/meta"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -14,7 +15,8 @@ import (
 )
 
 var (
-	errNotAHelmReleaseManifesto = errors.New("FluxResource is not a HelmReleaseManifesto")
+	errNotAHelmReleaseManifesto   = errors.New("FluxResource is not a HelmReleaseManifesto")
+	errNotAKustomizationManifesto = errors.New("FluxResource is not a KustomizationManifesto")
 )
 
 var _ Manifesto = &HelmReleaseManifesto{}
@@ -62,6 +64,11 @@ func (h *HelmReleaseManifesto) GetHealthiness() juggler.ResourceHealthiness {
 	}
 }
 
+// GetInstalledVersion implements Manifesto.
+func (h *HelmReleaseManifesto) GetInstalledVersion() string {
+	return h.Manifest.Status.LastAttemptedRevision
+}
+
 func (h *HelmReleaseManifesto) GetObjectKey() client.ObjectKey {
 	return client.ObjectKey{
 		Namespace: h.Manifest.Namespace,
@@ -102,3 +109,76 @@ func (h *HelmReleaseManifesto) ApplyDefaults() {
 
 	h.Manifest.Spec.Upgrade.Remediation.Retries = -1
 }
+
+// ---------------------------------------------------------------------------------------------------
+
+var _ Manifesto = &KustomizationManifesto{}
+
+// KustomizationManifesto applies plain manifests from a GitRepository or OCIRepository.
+type KustomizationManifesto struct {
+	Manifest *kustomizev1.Kustomization
+}
+
+// Reconcile implements Manifesto.
+func (k *KustomizationManifesto) Reconcile(desired FluxResource) error {
+	desiredManifesto, ok := desired.(*KustomizationManifesto)
+	if !ok {
+		return errNotAKustomizationManifesto
+	}
+
+	preserved := k.Manifest.Spec.DeepCopy()
+	k.Manifest.Spec = desiredManifesto.Manifest.Spec
+	// Give suspension precedence
+	k.Manifest.Spec.Suspend = preserved.Suspend
+	return nil
+}
+
+// Empty implements Manifesto.
+func (k *KustomizationManifesto) Empty() Manifesto {
+	return &KustomizationManifesto{&kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      k.Manifest.Name,
+			Namespace: k.Manifest.Namespace,
+		},
+	}}
+}
+
+// GetHealthiness implements Manifesto.
+func (k *KustomizationManifesto) GetHealthiness() juggler.ResourceHealthiness {
+	cond := apimeta.FindStatusCondition(k.Manifest.Status.Conditions, fluxmeta.ReadyCondition)
+	if cond == nil {
+		return juggler.ResourceHealthiness{
+			Healthy: false,
+			Message: msgReadyNotPresent,
+		}
+	}
+	return juggler.ResourceHealthiness{
+		Healthy: cond.Status == metav1.ConditionTrue,
+		Message: cond.Message,
+	}
+}
+
+// GetInstalledVersion implements Manifesto.
+// The revision of the last applied source, e.g. "v1.0.0@sha1:...".
+func (k *KustomizationManifesto) GetInstalledVersion() string {
+	return k.Manifest.Status.LastAppliedRevision
+}
+
+func (k *KustomizationManifesto) GetObjectKey() client.ObjectKey {
+	return client.ObjectKey{
+		Namespace: k.Manifest.Namespace,
+		Name:      k.Manifest.Name,
+	}
+}
+
+func (k *KustomizationManifesto) GetObject() client.Object {
+	return k.Manifest
+}
+
+func (k *KustomizationManifesto) ApplyDefaults() {
+	scheme.Default(k.Manifest)
+
+	k.Manifest.Spec.Interval = metav1.Duration{Duration: 5 * time.Minute}
+	// Remove objects from the cluster that are no longer part of the source.
+	k.Manifest.Spec.Prune = true
+}
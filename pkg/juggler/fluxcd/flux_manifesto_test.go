@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
 	fluxmeta "github.com/fluxcd/pkg/apis/meta"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -102,3 +103,114 @@ func TestHelmReleaseManifesto_GetHealthiness(t *testing.T) {
 		})
 	}
 }
+
+func TestHelmReleaseManifesto_GetInstalledVersion(t *testing.T) {
+	manifesto := HelmReleaseManifesto{
+		Manifest: &helmv2.HelmRelease{
+			Status: helmv2.HelmReleaseStatus{
+				LastAttemptedRevision: "1.16.1",
+			},
+		},
+	}
+	assert.Equal(t, "1.16.1", manifesto.GetInstalledVersion())
+}
+
+func TestKustomizationManifesto_GetHealthiness(t *testing.T) {
+	tests := []struct {
+		name      string
+		manifesto KustomizationManifesto
+		expected  juggler.ResourceHealthiness
+	}{
+		{
+			name: "KustomizationManifesto - Status Condition nil - Ready condition not present",
+			manifesto: KustomizationManifesto{
+				Manifest: &kustomizev1.Kustomization{
+					Status: kustomizev1.KustomizationStatus{
+						Conditions: nil,
+					},
+				},
+			},
+			expected: juggler.ResourceHealthiness{
+				Healthy: false,
+				Message: msgReadyNotPresent,
+			},
+		},
+		{
+			name: "KustomizationManifesto - Status Condition Ready = True",
+			manifesto: KustomizationManifesto{
+				Manifest: &kustomizev1.Kustomization{
+					Status: kustomizev1.KustomizationStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:    fluxmeta.ReadyCondition,
+								Status:  metav1.ConditionTrue,
+								Message: "Applied revision: v1.0.0",
+							},
+						},
+					},
+				},
+			},
+			expected: juggler.ResourceHealthiness{
+				Healthy: true,
+				Message: "Applied revision: v1.0.0",
+			},
+		},
+		{
+			name: "KustomizationManifesto - Status Condition Ready = False",
+			manifesto: KustomizationManifesto{
+				Manifest: &kustomizev1.Kustomization{
+					Status: kustomizev1.KustomizationStatus{
+						Conditions: []metav1.Condition{
+							{
+								Type:    fluxmeta.ReadyCondition,
+								Status:  metav1.ConditionFalse,
+								Message: "kustomization path not found",
+							},
+						},
+					},
+				},
+			},
+			expected: juggler.ResourceHealthiness{
+				Healthy: false,
+				Message: "kustomization path not found",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.manifesto.GetHealthiness()
+			if !assert.Equal(t, tt.expected, actual) {
+				t.Errorf("KustomizationManifesto.GetHealthiness() = %v, want %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
+func TestKustomizationManifesto_GetInstalledVersion(t *testing.T) {
+	manifesto := KustomizationManifesto{
+		Manifest: &kustomizev1.Kustomization{
+			Status: kustomizev1.KustomizationStatus{
+				LastAppliedRevision: "v1.0.0@sha1:a1b2c3",
+			},
+		},
+	}
+	assert.Equal(t, "v1.0.0@sha1:a1b2c3", manifesto.GetInstalledVersion())
+}
+
+func TestKustomizationManifesto_Reconcile(t *testing.T) {
+	actual := &KustomizationManifesto{
+		Manifest: &kustomizev1.Kustomization{
+			Spec: kustomizev1.KustomizationSpec{Path: "./old", Suspend: true},
+		},
+	}
+	desired := &KustomizationManifesto{
+		Manifest: &kustomizev1.Kustomization{
+			Spec: kustomizev1.KustomizationSpec{Path: "./new"},
+		},
+	}
+	assert.NoError(t, actual.Reconcile(desired))
+	assert.Equal(t, "./new", actual.Manifest.Spec.Path)
+	assert.True(t, actual.Manifest.Spec.Suspend)
+
+	assert.ErrorIs(t, actual.Reconcile(&HelmReleaseManifesto{}), errNotAKustomizationManifesto)
+}
@@ -5,15 +5,19 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 
+	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	"github.com/go-logr/logr"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/openmcp-project/control-plane-operator/pkg/juggler"
+	"github.com/openmcp-project/control-plane-operator/pkg/juggler/object"
 	"github.com/openmcp-project/control-plane-operator/pkg/utils"
 )
 
@@ -22,6 +26,8 @@ var (
 )
 
 var _ juggler.ComponentReconciler = &FluxReconciler{}
+var _ juggler.ComponentDiffer = &FluxReconciler{}
+var _ juggler.OrphanedComponentsDetector = &FluxReconciler{}
 
 func NewFluxReconciler(logger logr.Logger, localClient client.Client, remoteClient client.Client, labelComponentName string) *FluxReconciler {
 	return &FluxReconciler{
@@ -60,6 +66,54 @@ func (r *FluxReconciler) RegisterType(comps ...FluxComponent) {
 	}
 }
 
+// DetectOrphanedComponents implements juggler.OrphanedComponentsDetector.
+func (r *FluxReconciler) DetectOrphanedComponents(
+	ctx context.Context,
+	configuredComponents []juggler.Component,
+) ([]juggler.Component, error) {
+	orphaned := []juggler.Component{}
+	for _, kt := range r.KnownTypes() {
+		newComp := reflect.New(kt).Elem().Interface()
+		if omd, ok := newComp.(OrphanedManifestosDetector); ok {
+			for _, dc := range omd.OrphanDetectorContexts(ctx) {
+				filtered, err := r.filterOrphanedManifestos(ctx, configuredComponents, dc)
+				if err != nil {
+					return nil, err
+				}
+				orphaned = append(orphaned, filtered...)
+			}
+		}
+	}
+	return orphaned, nil
+}
+
+// filterOrphanedManifestos lists the Flux resources described by the `DetectorContext`, converts them
+// to components and returns those which are not configured.
+func (r *FluxReconciler) filterOrphanedManifestos(
+	ctx context.Context,
+	configuredComponents []juggler.Component,
+	dc object.DetectorContext,
+) ([]juggler.Component, error) {
+	err := r.localClient.List(ctx, dc.ListType, dc.FilterCriteria...)
+	if utils.IsCRDNotFound(err) {
+		// CRD not installed, so there can't be any orphaned resources of this type.
+		return []juggler.Component{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	orphaned := []juggler.Component{}
+	for _, possiblyOrphaned := range dc.ConvertFunc(dc.ListType) {
+		found := slices.ContainsFunc(configuredComponents, func(configured juggler.Component) bool {
+			return reflect.TypeOf(configured) == reflect.TypeOf(possiblyOrphaned) && dc.SameFunc(configured, possiblyOrphaned)
+		})
+		if !found {
+			orphaned = append(orphaned, possiblyOrphaned)
+		}
+	}
+	return orphaned, nil
+}
+
 //nolint:lll
 func (r *FluxReconciler) Observe(ctx context.Context, component juggler.Component) (juggler.ComponentObservation, error) {
 	fluxComponent, ok := component.(FluxComponent)
@@ -80,6 +134,7 @@ func (r *FluxReconciler) Observe(ctx context.Context, component juggler.Componen
 	return juggler.ComponentObservation{
 		ResourceExists:      sourceObservation.ResourceExists || manifestoObservation.ResourceExists,
 		ResourceHealthiness: aggregateHealthiness(manifestoObservation.ResourceHealthiness, sourceObservation.ResourceHealthiness),
+		Version:             manifestoObservation.Version,
 	}, nil
 }
 
@@ -101,6 +156,7 @@ func (r *FluxReconciler) observeManifesto(ctx context.Context, fluxComponent Flu
 	return juggler.ComponentObservation{
 		ResourceExists:      true,
 		ResourceHealthiness: actualManifest.GetHealthiness(),
+		Version:             actualManifest.GetInstalledVersion(),
 	}, nil
 }
 
@@ -149,7 +205,62 @@ func (r *FluxReconciler) deleteSource(ctx context.Context, fluxComponent FluxCom
 	resourceRepository := desiredRepository.GetObject()
 
 	err = r.localClient.Delete(ctx, resourceRepository)
-	return client.IgnoreNotFound(err)
+	if client.IgnoreNotFound(err) != nil {
+		return err
+	}
+	return r.deleteReplacedSources(ctx, desiredRepository)
+}
+
+// replacedChartSources returns the chart sources with the name of the desired source but of the other kind.
+// They are left behind when the chart of a component moves between a Helm repository and an OCI registry.
+func replacedChartSources(desired SourceAdapter) []client.Object {
+	key := desired.GetObjectKey()
+	objectMeta := metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}
+	switch desired.GetObject().(type) {
+	case *sourcev1.HelmRepository:
+		return []client.Object{&sourcev1.OCIRepository{ObjectMeta: objectMeta}}
+	case *sourcev1.OCIRepository:
+		return []client.Object{&sourcev1.HelmRepository{ObjectMeta: objectMeta}}
+	}
+	return nil
+}
+
+// deleteReplacedSources deletes the chart sources of the other kind than the desired source.
+// The HelmCharts that helm-controller created from a HelmRepository are deleted with it.
+func (r *FluxReconciler) deleteReplacedSources(ctx context.Context, desired SourceAdapter) error {
+	for _, obj := range replacedChartSources(desired) {
+		if _, ok := obj.(*sourcev1.HelmRepository); ok {
+			if err := r.deleteHelmCharts(ctx, obj); err != nil {
+				return err
+			}
+		}
+		if err := r.localClient.Delete(ctx, obj); client.IgnoreNotFound(err) != nil && !utils.IsCRDNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteHelmCharts deletes the HelmCharts that are pulled from the HelmRepository.
+func (r *FluxReconciler) deleteHelmCharts(ctx context.Context, repository client.Object) error {
+	charts := &sourcev1.HelmChartList{}
+	err := r.localClient.List(ctx, charts, client.InNamespace(repository.GetNamespace()))
+	if utils.IsCRDNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for i := range charts.Items {
+		ref := charts.Items[i].Spec.SourceRef
+		if ref.Kind != sourcev1.HelmRepositoryKind || ref.Name != repository.GetName() {
+			continue
+		}
+		if err := r.localClient.Delete(ctx, &charts.Items[i]); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (r *FluxReconciler) deleteManifesto(ctx context.Context, fluxComponent FluxComponent) error {
@@ -195,6 +306,38 @@ func (r *FluxReconciler) PreUpdate(ctx context.Context, component juggler.Compon
 	return nil
 }
 
+// PostUninstall implements juggler.ComponentReconciler.
+func (r *FluxReconciler) PostUninstall(ctx context.Context, component juggler.Component) error {
+	if component.Hooks().PostUninstall != nil {
+		return component.Hooks().PostUninstall(ctx, r.remoteClient)
+	}
+	return nil
+}
+
+// PostInstall implements juggler.ComponentReconciler.
+func (r *FluxReconciler) PostInstall(ctx context.Context, component juggler.Component) error {
+	if component.Hooks().PostInstall != nil {
+		return component.Hooks().PostInstall(ctx, r.remoteClient)
+	}
+	return nil
+}
+
+// PostUpdate implements juggler.ComponentReconciler.
+func (r *FluxReconciler) PostUpdate(ctx context.Context, component juggler.Component) error {
+	if component.Hooks().PostUpdate != nil {
+		return component.Hooks().PostUpdate(ctx, r.remoteClient)
+	}
+	return nil
+}
+
+// PostHealthy implements juggler.ComponentReconciler.
+func (r *FluxReconciler) PostHealthy(ctx context.Context, component juggler.Component) error {
+	if component.Hooks().PostHealthy != nil {
+		return component.Hooks().PostHealthy(ctx, r.remoteClient)
+	}
+	return nil
+}
+
 func (r *FluxReconciler) installOrUpdate(ctx context.Context, component juggler.Component) error {
 	fluxComponent, ok := component.(FluxComponent)
 	if !ok {
@@ -256,7 +399,55 @@ func (r *FluxReconciler) installOrUpdateSource(ctx context.Context, fluxComponen
 
 	r.logger.Info(fmt.Sprintf("%T %s/%s %s", obj, obj.GetNamespace(), obj.GetName(), result))
 
-	return nil
+	return r.deleteReplacedSources(ctx, desired)
+}
+
+// Diff implements juggler.ComponentDiffer.
+func (r *FluxReconciler) Diff(ctx context.Context, component juggler.Component) (string, error) {
+	fluxComponent, ok := component.(FluxComponent)
+	if !ok {
+		return "", errNotFluxComponent
+	}
+
+	desiredSource, err := fluxComponent.BuildSourceRepository(ctx)
+	if err != nil {
+		return "", err
+	}
+	sourceDiff, err := r.diffResource(ctx, fluxComponent, desiredSource.Empty(), desiredSource)
+	if err != nil {
+		return "", err
+	}
+
+	desiredManifesto, err := fluxComponent.BuildManifesto(ctx)
+	if err != nil {
+		return "", err
+	}
+	manifestoDiff, err := r.diffResource(ctx, fluxComponent, desiredManifesto.Empty(), desiredManifesto)
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%s\n%s", sourceDiff, manifestoDiff)), nil
+}
+
+// diffResource fetches `actual` and returns the changes that `installOrUpdate` would apply to it.
+func (r *FluxReconciler) diffResource(ctx context.Context, fluxComponent FluxComponent, actual, desired FluxResource) (string, error) {
+	obj := actual.GetObject()
+	if err := r.localClient.Get(ctx, actual.GetObjectKey(), obj); client.IgnoreNotFound(err) != nil {
+		return "", err
+	}
+
+	current := obj.DeepCopyObject()
+	if err := actual.Reconcile(desired); err != nil {
+		return "", err
+	}
+	utils.SetLabels(obj, r.labelFunc(fluxComponent))
+
+	diff, err := utils.DiffObjects(current, obj)
+	if err != nil || diff == "" {
+		return "", err
+	}
+	return fmt.Sprintf("%T %s/%s:\n%s", obj, obj.GetNamespace(), obj.GetName(), diff), nil
 }
 
 func aggregateHealthiness(states ...juggler.ResourceHealthiness) juggler.ResourceHealthiness {
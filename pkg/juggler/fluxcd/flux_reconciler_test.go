@@ -8,10 +8,12 @@ import (
 	"testing"
 
 	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
 	"github.com/go-logr/logr"
 	"github.com/google/go-cmp/cmp"
 	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -534,6 +536,42 @@ func TestFluxReconciler_PreUpdate(t *testing.T) {
 	}
 }
 
+func TestFluxReconciler_PostHooks(t *testing.T) {
+	r := &FluxReconciler{}
+	hook := func(err error) func(ctx context.Context, client client.Client) error {
+		return func(ctx context.Context, client client.Client) error {
+			return err
+		}
+	}
+	tests := []struct {
+		name     string
+		call     func(ctx context.Context, component juggler.Component) error
+		hooks    juggler.ComponentHooks
+		expected error
+	}{
+		{name: "no PostUninstall hook", call: r.PostUninstall},
+		{name: "PostUninstall error", call: r.PostUninstall, hooks: juggler.ComponentHooks{PostUninstall: hook(errBoom)}, expected: errBoom},
+		{name: "PostUninstall no error", call: r.PostUninstall, hooks: juggler.ComponentHooks{PostUninstall: hook(nil)}},
+		{name: "no PostInstall hook", call: r.PostInstall},
+		{name: "PostInstall error", call: r.PostInstall, hooks: juggler.ComponentHooks{PostInstall: hook(errBoom)}, expected: errBoom},
+		{name: "PostInstall no error", call: r.PostInstall, hooks: juggler.ComponentHooks{PostInstall: hook(nil)}},
+		{name: "no PostUpdate hook", call: r.PostUpdate},
+		{name: "PostUpdate error", call: r.PostUpdate, hooks: juggler.ComponentHooks{PostUpdate: hook(errBoom)}, expected: errBoom},
+		{name: "PostUpdate no error", call: r.PostUpdate, hooks: juggler.ComponentHooks{PostUpdate: hook(nil)}},
+		{name: "no PostHealthy hook", call: r.PostHealthy},
+		{name: "PostHealthy error", call: r.PostHealthy, hooks: juggler.ComponentHooks{PostHealthy: hook(errBoom)}, expected: errBoom},
+		{name: "PostHealthy no error", call: r.PostHealthy, hooks: juggler.ComponentHooks{PostHealthy: hook(nil)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.call(context.TODO(), FakeFluxComponent{HookFunc: tt.hooks})
+			if !errors.Is(actual, tt.expected) {
+				t.Errorf("FluxReconciler post hook = %v, want %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
 func TestFluxReconciler_Uninstall(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -740,6 +778,63 @@ func TestFluxReconciler_Install(t *testing.T) {
 			},
 			expected: nil,
 		},
+		{
+			name: "OCIRepository and Kustomization - creation successful",
+			obj: FakeFluxComponent{
+				BuildSourceRepositoryFunc: func(ctx context.Context) (SourceAdapter, error) {
+					return &OCIRepositoryAdapter{
+						Source: &sourcev1.OCIRepository{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test",
+								Namespace: "default",
+							},
+							Spec: sourcev1.OCIRepositorySpec{
+								URL: "oci://registry.example.com/manifests",
+							},
+						},
+					}, nil
+				},
+				BuildManifestoFunc: func(ctx context.Context) (Manifesto, error) {
+					return &KustomizationManifesto{
+						Manifest: &kustomizev1.Kustomization{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "test",
+								Namespace: "default",
+							},
+							Spec: kustomizev1.KustomizationSpec{
+								SourceRef: kustomizev1.CrossNamespaceSourceReference{
+									Kind: sourcev1.OCIRepositoryKind,
+									Name: "test",
+								},
+								Path: "./deploy",
+							},
+						},
+					}, nil
+				},
+				GetNameFunc: "FakeFluxComponent",
+			},
+			validateFunc: func(ctx context.Context, c client.Client, component juggler.Component) error {
+				ociRepository := &sourcev1.OCIRepository{}
+				if err := c.Get(ctx, client.ObjectKey{Name: "test", Namespace: "default"}, ociRepository); err != nil {
+					return err
+				}
+				kustomization := &kustomizev1.Kustomization{}
+				if err := c.Get(ctx, client.ObjectKey{Name: "test", Namespace: "default"}, kustomization); err != nil {
+					return err
+				}
+				if !assert.Equal(t, "./deploy", kustomization.Spec.Path) {
+					return errors.New("path not equal")
+				}
+				if !assert.Equal(t, kustomization.GetLabels(), map[string]string{
+					"app.kubernetes.io/managed-by": "control-plane-operator",
+					testLabelComponentKey:          component.GetName(),
+				}) {
+					return errors.New("labels not equal")
+				}
+				return nil
+			},
+			expected: nil,
+		},
 		{
 			name: "FluxReconciler with custom label func - creation successful",
 			labelFunc: func(comp juggler.Component) map[string]string {
@@ -820,6 +915,155 @@ func TestFluxReconciler_Install(t *testing.T) {
 	}
 }
 
+func TestFluxReconciler_SourceKindChanged(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{Name: "test", Namespace: "default"}
+	component := FakeFluxComponent{
+		BuildSourceRepositoryFunc: func(ctx context.Context) (SourceAdapter, error) {
+			return &OCIRepositoryAdapter{Source: &sourcev1.OCIRepository{
+				ObjectMeta: objectMeta,
+				Spec:       sourcev1.OCIRepositorySpec{URL: "oci://registry.example.com/charts/test"},
+			}}, nil
+		},
+		BuildManifestoFunc: func(ctx context.Context) (Manifesto, error) {
+			return &HelmReleaseManifesto{Manifest: &helmv2.HelmRelease{ObjectMeta: objectMeta}}, nil
+		},
+	}
+	helmChart := func(name, repository string) *sourcev1.HelmChart {
+		return &sourcev1.HelmChart{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: sourcev1.HelmChartSpec{
+				SourceRef: sourcev1.LocalHelmChartSourceReference{Kind: sourcev1.HelmRepositoryKind, Name: repository},
+			},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&sourcev1.HelmRepository{ObjectMeta: objectMeta},
+		helmChart("default-test", "test"),
+		helmChart("default-other", "other"),
+	).Build()
+	r := NewFluxReconciler(logr.Logger{}, c, nil, testLabelComponentKey)
+	ctx := context.Background()
+
+	// the HelmRepository and its HelmChart are replaced by the OCIRepository
+	assert.NoError(t, r.Install(ctx, component))
+	assert.NoError(t, c.Get(ctx, client.ObjectKeyFromObject(&sourcev1.OCIRepository{ObjectMeta: objectMeta}), &sourcev1.OCIRepository{}))
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(&sourcev1.HelmRepository{ObjectMeta: objectMeta}), &sourcev1.HelmRepository{})))
+	charts := &sourcev1.HelmChartList{}
+	assert.NoError(t, c.List(ctx, charts))
+	assert.Len(t, charts.Items, 1)
+	assert.Equal(t, "default-other", charts.Items[0].Name)
+
+	// both kinds are deleted on uninstall
+	assert.NoError(t, c.Create(ctx, &sourcev1.HelmRepository{ObjectMeta: objectMeta}))
+	assert.NoError(t, r.Uninstall(ctx, component))
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(&sourcev1.OCIRepository{ObjectMeta: objectMeta}), &sourcev1.OCIRepository{})))
+	assert.True(t, apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(&sourcev1.HelmRepository{ObjectMeta: objectMeta}), &sourcev1.HelmRepository{})))
+}
+
+func TestFluxReconciler_DetectOrphanedComponents(t *testing.T) {
+	helmRelease := func(name string, labels map[string]string) *helmv2.HelmRelease {
+		return &helmv2.HelmRelease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				Labels:    labels,
+			},
+		}
+	}
+	fakeLocalClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		helmRelease("configured", map[string]string{fakeOrphanLabel: "true"}),
+		helmRelease("orphaned", map[string]string{fakeOrphanLabel: "true"}),
+		helmRelease("unrelated", nil),
+	).Build()
+
+	r := NewFluxReconciler(logr.Logger{}, fakeLocalClient, nil, testLabelComponentKey)
+	r.RegisterType(FakeFluxComponent{}, FakeOrphanFluxComponent{})
+
+	orphaned, err := r.DetectOrphanedComponents(context.TODO(), []juggler.Component{
+		FakeOrphanFluxComponent{FakeFluxComponent{GetNameFunc: "configured", IsEnabledFunc: true}},
+		// components of other types never match
+		FakeFluxComponent{GetNameFunc: "orphaned", IsEnabledFunc: true},
+	})
+	assert.NoError(t, err)
+	if assert.Len(t, orphaned, 1) {
+		assert.Equal(t, "orphaned", orphaned[0].GetName())
+		assert.False(t, orphaned[0].IsEnabled())
+	}
+}
+
+func TestFluxReconciler_Diff(t *testing.T) {
+	component := func(url string) FakeFluxComponent {
+		return FakeFluxComponent{
+			BuildSourceRepositoryFunc: func(ctx context.Context) (SourceAdapter, error) {
+				return &HelmRepositoryAdapter{
+					Source: &sourcev1.HelmRepository{
+						ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+						Spec:       sourcev1.HelmRepositorySpec{URL: url},
+					},
+				}, nil
+			},
+			BuildManifestoFunc: func(ctx context.Context) (Manifesto, error) {
+				return &HelmReleaseManifesto{
+					Manifest: &helmv2.HelmRelease{
+						ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default"},
+						Spec:       helmv2.HelmReleaseSpec{ReleaseName: "test-name"},
+					},
+				}, nil
+			},
+			GetNameFunc: "FakeFluxComponent",
+		}
+	}
+
+	tests := []struct {
+		name         string
+		installed    juggler.Component
+		obj          juggler.Component
+		wantContains []string
+		wantErr      error
+	}{
+		{
+			name:    "Not a FluxComponent",
+			obj:     FakeComponent{},
+			wantErr: errNotFluxComponent,
+		},
+		{
+			name:      "No changes",
+			installed: component("test-url"),
+			obj:       component("test-url"),
+		},
+		{
+			name:         "Source changed",
+			installed:    component("test-url"),
+			obj:          component("other-url"),
+			wantContains: []string{"*v1.HelmRepository default/test", "test-url", "other-url"},
+		},
+		{
+			name:         "Not installed",
+			obj:          component("test-url"),
+			wantContains: []string{"*v1.HelmRepository default/test", "*v2.HelmRelease default/test", "test-name"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeLocalClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			r := NewFluxReconciler(logr.Logger{}, fakeLocalClient, nil, testLabelComponentKey)
+			ctx := context.TODO()
+			if tt.installed != nil {
+				assert.NoError(t, r.Install(ctx, tt.installed))
+			}
+
+			diff, err := r.Diff(ctx, tt.obj)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if len(tt.wantContains) == 0 {
+				assert.Empty(t, diff)
+			}
+			for _, s := range tt.wantContains {
+				assert.Contains(t, diff, s)
+			}
+		})
+	}
+}
+
 func Test_FluxReconciler_Types(t *testing.T) {
 	r := NewFluxReconciler(logr.Logger{}, nil, nil, "")
 	r.RegisterType(FakeFluxComponent{}, FakeFluxComponent{})
@@ -16,6 +16,7 @@ import (
 var (
 	errNotAHelmRepositoryAdapter = errors.New("FluxResource is not a HelmRepositoryAdapter")
 	errNotAGitRepositoryAdapter  = errors.New("FluxResource is not a GitRepositoryAdapter")
+	errNotAnOCIRepositoryAdapter = errors.New("FluxResource is not an OCIRepositoryAdapter")
 )
 
 const (
@@ -226,7 +227,7 @@ func (o *OCIRepositoryAdapter) GetObjectKey() client.ObjectKey {
 func (o *OCIRepositoryAdapter) Reconcile(desired FluxResource) error {
 	desiredAdapter, ok := desired.(*OCIRepositoryAdapter)
 	if !ok {
-		return errNotAGitRepositoryAdapter
+		return errNotAnOCIRepositoryAdapter
 	}
 
 	preserved := o.Source.Spec.DeepCopy()
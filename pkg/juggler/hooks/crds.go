@@ -0,0 +1,29 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openmcp-project/control-plane-operator/pkg/utils"
+)
+
+var ErrCRDNotInstalled = errors.New("CRD is not installed yet")
+
+// RequireCRD can be used as a pre-install hook to wait until the CRD of the given GroupVersionKind is installed.
+func RequireCRD(gvk schema.GroupVersionKind) func(ctx context.Context, c client.Client) error {
+	return func(ctx context.Context, c client.Client) error {
+		list := &unstructured.UnstructuredList{}
+		list.SetGroupVersionKind(gvk)
+
+		err := c.List(ctx, list, client.Limit(1))
+		if utils.IsCRDNotFound(err) {
+			return fmt.Errorf("%w: %s", ErrCRDNotInstalled, gvk)
+		}
+		return err
+	}
+}
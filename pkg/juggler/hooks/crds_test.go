@@ -0,0 +1,64 @@
+package hooks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var errFakeList = errors.New("some unknown error")
+
+func Test_RequireCRD(t *testing.T) {
+	testCases := []struct {
+		desc             string
+		gvk              schema.GroupVersionKind
+		interceptorFuncs interceptor.Funcs
+		expectedErr      error
+	}{
+		{
+			desc: "should not return error when CRD is installed",
+			gvk:  schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Secret"},
+		},
+		{
+			desc: "should return error when CRD is not installed",
+			gvk:  schema.GroupVersionKind{Group: "aws.upbound.io", Version: "v1beta1", Kind: "ProviderConfig"},
+			interceptorFuncs: interceptor.Funcs{
+				List: func(ctx context.Context, client client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+					return &apiutil.ErrResourceDiscoveryFailed{
+						list.GetObjectKind().GroupVersionKind().GroupVersion(): apierrors.NewNotFound(schema.GroupResource{}, ""),
+					}
+				},
+			},
+			expectedErr: ErrCRDNotInstalled,
+		},
+		{
+			desc: "should return error when API server returns unknown error",
+			gvk:  schema.GroupVersionKind{Group: "", Version: "v1", Kind: "Secret"},
+			interceptorFuncs: interceptor.Funcs{
+				List: func(ctx context.Context, client client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+					return errFakeList
+				},
+			},
+			expectedErr: errFakeList,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithInterceptorFuncs(tC.interceptorFuncs).Build()
+			err := RequireCRD(tC.gvk)(context.Background(), c)
+			if tC.expectedErr != nil {
+				assert.ErrorIs(t, err, tC.expectedErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
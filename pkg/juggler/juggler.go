@@ -6,10 +6,15 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-logr/logr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 
 	"github.com/openmcp-project/control-plane-operator/internal/ocm"
+	"github.com/openmcp-project/control-plane-operator/internal/tracing"
 	"github.com/openmcp-project/control-plane-operator/pkg/utils/rcontext"
 )
 
@@ -18,6 +23,8 @@ var (
 	errTooManyReconcilers           = errors.New("more than one reconciler available for component")
 	errDependencyNotRegistered      = errors.New("one or more dependencies are not registered")
 	errDependencyNotEnabled         = errors.New("one or more dependencies are registered but not enabled")
+	errDependencyCycle              = errors.New("dependency cycle detected")
+	errDependencyNotReady           = errors.New("dependency is not ready")
 	errHookFailed                   = errors.New("hook failed")
 )
 
@@ -28,15 +35,59 @@ func NewJuggler(logger logr.Logger, recorder EventRecorder) *Juggler {
 		components:  []Component{},
 		reconcilers: []ComponentReconciler{},
 		recorder:    recorder,
+		maxWorkers:  1,
+		now:         time.Now,
 	}
 }
 
+// WithMaxConcurrentReconciles sets the number of components that may be reconciled at the same time.
+// Components are only reconciled concurrently if they don't depend on each other. Defaults to 1.
+func (am *Juggler) WithMaxConcurrentReconciles(n int) *Juggler {
+	if n > 0 {
+		am.maxWorkers = n
+	}
+	return am
+}
+
+// WithReadinessHistory sets the function used to determine if a component has been ready after the previous
+// reconciliation, which decides whether the PostHealthy hook of a healthy component is called. If a failed hook
+// leaves the component not ready (e.g. ReadinessFromConditions), the hook is retried in the next reconciliation.
+// If not set, a component is considered to have been ready if it was healthy before being updated.
+func (am *Juggler) WithReadinessHistory(history ReadinessHistory) *Juggler {
+	am.readinessHistory = history
+	return am
+}
+
+// WithRetryBackoff enables tracking of consecutive failures per component. A component that failed
+// is not reconciled again before the delay returned by `backoff` has passed. The RetryState after
+// the previous reconciliation is read from `history`. Passing a nil `backoff` disables the tracking.
+// Missing dependencies and components that are not allowed are not backed off.
+func (am *Juggler) WithRetryBackoff(backoff RetryBackoff, history RetryHistory) *Juggler {
+	am.retryBackoff = backoff
+	am.retryHistory = history
+	return am
+}
+
+// WithPhaseObserver sets a function that is called with the duration of every phase
+// (see ReconcilePhase) of the reconciliation of a component. Planning is not observed.
+// Independent of the PhaseObserver, a tracing span is started for every phase.
+func (am *Juggler) WithPhaseObserver(observer PhaseObserver) *Juggler {
+	am.phaseObserver = observer
+	return am
+}
+
 // Juggler manages components.
 type Juggler struct {
-	logger      logr.Logger
-	components  []Component
-	reconcilers []ComponentReconciler
-	recorder    EventRecorder
+	logger           logr.Logger
+	components       []Component
+	reconcilers      []ComponentReconciler
+	recorder         EventRecorder
+	maxWorkers       int
+	readinessHistory ReadinessHistory
+	retryBackoff     RetryBackoff
+	retryHistory     RetryHistory
+	phaseObserver    PhaseObserver
+	now              func() time.Time
 }
 
 // RegisterComponent makes the Juggler aware of new components.
@@ -73,19 +124,126 @@ func (am *Juggler) RegisteredComponents() int {
 // Reconcile compares the current and desired state for each registered
 // component and takes measures to reach the desired state if necessary.
 // The implementation is inspired by Crossplanes Managed resource reconciler
+//
+// Enabled components are installed or updated in dependency order, disabled components
+// are uninstalled in reverse dependency order. Components that are part of a dependency
+// cycle are not reconciled at all. Components without mutual dependencies may be reconciled
+// concurrently (see WithMaxConcurrentReconciles). Components that failed before are skipped until
+// their next retry (see WithRetryBackoff). The returned results are always in registration order.
 func (am *Juggler) Reconcile(ctx context.Context) []ComponentResult {
-	results := make([]ComponentResult, 0, len(am.components))
-
-	for _, component := range am.components {
-		cr := am.reconcileComponent(ctx, component)
-		NewComponentEventRecorder(am.recorder, component).Event(cr.Result, cr.Message)
-		results = append(results, cr)
+	results := make([]ComponentResult, len(am.components))
+
+	graph := newDependencyGraph(am.components)
+	sorted, cyclic := graph.sort()
+
+	if len(cyclic) > 0 {
+		message := am.cycleError(cyclic).Error()
+		for _, i := range cyclic {
+			results[i] = am.recordResult(ComponentResult{
+				Component: am.components[i],
+				Result:    StatusDependencyCheckFailed,
+				Message:   message,
+			})
+		}
 	}
 
+	am.forEachInDependencyOrder(graph, sorted, func(i int) {
+		results[i] = am.recordResult(am.reconcileWithRetry(ctx, am.components[i], results))
+	})
+
 	return results
 }
 
-func (am *Juggler) reconcileComponent(ctx context.Context, component Component) ComponentResult {
+// recordResult emits an event for the given ComponentResult and returns it unchanged.
+func (am *Juggler) recordResult(cr ComponentResult) ComponentResult {
+	NewComponentEventRecorder(am.recorder, cr.Component).Event(cr.Result, cr.Message)
+	return cr
+}
+
+// cycleError returns an error naming all components that are part of a dependency cycle.
+func (am *Juggler) cycleError(cyclic []int) error {
+	names := make([]string, 0, len(cyclic))
+	for _, i := range cyclic {
+		names = append(names, am.components[i].GetName())
+	}
+	return fmt.Errorf("%w: %s", errDependencyCycle, strings.Join(names, ", "))
+}
+
+// forEachInDependencyOrder calls `fn` for every component index in `sorted`.
+// Disabled components are processed first, dependents before their dependencies.
+// Enabled components are processed afterwards, dependencies before their dependents.
+func (am *Juggler) forEachInDependencyOrder(graph *dependencyGraph, sorted []int, fn func(i int)) {
+	uninstall := make([]int, 0, len(sorted))
+	install := make([]int, 0, len(sorted))
+	for k := range sorted {
+		if i := sorted[len(sorted)-1-k]; !am.components[i].IsEnabled() {
+			uninstall = append(uninstall, i)
+		}
+		if i := sorted[k]; am.components[i].IsEnabled() {
+			install = append(install, i)
+		}
+	}
+
+	am.runInOrder(uninstall, func(i int) []int { return graph.dependents[i] }, fn)
+	am.runInOrder(install, func(i int) []int { return graph.dependencies[i] }, fn)
+}
+
+// runInOrder calls `reconcile` for every component index in `order`. A component is only
+// started after all components returned by `waitFor` which are also part of `order` are done.
+// At most `maxWorkers` components are reconciled at the same time.
+func (am *Juggler) runInOrder(order []int, waitFor func(i int) []int, reconcile func(i int)) {
+	if am.maxWorkers <= 1 {
+		for _, i := range order {
+			reconcile(i)
+		}
+		return
+	}
+
+	done := make(map[int]chan struct{}, len(order))
+	for _, i := range order {
+		done[i] = make(chan struct{})
+	}
+
+	workers := make(chan struct{}, am.maxWorkers)
+	wg := sync.WaitGroup{}
+	for _, i := range order {
+		wg.Go(func() {
+			defer close(done[i])
+			for _, j := range waitFor(i) {
+				if ch, ok := done[j]; ok {
+					<-ch
+				}
+			}
+
+			workers <- struct{}{}
+			defer func() { <-workers }()
+			reconcile(i)
+		})
+	}
+	wg.Wait()
+}
+
+// reconcileComponent reconciles a single component. The `results` of the current reconciliation pass
+// (indexed like the registered components) are used to check if the dependencies of the component are ready.
+func (am *Juggler) reconcileComponent(ctx context.Context, component Component, results []ComponentResult) (cr ComponentResult) {
+	ctx, span := tracing.Start(ctx, "Juggler.reconcileComponent",
+		attribute.String("component.name", component.GetName()),
+		attribute.String("component.kind", ComponentKind(component)),
+	)
+
+	// Report the installed version of the latest observation and the resolved version with every result
+	var observation ComponentObservation
+	defer func() {
+		cr.ObservedVersion = observation.Version
+		cr.ResolvedVersion = resolvedVersion(ctx, component)
+
+		span.SetAttributes(attribute.String("component.status", cr.Result.Name))
+		if cr.Result.IsFailure() {
+			span.SetStatus(codes.Error, cr.Message)
+		}
+		span.End()
+	}()
+
 	// Find reconciler for component
 	reconciler, err := am.findReconcilerFor(component)
 	if err != nil {
@@ -95,9 +253,10 @@ func (am *Juggler) reconcileComponent(ctx context.Context, component Component)
 			Message:   err.Error(),
 		}
 	}
+	reconciler = &instrumentedReconciler{ComponentReconciler: reconciler, observer: am.phaseObserver, now: am.now}
 
 	// Observe Component
-	observation, err := reconciler.Observe(ctx, component)
+	observation, err = reconciler.Observe(ctx, component)
 	if err != nil {
 		return ComponentResult{
 			Component: component,
@@ -134,6 +293,15 @@ func (am *Juggler) reconcileComponent(ctx context.Context, component Component)
 			}
 		}
 
+		if err := reconciler.PostUninstall(ctx, component); err != nil {
+			wrappedErr := errors.Join(errHookFailed, err).Error()
+			return ComponentResult{
+				Component: component,
+				Result:    StatusPostUninstallHookFailed,
+				Message:   wrappedErr,
+			}
+		}
+
 		return ComponentResult{
 			Component: component,
 			Result:    StatusUninstalled,
@@ -183,6 +351,14 @@ func (am *Juggler) reconcileComponent(ctx context.Context, component Component)
 		}
 	}
 
+	if err := am.checkDependenciesReady(component, results); err != nil {
+		return ComponentResult{
+			Component: component,
+			Result:    StatusDependencyNotReady,
+			Message:   err.Error(),
+		}
+	}
+
 	// Resource does not exist but is enabled, then install
 	if !observation.ResourceExists {
 		if err := reconciler.PreInstall(ctx, component); err != nil {
@@ -202,6 +378,15 @@ func (am *Juggler) reconcileComponent(ctx context.Context, component Component)
 				Message:   err.Error(),
 			}
 		}
+
+		if err := reconciler.PostInstall(ctx, component); err != nil {
+			wrappedErr := errors.Join(errHookFailed, err).Error()
+			return ComponentResult{
+				Component: component,
+				Result:    StatusPostInstallHookFailed,
+				Message:   wrappedErr,
+			}
+		}
 		return ComponentResult{
 			Component: component,
 			Result:    StatusInstalled,
@@ -218,6 +403,8 @@ func (am *Juggler) reconcileComponent(ctx context.Context, component Component)
 		}
 	}
 
+	wasReady := am.wasReady(component, observation)
+
 	// Always run update. Should be a no-op if component is already up-to-date.
 	err = reconciler.Update(ctx, component)
 	if err != nil {
@@ -228,6 +415,15 @@ func (am *Juggler) reconcileComponent(ctx context.Context, component Component)
 		}
 	}
 
+	if err := reconciler.PostUpdate(ctx, component); err != nil {
+		wrappedErr := errors.Join(errHookFailed, err).Error()
+		return ComponentResult{
+			Component: component,
+			Result:    StatusPostUpdateHookFailed,
+			Message:   wrappedErr,
+		}
+	}
+
 	// Observe Component again after updating it
 	observation, err = reconciler.Observe(ctx, component)
 	if err != nil {
@@ -264,6 +460,18 @@ func (am *Juggler) reconcileComponent(ctx context.Context, component Component)
 		}
 	}
 
+	// Resource turned healthy
+	if !wasReady {
+		if err := reconciler.PostHealthy(ctx, component); err != nil {
+			wrappedErr := errors.Join(errHookFailed, err).Error()
+			return ComponentResult{
+				Component: component,
+				Result:    StatusPostHealthyHookFailed,
+				Message:   wrappedErr,
+			}
+		}
+	}
+
 	// Resource is healthy
 	return ComponentResult{
 		Component: component,
@@ -272,6 +480,15 @@ func (am *Juggler) reconcileComponent(ctx context.Context, component Component)
 	}
 }
 
+// wasReady returns whether the component has been ready after the previous reconciliation.
+// Without a ReadinessHistory, the given observation (made before updating the component) is used instead.
+func (am *Juggler) wasReady(component Component, observation ComponentObservation) bool {
+	if am.readinessHistory != nil {
+		return am.readinessHistory(component)
+	}
+	return observation.Healthy
+}
+
 func (am *Juggler) findReconcilerFor(component Component) (ComponentReconciler, error) {
 	var selectedReconciler ComponentReconciler
 	for _, cr := range am.reconcilers {
@@ -292,7 +509,7 @@ func (am *Juggler) findReconcilerFor(component Component) (ComponentReconciler,
 
 func (am *Juggler) findRegisteredComponent(sample Component) Component {
 	for _, registered := range am.components {
-		if reflect.TypeOf(registered) == reflect.TypeOf(sample) {
+		if isDependency(registered, sample) {
 			return registered
 		}
 	}
@@ -312,6 +529,27 @@ func (am *Juggler) checkDependencies(component Component) error {
 	return nil
 }
 
+// checkDependenciesReady verifies that every registered component a component depends on
+// has been reconciled successfully in the current pass and reports a ready status.
+func (am *Juggler) checkDependenciesReady(component Component, results []ComponentResult) error {
+	for _, dep := range component.GetDependencies() {
+		for i, registered := range am.components {
+			if !isDependency(registered, dep) {
+				continue
+			}
+			status := "NotReconciled"
+			if i < len(results) && results[i].Component != nil {
+				if results[i].Result.IsReady {
+					continue
+				}
+				status = results[i].Result.Name
+			}
+			return fmt.Errorf("%w: %s (%s)", errDependencyNotReady, registered.GetName(), status)
+		}
+	}
+	return nil
+}
+
 func (am *Juggler) componentsOfReconciler(r ComponentReconciler) []Component {
 	configuredComponents := []Component{}
 	for _, cc := range am.components {
@@ -5,7 +5,9 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"k8s.io/client-go/tools/events"
 
@@ -205,6 +207,71 @@ func TestJuggler_Reconcile(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "dependency not ready",
+			fields: fields{
+				components: []Component{
+					FakeComponent{Enabled: true, Dependencies: []Component{FakeComponent2{}}, Allowed: true},
+					FakeComponent2{Enabled: true, Allowed: true},
+				},
+				reconcilers: []ComponentReconciler{
+					FakeReconciler{
+						KnownTypesFunc: knowsAll(),
+						ObserverFunc: func(ctx context.Context, component Component) (ComponentObservation, error) {
+							return ComponentObservation{
+								ResourceExists: true,
+								ResourceHealthiness: ResourceHealthiness{
+									Healthy: false,
+									Message: "not healthy",
+								},
+							}, nil
+						},
+					}},
+			},
+			want: []ComponentResult{
+				{
+					Component: FakeComponent{Enabled: true, Dependencies: []Component{FakeComponent2{}}, Allowed: true},
+					Result:    StatusDependencyNotReady,
+					Message:   fmt.Sprintf("%s: FakeComponent2 (Unhealthy)", errDependencyNotReady),
+				},
+				{
+					Component: FakeComponent2{Enabled: true, Allowed: true},
+					Result:    StatusUnhealthy,
+					Message:   "not healthy",
+				},
+			},
+		},
+		{
+			name: "dependency just installed",
+			fields: fields{
+				components: []Component{
+					FakeComponent{Enabled: true, Dependencies: []Component{FakeComponent2{}}, Allowed: true},
+					FakeComponent2{Enabled: true, Allowed: true},
+				},
+				reconcilers: []ComponentReconciler{
+					FakeReconciler{
+						KnownTypesFunc: knowsAll(),
+						ObserverFunc: func(ctx context.Context, component Component) (ComponentObservation, error) {
+							return ComponentObservation{ResourceExists: false}, nil
+						},
+						InstallFunc: func(ctx context.Context, component Component) error {
+							return nil
+						},
+					}},
+			},
+			want: []ComponentResult{
+				{
+					Component: FakeComponent{Enabled: true, Dependencies: []Component{FakeComponent2{}}, Allowed: true},
+					Result:    StatusDependencyNotReady,
+					Message:   fmt.Sprintf("%s: FakeComponent2 (Installed)", errDependencyNotReady),
+				},
+				{
+					Component: FakeComponent2{Enabled: true, Allowed: true},
+					Result:    StatusInstalled,
+					Message:   "FakeComponent2 has been installed successfully.",
+				},
+			},
+		},
 		{
 			name: "error, component is not allowed to be installed",
 			fields: fields{
@@ -245,6 +312,160 @@ func TestJuggler_Reconcile(t *testing.T) {
 	}
 }
 
+func TestJuggler_Reconcile_DependencyOrder(t *testing.T) {
+	dependent := FakeComponent{Name: "Dependent", Dependencies: []Component{FakeComponent2{}}, Allowed: true}
+	dependency := FakeComponent2{Name: "Dependency", Allowed: true}
+
+	tests := []struct {
+		name        string
+		enabled     bool
+		exists      bool
+		wantActions []string
+	}{
+		{
+			name:        "install dependency first, dependent waits until dependency is ready",
+			enabled:     true,
+			exists:      false,
+			wantActions: []string{"install Dependency"},
+		},
+		{
+			name:        "update dependency first",
+			enabled:     true,
+			exists:      true,
+			wantActions: []string{"update Dependency", "update Dependent"},
+		},
+		{
+			name:        "uninstall dependent first",
+			enabled:     false,
+			exists:      true,
+			wantActions: []string{"uninstall Dependent", "uninstall Dependency"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actions := []string{}
+			record := func(action string) func(ctx context.Context, component Component) error {
+				return func(ctx context.Context, component Component) error {
+					actions = append(actions, action+" "+component.GetName())
+					return nil
+				}
+			}
+
+			dependent.Enabled = tt.enabled
+			dependency.Enabled = tt.enabled
+
+			cp := v1beta1.ControlPlane{}
+			am := NewJuggler(testr.New(t), &ObjectEventRecorder{
+				recorder: events.NewFakeRecorder(3),
+				object:   &cp,
+			})
+			am.RegisterComponent(dependent, dependency)
+			am.RegisterReconciler(FakeReconciler{
+				KnownTypesFunc: knowsAll(),
+				ObserverFunc: func(ctx context.Context, component Component) (ComponentObservation, error) {
+					return ComponentObservation{
+						ResourceExists:      tt.exists,
+						ResourceHealthiness: ResourceHealthiness{Healthy: tt.exists},
+					}, nil
+				},
+				InstallFunc:   record("install"),
+				UpdateFunc:    record("update"),
+				UninstallFunc: record("uninstall"),
+			})
+
+			result := am.Reconcile(context.TODO())
+			assert.Equal(t, tt.wantActions, actions)
+			// results are returned in registration order
+			assert.Len(t, result, 2)
+			assert.Equal(t, dependent, result[0].Component)
+			assert.Equal(t, dependency, result[1].Component)
+		})
+	}
+}
+
+func TestJuggler_Reconcile_DependencyCycle(t *testing.T) {
+	a := FakeComponent{Name: "A", Enabled: true, Allowed: true, Dependencies: []Component{FakeComponent2{}}}
+	b := FakeComponent2{Name: "B", Enabled: true, Allowed: true, Dependencies: []Component{FakeComponent{}}}
+
+	cp := v1beta1.ControlPlane{}
+	am := NewJuggler(testr.New(t), &ObjectEventRecorder{
+		recorder: events.NewFakeRecorder(3),
+		object:   &cp,
+	})
+	am.RegisterComponent(a, b)
+	am.RegisterReconciler(FakeReconciler{
+		KnownTypesFunc: knowsAll(),
+		ObserverFunc: func(ctx context.Context, component Component) (ComponentObservation, error) {
+			t.Fatal("components in a dependency cycle must not be observed")
+			return ComponentObservation{}, nil
+		},
+	})
+
+	message := fmt.Sprintf("%s: A, B", errDependencyCycle)
+	assert.Equal(t, []ComponentResult{
+		{Component: a, Result: StatusDependencyCheckFailed, Message: message},
+		{Component: b, Result: StatusDependencyCheckFailed, Message: message},
+	}, am.Reconcile(context.TODO()))
+}
+
+func TestJuggler_Reconcile_Concurrent(t *testing.T) {
+	const maxWorkers = 3
+
+	components := []Component{}
+	for i := range 10 {
+		components = append(components, FakeComponent{Name: fmt.Sprintf("Independent%d", i), Enabled: true, Allowed: true})
+	}
+	dependency := FakeComponent2{Name: "Dependency", Enabled: true, Allowed: true}
+	dependent := FakeComponent3{FakeComponent: FakeComponent{Name: "Dependent", Enabled: true, Dependencies: []Component{FakeComponent2{}}}}
+	components = append(components, dependent, dependency)
+
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	dependencyDone := false
+
+	cp := v1beta1.ControlPlane{}
+	am := NewJuggler(testr.New(t), &ObjectEventRecorder{
+		recorder: events.NewFakeRecorder(len(components)),
+		object:   &cp,
+	}).WithMaxConcurrentReconciles(maxWorkers)
+	am.RegisterComponent(components...)
+	am.RegisterReconciler(FakeReconciler{
+		KnownTypesFunc: knowsAll(),
+		ObserverFunc: func(ctx context.Context, component Component) (ComponentObservation, error) {
+			mu.Lock()
+			running++
+			maxRunning = max(maxRunning, running)
+			if component.GetName() == "Dependent" {
+				assert.True(t, dependencyDone, "dependent must not be reconciled before its dependency")
+			}
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			running--
+			if component.GetName() == "Dependency" {
+				dependencyDone = true
+			}
+			mu.Unlock()
+			return ComponentObservation{
+				ResourceExists:      true,
+				ResourceHealthiness: ResourceHealthiness{Healthy: true},
+			}, nil
+		},
+	})
+
+	result := am.Reconcile(context.TODO())
+
+	assert.LessOrEqual(t, maxRunning, maxWorkers)
+	assert.Greater(t, maxRunning, 1)
+	assert.Len(t, result, len(components))
+	for i, cr := range result {
+		assert.Equal(t, components[i], cr.Component)
+	}
+	assert.Equal(t, StatusHealthy, result[len(components)-1].Result)
+}
+
 func knowsAll() func() []reflect.Type {
 	return func() []reflect.Type {
 		return []reflect.Type{
@@ -501,6 +722,67 @@ func TestJuggler_reconcileComponent(t *testing.T) {
 				Message:   errors.Join(errHookFailed, errBoom).Error(),
 			},
 		},
+		{
+			name: "needs uninstall, post-uninstall hook failed", args: args{
+				component: FakeComponent{Enabled: false, Allowed: true},
+				reconciler: FakeReconciler{
+					KnownTypesFunc: knowsAll(),
+					ObserverFunc: func(ctx context.Context, component Component) (ComponentObservation, error) {
+						return ComponentObservation{ResourceExists: true}, nil
+					},
+					UninstallFunc: func(ctx context.Context, component Component) error {
+						return nil
+					},
+					PostUninstallFunc: func(ctx context.Context, component Component) error { return errBoom },
+				},
+			},
+			want: ComponentResult{
+				Component: FakeComponent{Enabled: false, Allowed: true},
+				Result:    StatusPostUninstallHookFailed,
+				Message:   errors.Join(errHookFailed, errBoom).Error(),
+			},
+		},
+		{
+			name: "needs install, post-install hook failed", args: args{
+				component: FakeComponent{Enabled: true, Allowed: true},
+				reconciler: FakeReconciler{
+					KnownTypesFunc: knowsAll(),
+					ObserverFunc: func(ctx context.Context, component Component) (ComponentObservation, error) {
+						return ComponentObservation{ResourceExists: false}, nil
+					},
+					InstallFunc: func(ctx context.Context, component Component) error {
+						return nil
+					},
+					PostInstallFunc: func(ctx context.Context, component Component) error {
+						return errBoom
+					},
+				},
+			},
+			want: ComponentResult{
+				Component: FakeComponent{Enabled: true, Allowed: true},
+				Result:    StatusPostInstallHookFailed,
+				Message:   errors.Join(errHookFailed, errBoom).Error(),
+			},
+		},
+		{
+			name: "needs update, post-update hook failed", args: args{
+				component: FakeComponent{Enabled: true, Allowed: true},
+				reconciler: FakeReconciler{
+					KnownTypesFunc: knowsAll(),
+					ObserverFunc: func(ctx context.Context, component Component) (ComponentObservation, error) {
+						return ComponentObservation{ResourceExists: true}, nil
+					},
+					PostUpdateFunc: func(ctx context.Context, component Component) error {
+						return errBoom
+					},
+				},
+			},
+			want: ComponentResult{
+				Component: FakeComponent{Enabled: true, Allowed: true},
+				Result:    StatusPostUpdateHookFailed,
+				Message:   errors.Join(errHookFailed, errBoom).Error(),
+			},
+		},
 		{
 			name: "is disabled, no-op", args: args{
 				component: FakeComponent{Enabled: false, Allowed: true},
@@ -616,6 +898,29 @@ func TestJuggler_reconcileComponent(t *testing.T) {
 				Message:   "Reconciliation of FakeComponent skipped due to skip-reconciliation annotation.",
 			},
 		},
+		{
+			name: "is healthy, reports observed version",
+			args: args{
+				component: FakeComponent{Enabled: true, Allowed: true},
+				reconciler: FakeReconciler{
+					KnownTypesFunc: knowsAll(),
+					ObserverFunc: func(ctx context.Context, component Component) (ComponentObservation, error) {
+						return ComponentObservation{
+							ResourceExists:      true,
+							ResourceHealthiness: ResourceHealthiness{Healthy: true},
+							ResourceSkipped:     true,
+							Version:             "1.2.3",
+						}, nil
+					},
+				},
+			},
+			want: ComponentResult{
+				Component:       FakeComponent{Enabled: true, Allowed: true},
+				Result:          StatusHealthyReconciliationSkipped,
+				Message:         "Reconciliation of FakeComponent skipped due to skip-reconciliation annotation.",
+				ObservedVersion: "1.2.3",
+			},
+		},
 		{
 			name: "is healthy and skipped",
 			args: args{
@@ -653,12 +958,88 @@ func TestJuggler_reconcileComponent(t *testing.T) {
 			ctx := rcontext.WithAvailableVersionsResolver(context.TODO(), func(componentName string) ([]string, error) {
 				return nil, nil
 			})
-			result := am.reconcileComponent(ctx, tt.args.component)
+			result := am.reconcileComponent(ctx, tt.args.component, nil)
 			assert.Equal(t, tt.want, result)
 		})
 	}
 }
 
+func TestJuggler_reconcileComponent_PostHealthy(t *testing.T) {
+	component := FakeComponent{Enabled: true, Allowed: true}
+	tests := []struct {
+		name           string
+		history        ReadinessHistory
+		healthyBefore  bool
+		hookErr        error
+		wantHookCalled bool
+		want           ComponentStatus
+	}{
+		{
+			name:           "no history, turned healthy during update",
+			healthyBefore:  false,
+			wantHookCalled: true,
+			want:           StatusHealthy,
+		},
+		{
+			name:           "no history, already healthy before update",
+			healthyBefore:  true,
+			wantHookCalled: false,
+			want:           StatusHealthy,
+		},
+		{
+			name:           "history, not ready before",
+			history:        func(Component) bool { return false },
+			healthyBefore:  true,
+			wantHookCalled: true,
+			want:           StatusHealthy,
+		},
+		{
+			name:           "history, ready before",
+			history:        func(Component) bool { return true },
+			healthyBefore:  false,
+			wantHookCalled: false,
+			want:           StatusHealthy,
+		},
+		{
+			name:           "hook failed",
+			history:        func(Component) bool { return false },
+			hookErr:        errBoom,
+			wantHookCalled: true,
+			want:           StatusPostHealthyHookFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			observations := 0
+			hookCalled := false
+
+			cp := v1beta1.ControlPlane{}
+			am := NewJuggler(testr.New(t), &ObjectEventRecorder{
+				recorder: events.NewFakeRecorder(3),
+				object:   &cp,
+			}).WithReadinessHistory(tt.history)
+			am.RegisterReconciler(FakeReconciler{
+				KnownTypesFunc: knowsAll(),
+				ObserverFunc: func(ctx context.Context, component Component) (ComponentObservation, error) {
+					observations++
+					return ComponentObservation{
+						ResourceExists:      true,
+						ResourceHealthiness: ResourceHealthiness{Healthy: observations > 1 || tt.healthyBefore},
+					}, nil
+				},
+				PostHealthyFunc: func(ctx context.Context, component Component) error {
+					hookCalled = true
+					return tt.hookErr
+				},
+			})
+
+			result := am.reconcileComponent(context.TODO(), component, nil)
+			assert.Equal(t, tt.want, result.Result)
+			assert.Equal(t, tt.wantHookCalled, hookCalled)
+		})
+	}
+}
+
 func Test_Juggler_RegisterOrphanedComponents(t *testing.T) {
 	juggler := NewJuggler(logr.Logger{}, nil)
 
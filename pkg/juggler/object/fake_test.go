@@ -83,6 +83,18 @@ func (f FakeObjectComponent) IsObjectHealthy(obj client.Object) juggler.Resource
 	return f.IsObjectHealthyFunc(obj)
 }
 
+var _ HealthInspector = FakeHealthInspectingComponent{}
+
+type FakeHealthInspectingComponent struct {
+	FakeObjectComponent
+	InspectObjectHealthFunc func(ctx context.Context, c client.Client, obj client.Object) juggler.ResourceHealthiness
+}
+
+// InspectObjectHealth implements HealthInspector.
+func (f FakeHealthInspectingComponent) InspectObjectHealth(ctx context.Context, c client.Client, obj client.Object) juggler.ResourceHealthiness {
+	return f.InspectObjectHealthFunc(ctx, c, obj)
+}
+
 // ---------------------------------------------------------------------------------------------------
 
 var _ juggler.Component = FakeComponent{}
@@ -121,14 +133,18 @@ func (f FakeComponent) Hooks() juggler.ComponentHooks {
 var _ juggler.ComponentReconciler = FakeReconciler{}
 
 type FakeReconciler struct {
-	ObserverFunc     func(ctx context.Context, component juggler.Component) (juggler.ComponentObservation, error)
-	UninstallFunc    func(ctx context.Context, component juggler.Component) error
-	UpdateFunc       func(ctx context.Context, component juggler.Component) error
-	InstallFunc      func(ctx context.Context, component juggler.Component) error
-	PreUninstallFunc func(ctx context.Context, component juggler.Component) error
-	PreInstallFunc   func(ctx context.Context, component juggler.Component) error
-	PreUpdateFunc    func(ctx context.Context, component juggler.Component) error
-	KnownTypesFunc   func() []reflect.Type
+	ObserverFunc      func(ctx context.Context, component juggler.Component) (juggler.ComponentObservation, error)
+	UninstallFunc     func(ctx context.Context, component juggler.Component) error
+	UpdateFunc        func(ctx context.Context, component juggler.Component) error
+	InstallFunc       func(ctx context.Context, component juggler.Component) error
+	PreUninstallFunc  func(ctx context.Context, component juggler.Component) error
+	PreInstallFunc    func(ctx context.Context, component juggler.Component) error
+	PreUpdateFunc     func(ctx context.Context, component juggler.Component) error
+	PostUninstallFunc func(ctx context.Context, component juggler.Component) error
+	PostInstallFunc   func(ctx context.Context, component juggler.Component) error
+	PostUpdateFunc    func(ctx context.Context, component juggler.Component) error
+	PostHealthyFunc   func(ctx context.Context, component juggler.Component) error
+	KnownTypesFunc    func() []reflect.Type
 }
 
 // KnownTypes implements juggler.ComponentReconciler.
@@ -160,6 +176,38 @@ func (f FakeReconciler) PreUpdate(ctx context.Context, component juggler.Compone
 	return f.PreUpdateFunc(ctx, component)
 }
 
+// PostUninstall implements juggler.ComponentReconciler.
+func (f FakeReconciler) PostUninstall(ctx context.Context, component juggler.Component) error {
+	if f.PostUninstallFunc == nil {
+		return nil
+	}
+	return f.PostUninstallFunc(ctx, component)
+}
+
+// PostInstall implements juggler.ComponentReconciler.
+func (f FakeReconciler) PostInstall(ctx context.Context, component juggler.Component) error {
+	if f.PostInstallFunc == nil {
+		return nil
+	}
+	return f.PostInstallFunc(ctx, component)
+}
+
+// PostUpdate implements juggler.ComponentReconciler.
+func (f FakeReconciler) PostUpdate(ctx context.Context, component juggler.Component) error {
+	if f.PostUpdateFunc == nil {
+		return nil
+	}
+	return f.PostUpdateFunc(ctx, component)
+}
+
+// PostHealthy implements juggler.ComponentReconciler.
+func (f FakeReconciler) PostHealthy(ctx context.Context, component juggler.Component) error {
+	if f.PostHealthyFunc == nil {
+		return nil
+	}
+	return f.PostHealthyFunc(ctx, component)
+}
+
 //nolint:lll
 func (f FakeReconciler) Observe(ctx context.Context, component juggler.Component) (juggler.ComponentObservation, error) {
 	return f.ObserverFunc(ctx, component)
@@ -51,3 +51,16 @@ type DetectorContext struct {
 type OrphanedObjectsDetector interface {
 	OrphanDetectorContext(_ context.Context) DetectorContext
 }
+
+// InstalledVersionDetector can be implemented by an ObjectComponent to report the installed version
+// of the component based on the reconciled object.
+type InstalledVersionDetector interface {
+	GetInstalledVersion(obj client.Object) string
+}
+
+// HealthInspector can be implemented by an ObjectComponent whose health depends on further objects
+// in the cluster, e.g. objects that are created by a controller for the reconciled object.
+// It is used instead of IsObjectHealthy when the component is observed.
+type HealthInspector interface {
+	InspectObjectHealth(ctx context.Context, c client.Client, obj client.Object) juggler.ResourceHealthiness
+}
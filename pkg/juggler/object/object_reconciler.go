@@ -22,6 +22,7 @@ var (
 
 var _ juggler.ComponentReconciler = &ObjectReconciler{}
 var _ juggler.OrphanedComponentsDetector = &ObjectReconciler{}
+var _ juggler.ComponentDiffer = &ObjectReconciler{}
 
 func NewReconciler(logger logr.Logger, remoteClient client.Client, labelComponentName string) *ObjectReconciler {
 	return &ObjectReconciler{
@@ -139,11 +140,19 @@ func (r *ObjectReconciler) Observe(ctx context.Context, comp juggler.Component)
 		return juggler.ComponentObservation{}, err
 	}
 
-	return juggler.ComponentObservation{
-		ResourceExists:      true,
-		ResourceSkipped:     shouldSkipReconciliation(obj),
-		ResourceHealthiness: objectComponent.IsObjectHealthy(obj),
-	}, nil
+	observation := juggler.ComponentObservation{
+		ResourceExists:  true,
+		ResourceSkipped: shouldSkipReconciliation(obj),
+	}
+	if hi, ok := comp.(HealthInspector); ok {
+		observation.ResourceHealthiness = hi.InspectObjectHealth(ctx, r.remoteClient, obj)
+	} else {
+		observation.ResourceHealthiness = objectComponent.IsObjectHealthy(obj)
+	}
+	if ivd, ok := comp.(InstalledVersionDetector); ok {
+		observation.Version = ivd.GetInstalledVersion(obj)
+	}
+	return observation, nil
 }
 
 // PreUninstall implements ComponentReconciler.
@@ -170,6 +179,38 @@ func (r *ObjectReconciler) PreUpdate(ctx context.Context, component juggler.Comp
 	return nil
 }
 
+// PostUninstall implements ComponentReconciler.
+func (r *ObjectReconciler) PostUninstall(ctx context.Context, component juggler.Component) error {
+	if component.Hooks().PostUninstall != nil {
+		return component.Hooks().PostUninstall(ctx, r.remoteClient)
+	}
+	return nil
+}
+
+// PostInstall implements ComponentReconciler.
+func (r *ObjectReconciler) PostInstall(ctx context.Context, component juggler.Component) error {
+	if component.Hooks().PostInstall != nil {
+		return component.Hooks().PostInstall(ctx, r.remoteClient)
+	}
+	return nil
+}
+
+// PostUpdate implements ComponentReconciler.
+func (r *ObjectReconciler) PostUpdate(ctx context.Context, component juggler.Component) error {
+	if component.Hooks().PostUpdate != nil {
+		return component.Hooks().PostUpdate(ctx, r.remoteClient)
+	}
+	return nil
+}
+
+// PostHealthy implements ComponentReconciler.
+func (r *ObjectReconciler) PostHealthy(ctx context.Context, component juggler.Component) error {
+	if component.Hooks().PostHealthy != nil {
+		return component.Hooks().PostHealthy(ctx, r.remoteClient)
+	}
+	return nil
+}
+
 // Uninstall implements ComponentReconciler.
 func (r *ObjectReconciler) Uninstall(ctx context.Context, component juggler.Component) error {
 	objectComponent, ok := component.(ObjectComponent)
@@ -216,6 +257,36 @@ func (r *ObjectReconciler) applyObject(ctx context.Context, component juggler.Co
 	return err
 }
 
+// Diff implements juggler.ComponentDiffer.
+func (r *ObjectReconciler) Diff(ctx context.Context, component juggler.Component) (string, error) {
+	objectComponent, ok := component.(ObjectComponent)
+	if !ok {
+		return "", errNotObjectComponent
+	}
+
+	obj, key, err := objectComponent.BuildObjectToReconcile(ctx)
+	if err != nil {
+		return "", err
+	}
+	obj.SetName(key.Name)
+	obj.SetNamespace(key.Namespace)
+
+	err = r.remoteClient.Get(ctx, key, obj)
+	if err != nil && !apierrors.IsNotFound(err) && !utils.IsCRDNotFound(err) {
+		return "", err
+	}
+	if shouldSkipReconciliation(obj) {
+		return "", nil
+	}
+
+	current := obj.DeepCopyObject()
+	utils.SetLabels(obj, r.labelFunc(component))
+	if err := objectComponent.ReconcileObject(ctx, obj); err != nil {
+		return "", err
+	}
+	return utils.DiffObjects(current, obj)
+}
+
 func shouldSkipReconciliation(obj client.Object) bool {
 	if obj == nil {
 		return false
@@ -414,6 +414,42 @@ func TestObjectReconciler_PreUpdate(t *testing.T) {
 	}
 }
 
+func TestObjectReconciler_PostHooks(t *testing.T) {
+	r := &ObjectReconciler{}
+	hook := func(err error) func(ctx context.Context, client client.Client) error {
+		return func(ctx context.Context, client client.Client) error {
+			return err
+		}
+	}
+	tests := []struct {
+		name     string
+		call     func(ctx context.Context, component juggler.Component) error
+		hooks    juggler.ComponentHooks
+		expected error
+	}{
+		{name: "no PostUninstall hook", call: r.PostUninstall},
+		{name: "PostUninstall error", call: r.PostUninstall, hooks: juggler.ComponentHooks{PostUninstall: hook(errBoom)}, expected: errBoom},
+		{name: "PostUninstall no error", call: r.PostUninstall, hooks: juggler.ComponentHooks{PostUninstall: hook(nil)}},
+		{name: "no PostInstall hook", call: r.PostInstall},
+		{name: "PostInstall error", call: r.PostInstall, hooks: juggler.ComponentHooks{PostInstall: hook(errBoom)}, expected: errBoom},
+		{name: "PostInstall no error", call: r.PostInstall, hooks: juggler.ComponentHooks{PostInstall: hook(nil)}},
+		{name: "no PostUpdate hook", call: r.PostUpdate},
+		{name: "PostUpdate error", call: r.PostUpdate, hooks: juggler.ComponentHooks{PostUpdate: hook(errBoom)}, expected: errBoom},
+		{name: "PostUpdate no error", call: r.PostUpdate, hooks: juggler.ComponentHooks{PostUpdate: hook(nil)}},
+		{name: "no PostHealthy hook", call: r.PostHealthy},
+		{name: "PostHealthy error", call: r.PostHealthy, hooks: juggler.ComponentHooks{PostHealthy: hook(errBoom)}, expected: errBoom},
+		{name: "PostHealthy no error", call: r.PostHealthy, hooks: juggler.ComponentHooks{PostHealthy: hook(nil)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			actual := tt.call(context.TODO(), FakeObjectComponent{hooks: tt.hooks})
+			if !errors.Is(actual, tt.expected) {
+				t.Errorf("ObjectReconciler post hook = %v, want %v", actual, tt.expected)
+			}
+		})
+	}
+}
+
 func TestObjectReconciler_Uninstall(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -651,6 +687,54 @@ func TestObjectReconciler_Observe(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "ObjectComponent BuildObject successful - Object found - Health is inspected",
+			obj: FakeHealthInspectingComponent{
+				FakeObjectComponent: FakeObjectComponent{
+					BuildObjectToReconcileFunc: func(ctx context.Context) (client.Object, types.NamespacedName, error) {
+						return &corev1.Secret{}, types.NamespacedName{
+							Name:      "test",
+							Namespace: "default",
+						}, nil
+					},
+					IsObjectHealthyFunc: func(obj client.Object) juggler.ResourceHealthiness {
+						return juggler.ResourceHealthiness{
+							Healthy: true,
+							Message: "not inspected",
+						}
+					},
+				},
+				InspectObjectHealthFunc: func(ctx context.Context, c client.Client, obj client.Object) juggler.ResourceHealthiness {
+					configMap := &corev1.ConfigMap{}
+					err := c.Get(ctx, types.NamespacedName{Name: "related", Namespace: "default"}, configMap)
+					return juggler.ResourceHealthiness{
+						Healthy: err == nil,
+						Message: "related object found",
+					}
+				},
+			},
+			remoteObjects: []client.Object{
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "test",
+						Namespace: "default",
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "related",
+						Namespace: "default",
+					},
+				},
+			},
+			expectedObservation: juggler.ComponentObservation{
+				ResourceExists: true,
+				ResourceHealthiness: juggler.ResourceHealthiness{
+					Healthy: true,
+					Message: "related object found",
+				},
+			},
+		},
 		{
 			name: "ObjectComponent BuildObject successful - Object found - Skip annotation set",
 			obj: FakeObjectComponent{
@@ -709,6 +793,83 @@ func TestObjectReconciler_Observe(t *testing.T) {
 	}
 }
 
+func TestObjectReconciler_Diff(t *testing.T) {
+	component := func(value string) FakeObjectComponent {
+		return FakeObjectComponent{
+			BuildObjectToReconcileFunc: func(ctx context.Context) (client.Object, types.NamespacedName, error) {
+				return &corev1.Secret{}, types.NamespacedName{Name: "test", Namespace: "default"}, nil
+			},
+			ReconcileObjectFunc: func(ctx context.Context, obj client.Object) error {
+				obj.(*corev1.Secret).StringData = map[string]string{"key": value}
+				return nil
+			},
+			name: "FakeObjectComponent",
+		}
+	}
+	existing := func(annotations map[string]string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test",
+				Namespace:   "default",
+				Annotations: annotations,
+				Labels: map[string]string{
+					"app.kubernetes.io/managed-by": "control-plane-operator",
+					testLabelComponentKey:          "FakeObjectComponent",
+				},
+			},
+			StringData: map[string]string{"key": "old"},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		obj           juggler.Component
+		remoteObjects []client.Object
+		wantContains  []string
+		wantErr       error
+	}{
+		{
+			name:    "Error not a ObjectComponent",
+			obj:     FakeComponent{},
+			wantErr: errNotObjectComponent,
+		},
+		{
+			name:          "No changes",
+			obj:           component("old"),
+			remoteObjects: []client.Object{existing(nil)},
+		},
+		{
+			name:          "Object changed",
+			obj:           component("new"),
+			remoteObjects: []client.Object{existing(nil)},
+			wantContains:  []string{"old", "new"},
+		},
+		{
+			name:          "Object changed but reconciliation is skipped",
+			obj:           component("new"),
+			remoteObjects: []client.Object{existing(map[string]string{constants.AnnotationSkipReconciliation: "true"})},
+		},
+		{
+			name:         "Object not found",
+			obj:          component("new"),
+			wantContains: []string{"new", testLabelComponentKey},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewReconciler(logr.Logger{}, fake.NewClientBuilder().WithObjects(tt.remoteObjects...).Build(), testLabelComponentKey)
+			diff, err := r.Diff(context.TODO(), tt.obj)
+			assert.ErrorIs(t, err, tt.wantErr)
+			if len(tt.wantContains) == 0 {
+				assert.Empty(t, diff)
+			}
+			for _, s := range tt.wantContains {
+				assert.Contains(t, diff, s)
+			}
+		})
+	}
+}
+
 func Test_ObjectReconciler_Types(t *testing.T) {
 	r := NewReconciler(logr.Logger{}, nil, "")
 	r.RegisterType(FakeObjectComponent{}, FakeObjectComponent{})
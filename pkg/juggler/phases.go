@@ -0,0 +1,115 @@
+package juggler
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/openmcp-project/control-plane-operator/internal/tracing"
+)
+
+// ReconcilePhase is a step of the reconciliation of a single component.
+type ReconcilePhase string
+
+const (
+	// PhaseObserve is the observation of the current state of a component.
+	PhaseObserve ReconcilePhase = "observe"
+	// PhaseHook is the execution of a pre or post hook of a component.
+	PhaseHook ReconcilePhase = "hook"
+	// PhaseInstall is the installation of a component.
+	PhaseInstall ReconcilePhase = "install"
+	// PhaseUpdate is the update of a component.
+	PhaseUpdate ReconcilePhase = "update"
+	// PhaseUninstall is the removal of a component.
+	PhaseUninstall ReconcilePhase = "uninstall"
+)
+
+// PhaseObserver is called with the duration of every phase of the reconciliation of a component,
+// regardless of whether the phase succeeded or failed.
+type PhaseObserver func(component Component, phase ReconcilePhase, duration time.Duration)
+
+// instrumentedReconciler wraps a ComponentReconciler, starts a span for each call
+// and reports its duration to the PhaseObserver (if set).
+type instrumentedReconciler struct {
+	ComponentReconciler
+	observer PhaseObserver
+	now      func() time.Time
+}
+
+// run calls `fn` within a span named after the `operation` and reports its duration as `phase`.
+func (r *instrumentedReconciler) run(ctx context.Context, component Component, phase ReconcilePhase, operation string, fn func(ctx context.Context) error) error {
+	ctx, span := tracing.Start(ctx, "ComponentReconciler."+operation,
+		attribute.String("component.name", component.GetName()),
+		attribute.String("component.kind", ComponentKind(component)),
+		attribute.String("phase", string(phase)),
+	)
+	start := r.now()
+	err := fn(ctx)
+	if r.observer != nil {
+		r.observer(component, phase, r.now().Sub(start))
+	}
+	tracing.End(span, err)
+	return err
+}
+
+// Observe implements ComponentReconciler.
+func (r *instrumentedReconciler) Observe(ctx context.Context, component Component) (ComponentObservation, error) {
+	var observation ComponentObservation
+	err := r.run(ctx, component, PhaseObserve, "Observe", func(ctx context.Context) error {
+		var err error
+		observation, err = r.ComponentReconciler.Observe(ctx, component)
+		return err
+	})
+	return observation, err
+}
+
+// Install implements ComponentReconciler.
+func (r *instrumentedReconciler) Install(ctx context.Context, component Component) error {
+	return r.run(ctx, component, PhaseInstall, "Install", func(ctx context.Context) error { return r.ComponentReconciler.Install(ctx, component) })
+}
+
+// Update implements ComponentReconciler.
+func (r *instrumentedReconciler) Update(ctx context.Context, component Component) error {
+	return r.run(ctx, component, PhaseUpdate, "Update", func(ctx context.Context) error { return r.ComponentReconciler.Update(ctx, component) })
+}
+
+// Uninstall implements ComponentReconciler.
+func (r *instrumentedReconciler) Uninstall(ctx context.Context, component Component) error {
+	return r.run(ctx, component, PhaseUninstall, "Uninstall", func(ctx context.Context) error { return r.ComponentReconciler.Uninstall(ctx, component) })
+}
+
+// PreInstall implements ComponentReconciler.
+func (r *instrumentedReconciler) PreInstall(ctx context.Context, component Component) error {
+	return r.run(ctx, component, PhaseHook, "PreInstall", func(ctx context.Context) error { return r.ComponentReconciler.PreInstall(ctx, component) })
+}
+
+// PreUpdate implements ComponentReconciler.
+func (r *instrumentedReconciler) PreUpdate(ctx context.Context, component Component) error {
+	return r.run(ctx, component, PhaseHook, "PreUpdate", func(ctx context.Context) error { return r.ComponentReconciler.PreUpdate(ctx, component) })
+}
+
+// PreUninstall implements ComponentReconciler.
+func (r *instrumentedReconciler) PreUninstall(ctx context.Context, component Component) error {
+	return r.run(ctx, component, PhaseHook, "PreUninstall", func(ctx context.Context) error { return r.ComponentReconciler.PreUninstall(ctx, component) })
+}
+
+// PostInstall implements ComponentReconciler.
+func (r *instrumentedReconciler) PostInstall(ctx context.Context, component Component) error {
+	return r.run(ctx, component, PhaseHook, "PostInstall", func(ctx context.Context) error { return r.ComponentReconciler.PostInstall(ctx, component) })
+}
+
+// PostUpdate implements ComponentReconciler.
+func (r *instrumentedReconciler) PostUpdate(ctx context.Context, component Component) error {
+	return r.run(ctx, component, PhaseHook, "PostUpdate", func(ctx context.Context) error { return r.ComponentReconciler.PostUpdate(ctx, component) })
+}
+
+// PostUninstall implements ComponentReconciler.
+func (r *instrumentedReconciler) PostUninstall(ctx context.Context, component Component) error {
+	return r.run(ctx, component, PhaseHook, "PostUninstall", func(ctx context.Context) error { return r.ComponentReconciler.PostUninstall(ctx, component) })
+}
+
+// PostHealthy implements ComponentReconciler.
+func (r *instrumentedReconciler) PostHealthy(ctx context.Context, component Component) error {
+	return r.run(ctx, component, PhaseHook, "PostHealthy", func(ctx context.Context) error { return r.ComponentReconciler.PostHealthy(ctx, component) })
+}
@@ -0,0 +1,109 @@
+package juggler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"k8s.io/client-go/tools/events"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+)
+
+func TestJuggler_WithPhaseObserver(t *testing.T) {
+	noop := func(ctx context.Context, component Component) error { return nil }
+	tests := []struct {
+		name      string
+		component Component
+		exists    bool
+		want      []ReconcilePhase
+	}{
+		{
+			name:      "install",
+			component: FakeComponent{Enabled: true, Allowed: true},
+			want:      []ReconcilePhase{PhaseObserve, PhaseHook, PhaseInstall, PhaseHook},
+		},
+		{
+			name:      "update",
+			component: FakeComponent{Enabled: true, Allowed: true},
+			exists:    true,
+			want:      []ReconcilePhase{PhaseObserve, PhaseHook, PhaseUpdate, PhaseHook, PhaseObserve},
+		},
+		{
+			name:      "uninstall",
+			component: FakeComponent{},
+			exists:    true,
+			want:      []ReconcilePhase{PhaseObserve, PhaseHook, PhaseUninstall, PhaseHook},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var phases []ReconcilePhase
+			cp := v1beta1.ControlPlane{}
+			am := NewJuggler(testr.New(t), &ObjectEventRecorder{
+				recorder: events.NewFakeRecorder(3),
+				object:   &cp,
+			}).WithPhaseObserver(func(component Component, phase ReconcilePhase, duration time.Duration) {
+				assert.Equal(t, tt.component, component)
+				assert.GreaterOrEqual(t, duration, time.Duration(0))
+				phases = append(phases, phase)
+			})
+			am.RegisterReconciler(FakeReconciler{
+				KnownTypesFunc: knowsAll(),
+				ObserverFunc: func(ctx context.Context, component Component) (ComponentObservation, error) {
+					return ComponentObservation{ResourceExists: tt.exists, ResourceHealthiness: ResourceHealthiness{Healthy: true}}, nil
+				},
+				InstallFunc:       noop,
+				UpdateFunc:        noop,
+				UninstallFunc:     noop,
+				PreInstallFunc:    noop,
+				PreUpdateFunc:     noop,
+				PreUninstallFunc:  noop,
+				PostInstallFunc:   noop,
+				PostUpdateFunc:    noop,
+				PostUninstallFunc: noop,
+			})
+
+			am.reconcileComponent(context.TODO(), tt.component, nil)
+			assert.Equal(t, tt.want, phases)
+		})
+	}
+}
+
+func TestJuggler_reconcileComponent_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	defer otel.SetTracerProvider(previous)
+
+	cp := v1beta1.ControlPlane{}
+	am := NewJuggler(testr.New(t), &ObjectEventRecorder{
+		recorder: events.NewFakeRecorder(3),
+		object:   &cp,
+	})
+	am.RegisterReconciler(FakeReconciler{
+		KnownTypesFunc: knowsAll(),
+		ObserverFunc: func(ctx context.Context, component Component) (ComponentObservation, error) {
+			return ComponentObservation{}, errBoom
+		},
+	})
+
+	result := am.reconcileComponent(context.TODO(), FakeComponent{Enabled: true, Allowed: true}, nil)
+	assert.Equal(t, StatusObservationFailed, result.Result)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 2) {
+		assert.Equal(t, "ComponentReconciler.Observe", spans[0].Name())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, "Juggler.reconcileComponent", spans[1].Name())
+		assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+		assert.Contains(t, spans[1].Attributes(), attribute.String("component.status", StatusObservationFailed.Name))
+	}
+}
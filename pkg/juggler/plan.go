@@ -0,0 +1,152 @@
+package juggler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/openmcp-project/control-plane-operator/internal/ocm"
+)
+
+// PlanAction describes what the Juggler would do with a component during the next reconciliation.
+type PlanAction string
+
+const (
+	// PlanActionNone states that the component is up-to-date or disabled and nothing would be done.
+	PlanActionNone PlanAction = "None"
+	// PlanActionInstall states that the component would be installed.
+	PlanActionInstall PlanAction = "Install"
+	// PlanActionUpdate states that the component would be updated.
+	PlanActionUpdate PlanAction = "Update"
+	// PlanActionUninstall states that the component would be uninstalled.
+	PlanActionUninstall PlanAction = "Uninstall"
+	// PlanActionBlocked states that the component would not be reconciled, e.g. due to a failed dependency check.
+	PlanActionBlocked PlanAction = "Blocked"
+	// PlanActionUnknown states that it could not be determined what would happen with the component.
+	PlanActionUnknown PlanAction = "Unknown"
+)
+
+// ComponentPlan contains information about an operation the Juggler would perform on a component.
+type ComponentPlan struct {
+	Component Component
+	Action    PlanAction
+	Message   string
+	// Diff is only set for PlanActionUpdate, if the reconciler of the component implements `ComponentDiffer`.
+	Diff string
+}
+
+// Plan computes what Reconcile would do for each registered component without changing anything.
+// Components are processed in the same order as in Reconcile. Only Observe, IsInstallable and
+// (if implemented) Diff are called, hooks are never executed.
+// The returned plans are always in registration order.
+func (am *Juggler) Plan(ctx context.Context) []ComponentPlan {
+	plans := make([]ComponentPlan, len(am.components))
+	// ready tracks which components would be ready after the planned reconciliation,
+	// so that dependents can be planned accordingly.
+	ready := make([]bool, len(am.components))
+
+	graph := newDependencyGraph(am.components)
+	sorted, cyclic := graph.sort()
+
+	if len(cyclic) > 0 {
+		message := am.cycleError(cyclic).Error()
+		for _, i := range cyclic {
+			plans[i] = ComponentPlan{
+				Component: am.components[i],
+				Action:    PlanActionBlocked,
+				Message:   message,
+			}
+		}
+	}
+
+	am.forEachInDependencyOrder(graph, sorted, func(i int) {
+		plans[i], ready[i] = am.planComponent(ctx, am.components[i], func() error {
+			for _, j := range graph.dependencies[i] {
+				if !ready[j] {
+					return fmt.Errorf("%w: %s (%s)", errDependencyNotReady, am.components[j].GetName(), plans[j].Action)
+				}
+			}
+			return nil
+		})
+	})
+
+	return plans
+}
+
+// planComponent mirrors reconcileComponent without performing any changes. It returns the plan for the component
+// and whether the component would be ready afterwards. `dependenciesReady` is called to check if all dependencies
+// of the component would be ready after the planned reconciliation.
+func (am *Juggler) planComponent(ctx context.Context, component Component, dependenciesReady func() error) (ComponentPlan, bool) {
+	plan := func(action PlanAction, message string) ComponentPlan {
+		return ComponentPlan{Component: component, Action: action, Message: message}
+	}
+
+	reconciler, err := am.findReconcilerFor(component)
+	if err != nil {
+		return plan(PlanActionUnknown, err.Error()), false
+	}
+
+	observation, err := reconciler.Observe(ctx, component)
+	if err != nil {
+		return plan(PlanActionUnknown, err.Error()), false
+	}
+
+	if observation.ResourceExists && !component.IsEnabled() {
+		if kou, ok := component.(KeepOnUninstall); ok && kou.KeepOnUninstall() {
+			return plan(PlanActionNone, fmt.Sprintf("%s is marked as 'keep on uninstall'.", component.GetName())), false
+		}
+		return plan(PlanActionUninstall, fmt.Sprintf("%s would be uninstalled.", component.GetName())), false
+	}
+
+	if !component.IsEnabled() {
+		return plan(PlanActionNone, fmt.Sprintf("%s is not enabled.", component.GetName())), false
+	}
+
+	is, err := component.IsInstallable(ctx)
+	if observation.ResourceExists && errors.Is(err, ocm.ErrComponentVersionNotFound) {
+		message := fmt.Sprintf("%s is installed but current version is not in release channel", component.GetName())
+		message = am.appendAvailableVersionsToMessage(ctx, component, message)
+		return plan(PlanActionNone, message), true
+	}
+	if err != nil {
+		return plan(PlanActionBlocked, fmt.Sprintf("%s not installable: %s", component.GetName(), err.Error())), false
+	}
+	if !is {
+		return plan(PlanActionBlocked, fmt.Sprintf("%s not installable.", component.GetName())), false
+	}
+
+	if err := am.checkDependencies(component); err != nil {
+		return plan(PlanActionBlocked, err.Error()), false
+	}
+
+	if err := dependenciesReady(); err != nil {
+		return plan(PlanActionBlocked, err.Error()), false
+	}
+
+	if !observation.ResourceExists {
+		return plan(PlanActionInstall, fmt.Sprintf("%s would be installed.", component.GetName())), false
+	}
+
+	if observation.ResourceSkipped {
+		message := fmt.Sprintf("Reconciliation of %s skipped due to skip-reconciliation annotation.", component.GetName())
+		return plan(PlanActionNone, message), observation.Healthy
+	}
+
+	differ, ok := reconciler.(ComponentDiffer)
+	if !ok {
+		message := fmt.Sprintf("%s would be updated, changes cannot be determined in advance.", component.GetName())
+		return plan(PlanActionUpdate, message), observation.Healthy
+	}
+
+	diff, err := differ.Diff(ctx, component)
+	if err != nil {
+		return plan(PlanActionUnknown, err.Error()), false
+	}
+	if diff == "" {
+		return plan(PlanActionNone, fmt.Sprintf("%s is up-to-date.", component.GetName())), observation.Healthy
+	}
+
+	p := plan(PlanActionUpdate, fmt.Sprintf("%s would be updated.", component.GetName()))
+	p.Diff = diff
+	return p, observation.Healthy
+}
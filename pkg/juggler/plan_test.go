@@ -0,0 +1,177 @@
+package juggler
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/events"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+)
+
+func TestJuggler_Plan(t *testing.T) {
+	noChanges := func(ctx context.Context, component Component) error {
+		t.Fatalf("%s must not be changed during planning", component.GetName())
+		return nil
+	}
+	fakeReconciler := func(exists, healthy bool) FakeReconciler {
+		return FakeReconciler{
+			KnownTypesFunc: knowsAll(),
+			ObserverFunc: func(ctx context.Context, component Component) (ComponentObservation, error) {
+				return ComponentObservation{
+					ResourceExists:      exists,
+					ResourceHealthiness: ResourceHealthiness{Healthy: healthy},
+				}, nil
+			},
+			InstallFunc:      noChanges,
+			UpdateFunc:       noChanges,
+			UninstallFunc:    noChanges,
+			PreInstallFunc:   noChanges,
+			PreUpdateFunc:    noChanges,
+			PreUninstallFunc: noChanges,
+		}
+	}
+	differ := func(exists, healthy bool, diff string, err error) FakeDifferReconciler {
+		return FakeDifferReconciler{
+			FakeReconciler: fakeReconciler(exists, healthy),
+			DiffFunc: func(ctx context.Context, component Component) (string, error) {
+				return diff, err
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		components []Component
+		reconciler ComponentReconciler
+		want       []ComponentPlan
+	}{
+		{
+			name:       "install",
+			components: []Component{FakeComponent{Enabled: true, Allowed: true}},
+			reconciler: fakeReconciler(false, false),
+			want: []ComponentPlan{
+				{Component: FakeComponent{Enabled: true, Allowed: true}, Action: PlanActionInstall, Message: "FakeComponent would be installed."},
+			},
+		},
+		{
+			name:       "uninstall",
+			components: []Component{FakeComponent{}},
+			reconciler: fakeReconciler(true, true),
+			want: []ComponentPlan{
+				{Component: FakeComponent{}, Action: PlanActionUninstall, Message: "FakeComponent would be uninstalled."},
+			},
+		},
+		{
+			name:       "keep on uninstall",
+			components: []Component{FakeComponent{KeepInstalled: true}},
+			reconciler: fakeReconciler(true, true),
+			want: []ComponentPlan{
+				{Component: FakeComponent{KeepInstalled: true}, Action: PlanActionNone, Message: "FakeComponent is marked as 'keep on uninstall'."},
+			},
+		},
+		{
+			name:       "disabled",
+			components: []Component{FakeComponent{}},
+			reconciler: fakeReconciler(false, false),
+			want: []ComponentPlan{
+				{Component: FakeComponent{}, Action: PlanActionNone, Message: "FakeComponent is not enabled."},
+			},
+		},
+		{
+			name:       "not allowed",
+			components: []Component{FakeComponent{Enabled: true}},
+			reconciler: fakeReconciler(false, false),
+			want: []ComponentPlan{
+				{Component: FakeComponent{Enabled: true}, Action: PlanActionBlocked, Message: "FakeComponent not installable."},
+			},
+		},
+		{
+			name:       "update without differ",
+			components: []Component{FakeComponent{Enabled: true, Allowed: true}},
+			reconciler: fakeReconciler(true, true),
+			want: []ComponentPlan{
+				{
+					Component: FakeComponent{Enabled: true, Allowed: true},
+					Action:    PlanActionUpdate,
+					Message:   "FakeComponent would be updated, changes cannot be determined in advance.",
+				},
+			},
+		},
+		{
+			name:       "update with diff",
+			components: []Component{FakeComponent{Enabled: true, Allowed: true}},
+			reconciler: differ(true, true, "-a\n+b", nil),
+			want: []ComponentPlan{
+				{Component: FakeComponent{Enabled: true, Allowed: true}, Action: PlanActionUpdate, Message: "FakeComponent would be updated.", Diff: "-a\n+b"},
+			},
+		},
+		{
+			name:       "up-to-date",
+			components: []Component{FakeComponent{Enabled: true, Allowed: true}},
+			reconciler: differ(true, true, "", nil),
+			want: []ComponentPlan{
+				{Component: FakeComponent{Enabled: true, Allowed: true}, Action: PlanActionNone, Message: "FakeComponent is up-to-date."},
+			},
+		},
+		{
+			name:       "diff failed",
+			components: []Component{FakeComponent{Enabled: true, Allowed: true}},
+			reconciler: differ(true, true, "", errBoom),
+			want: []ComponentPlan{
+				{Component: FakeComponent{Enabled: true, Allowed: true}, Action: PlanActionUnknown, Message: errBoom.Error()},
+			},
+		},
+		{
+			name: "dependent is blocked until dependency is installed",
+			components: []Component{
+				FakeComponent{Name: "Dependent", Enabled: true, Allowed: true, Dependencies: []Component{FakeComponent2{}}},
+				FakeComponent2{Name: "Dependency", Enabled: true, Allowed: true},
+			},
+			reconciler: fakeReconciler(false, false),
+			want: []ComponentPlan{
+				{
+					Component: FakeComponent{Name: "Dependent", Enabled: true, Allowed: true, Dependencies: []Component{FakeComponent2{}}},
+					Action:    PlanActionBlocked,
+					Message:   fmt.Sprintf("%s: Dependency (Install)", errDependencyNotReady),
+				},
+				{Component: FakeComponent2{Name: "Dependency", Enabled: true, Allowed: true}, Action: PlanActionInstall, Message: "Dependency would be installed."},
+			},
+		},
+		{
+			name: "dependency cycle",
+			components: []Component{
+				FakeComponent{Name: "A", Enabled: true, Allowed: true, Dependencies: []Component{FakeComponent2{}}},
+				FakeComponent2{Name: "B", Enabled: true, Allowed: true, Dependencies: []Component{FakeComponent{}}},
+			},
+			reconciler: fakeReconciler(false, false),
+			want: []ComponentPlan{
+				{
+					Component: FakeComponent{Name: "A", Enabled: true, Allowed: true, Dependencies: []Component{FakeComponent2{}}},
+					Action:    PlanActionBlocked,
+					Message:   fmt.Sprintf("%s: A, B", errDependencyCycle),
+				},
+				{
+					Component: FakeComponent2{Name: "B", Enabled: true, Allowed: true, Dependencies: []Component{FakeComponent{}}},
+					Action:    PlanActionBlocked,
+					Message:   fmt.Sprintf("%s: A, B", errDependencyCycle),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cp := v1beta1.ControlPlane{}
+			am := NewJuggler(testr.New(t), &ObjectEventRecorder{
+				recorder: events.NewFakeRecorder(3),
+				object:   &cp,
+			})
+			am.RegisterComponent(tt.components...)
+			am.RegisterReconciler(tt.reconciler)
+			assert.Equal(t, tt.want, am.Plan(context.TODO()))
+		})
+	}
+}
@@ -25,12 +25,22 @@ type ComponentReconciler interface {
 	PreInstall(ctx context.Context, component Component) error
 	// PreUpdate calls the pre-update hook of a Component.
 	PreUpdate(ctx context.Context, component Component) error
+	// PostUninstall calls the post-uninstall hook of a Component.
+	PostUninstall(ctx context.Context, component Component) error
+	// PostInstall calls the post-install hook of a Component.
+	PostInstall(ctx context.Context, component Component) error
+	// PostUpdate calls the post-update hook of a Component.
+	PostUpdate(ctx context.Context, component Component) error
+	// PostHealthy calls the post-healthy hook of a Component.
+	PostHealthy(ctx context.Context, component Component) error
 }
 
 type ComponentObservation struct {
 	ResourceExists  bool
 	ResourceSkipped bool
 	ResourceHealthiness
+	// Version is the currently installed version of the component, if it can be determined.
+	Version string
 }
 
 type ResourceHealthiness struct {
@@ -48,6 +58,14 @@ type OrphanedComponentsDetector interface {
 	DetectOrphanedComponents(ctx context.Context, configuredComponents []Component) ([]Component, error)
 }
 
+// ComponentDiffer can be implemented by a `ComponentReconciler` to signal that it
+// supports computing the changes an update would apply (see `Juggler.Plan`).
+type ComponentDiffer interface {
+	// Diff returns a human-readable diff between the current and the desired state of an existing Component
+	// without changing anything in the cluster. An empty string means that an update would be a no-op.
+	Diff(ctx context.Context, component Component) (string, error)
+}
+
 // LabelFunc defines the Kubernetes object labels to be set during component reconciliation
 type LabelFunc func(comp Component) map[string]string
 
@@ -0,0 +1,105 @@
+package juggler
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RetryState describes the consecutive failed reconciliations of a component.
+type RetryState struct {
+	// RetryCount is the number of consecutive failed reconciliations.
+	RetryCount int
+	// NextRetryAt is the point in time before which the component is not reconciled again.
+	NextRetryAt time.Time
+	// LastError is the message of the last failed reconciliation.
+	LastError string
+	// Version is the desired version of the component at the last failed reconciliation.
+	Version string
+	// Enabled states if the component was enabled at the last failed reconciliation.
+	Enabled bool
+}
+
+// appliesTo returns if the RetryState has been recorded for the current spec of the component.
+// Changing the desired version or enabling/disabling a component starts over without a backoff.
+func (s RetryState) appliesTo(component Component) bool {
+	return s.Version == ComponentResult{Component: component}.DesiredVersion() && s.Enabled == component.IsEnabled()
+}
+
+// RetryHistory returns the RetryState of a component after the previous reconciliation.
+type RetryHistory func(component Component) RetryState
+
+// RetryBackoff returns the delay before a component is retried after `retryCount` consecutive failures.
+type RetryBackoff func(retryCount int) time.Duration
+
+// ExponentialBackoff returns a RetryBackoff which starts with `base` and doubles the delay
+// with every consecutive failure, but never exceeds `maxDelay`.
+func ExponentialBackoff(base, maxDelay time.Duration) RetryBackoff {
+	return func(retryCount int) time.Duration {
+		delay := base
+		for i := 1; i < retryCount && delay < maxDelay; i++ {
+			delay *= 2
+		}
+		return min(delay, maxDelay)
+	}
+}
+
+// IsFailure returns if the status represents a failed reconciliation.
+// Every status that emits a warning event is considered a failure.
+func (s ComponentStatus) IsFailure() bool {
+	return s.EmitsEvent == ComponentEventWarning
+}
+
+// backsOff returns if the status represents a failed reconciliation that is retried with a backoff.
+// Failures caused by the configuration, i.e. missing dependencies or components that are not allowed,
+// are not backed off, so that they are resolved as soon as the configuration is fixed.
+func (s ComponentStatus) backsOff() bool {
+	return s.IsFailure() && s.Name != StatusDependencyCheckFailed.Name && s.Name != StatusComponentNotAllowed.Name
+}
+
+// IsFailurePhase returns if `phase`, the name of a ComponentStatus as stored in the status
+// of a ControlPlane, represents a failed reconciliation.
+func IsFailurePhase(phase string) bool {
+	s, ok := statusesByName[phase]
+	return ok && s.IsFailure()
+}
+
+// reconcileWithRetry reconciles a component unless it is backing off after previous failures.
+// The RetryState of the returned ComponentResult is only maintained if a RetryBackoff is configured.
+func (am *Juggler) reconcileWithRetry(ctx context.Context, component Component, results []ComponentResult) ComponentResult {
+	if am.retryBackoff == nil {
+		return am.reconcileComponent(ctx, component, results)
+	}
+
+	previous := RetryState{}
+	if am.retryHistory != nil {
+		previous = am.retryHistory(component)
+	}
+	if !previous.appliesTo(component) {
+		previous = RetryState{}
+	}
+
+	now := am.now()
+	if previous.RetryCount > 0 && now.Before(previous.NextRetryAt) {
+		return ComponentResult{
+			Component: component,
+			Result:    StatusRetryBackoff,
+			Message: fmt.Sprintf("%s failed %d time(s) in a row, next retry at %s: %s", component.GetName(),
+				previous.RetryCount, previous.NextRetryAt.UTC().Format(time.RFC3339), previous.LastError),
+			Retry: previous,
+		}
+	}
+
+	cr := am.reconcileComponent(ctx, component, results)
+	if cr.Result.backsOff() {
+		retryCount := previous.RetryCount + 1
+		cr.Retry = RetryState{
+			RetryCount:  retryCount,
+			NextRetryAt: now.Add(am.retryBackoff(retryCount)),
+			LastError:   cr.Message,
+			Version:     ComponentResult{Component: component}.DesiredVersion(),
+			Enabled:     component.IsEnabled(),
+		}
+	}
+	return cr
+}
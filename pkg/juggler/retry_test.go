@@ -0,0 +1,151 @@
+package juggler
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/testr"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/tools/events"
+
+	"github.com/openmcp-project/control-plane-operator/api/v1beta1"
+)
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(10*time.Second, time.Minute)
+	assert.Equal(t, 10*time.Second, backoff(1))
+	assert.Equal(t, 20*time.Second, backoff(2))
+	assert.Equal(t, 40*time.Second, backoff(3))
+	assert.Equal(t, time.Minute, backoff(4))
+	assert.Equal(t, time.Minute, backoff(100))
+}
+
+func TestIsFailurePhase(t *testing.T) {
+	assert.True(t, IsFailurePhase(StatusInstallFailed.Name))
+	assert.True(t, IsFailurePhase(StatusPostHealthyHookFailed.Name))
+	assert.False(t, IsFailurePhase(StatusHealthy.Name))
+	assert.False(t, IsFailurePhase(StatusRetryBackoff.Name))
+	assert.False(t, IsFailurePhase("unknown"))
+}
+
+func TestComponentStatus_backsOff(t *testing.T) {
+	assert.True(t, StatusInstallFailed.backsOff())
+	assert.False(t, StatusDependencyCheckFailed.backsOff())
+	assert.False(t, StatusComponentNotAllowed.backsOff())
+	assert.False(t, StatusHealthy.backsOff())
+}
+
+func TestJuggler_Reconcile_RetryBackoff(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	component := FakeComponent{Enabled: true, Allowed: true}
+	versioned := &test_versionedType{FakeComponent: component}
+
+	tests := []struct {
+		name          string
+		component     Component
+		previous      RetryState
+		installErr    error
+		wantResult    ComponentStatus
+		wantRetry     RetryState
+		wantInstalled bool
+	}{
+		{
+			name:          "first failure",
+			installErr:    errBoom,
+			wantResult:    StatusInstallFailed,
+			wantRetry:     RetryState{RetryCount: 1, NextRetryAt: now.Add(10 * time.Second), LastError: errBoom.Error(), Enabled: true},
+			wantInstalled: true,
+		},
+		{
+			name:          "consecutive failure",
+			previous:      RetryState{RetryCount: 2, NextRetryAt: now.Add(-time.Second), LastError: errBoom.Error(), Enabled: true},
+			installErr:    errBoom,
+			wantResult:    StatusInstallFailed,
+			wantRetry:     RetryState{RetryCount: 3, NextRetryAt: now.Add(40 * time.Second), LastError: errBoom.Error(), Enabled: true},
+			wantInstalled: true,
+		},
+		{
+			name:          "backing off",
+			previous:      RetryState{RetryCount: 2, NextRetryAt: now.Add(time.Second), LastError: errBoom.Error(), Enabled: true},
+			wantResult:    StatusRetryBackoff,
+			wantRetry:     RetryState{RetryCount: 2, NextRetryAt: now.Add(time.Second), LastError: errBoom.Error(), Enabled: true},
+			wantInstalled: false,
+		},
+		{
+			name:          "version changed while backing off",
+			component:     versioned,
+			previous:      RetryState{RetryCount: 2, NextRetryAt: now.Add(time.Second), LastError: errBoom.Error(), Version: "0.9.0", Enabled: true},
+			installErr:    errBoom,
+			wantResult:    StatusInstallFailed,
+			wantRetry:     RetryState{RetryCount: 1, NextRetryAt: now.Add(10 * time.Second), LastError: errBoom.Error(), Version: "1.0.0", Enabled: true},
+			wantInstalled: true,
+		},
+		{
+			name:          "same version while backing off",
+			component:     versioned,
+			previous:      RetryState{RetryCount: 2, NextRetryAt: now.Add(time.Second), LastError: errBoom.Error(), Version: "1.0.0", Enabled: true},
+			wantResult:    StatusRetryBackoff,
+			wantRetry:     RetryState{RetryCount: 2, NextRetryAt: now.Add(time.Second), LastError: errBoom.Error(), Version: "1.0.0", Enabled: true},
+			wantInstalled: false,
+		},
+		{
+			name:          "enabled while backing off",
+			previous:      RetryState{RetryCount: 2, NextRetryAt: now.Add(time.Second), LastError: errBoom.Error()},
+			wantResult:    StatusInstalled,
+			wantRetry:     RetryState{},
+			wantInstalled: true,
+		},
+		{
+			name:          "not allowed is not backed off",
+			component:     FakeComponent{Enabled: true, Allowed: false},
+			wantResult:    StatusComponentNotAllowed,
+			wantRetry:     RetryState{},
+			wantInstalled: false,
+		},
+		{
+			name:          "success resets retry state",
+			previous:      RetryState{RetryCount: 2, NextRetryAt: now.Add(-time.Second), LastError: errBoom.Error(), Enabled: true},
+			wantResult:    StatusInstalled,
+			wantRetry:     RetryState{},
+			wantInstalled: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			installed := false
+
+			cp := v1beta1.ControlPlane{}
+			am := NewJuggler(testr.New(t), &ObjectEventRecorder{
+				recorder: events.NewFakeRecorder(3),
+				object:   &cp,
+			}).WithRetryBackoff(ExponentialBackoff(10*time.Second, time.Minute), func(Component) RetryState {
+				return tt.previous
+			})
+			am.now = func() time.Time { return now }
+			if tt.component == nil {
+				tt.component = component
+			}
+			am.RegisterComponent(tt.component)
+			am.RegisterReconciler(FakeReconciler{
+				KnownTypesFunc: func() []reflect.Type {
+					return append(knowsAll()(), reflect.TypeOf(versioned))
+				},
+				ObserverFunc: func(ctx context.Context, component Component) (ComponentObservation, error) {
+					return ComponentObservation{ResourceExists: false}, nil
+				},
+				InstallFunc: func(ctx context.Context, component Component) error {
+					installed = true
+					return tt.installErr
+				},
+			})
+
+			results := am.Reconcile(context.TODO())
+			assert.Len(t, results, 1)
+			assert.Equal(t, tt.wantResult, results[0].Result)
+			assert.Equal(t, tt.wantRetry, results[0].Retry)
+			assert.Equal(t, tt.wantInstalled, installed)
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"github.com/google/go-cmp/cmp"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// DiffObjects returns a human-readable diff between the current and the desired state of an object.
+// Only labels, annotations and the remaining top-level fields except `status` are compared,
+// server-managed metadata is ignored. An empty string means that there is no difference.
+func DiffObjects(current, desired runtime.Object) (string, error) {
+	c, err := comparableContent(current)
+	if err != nil {
+		return "", err
+	}
+	d, err := comparableContent(desired)
+	if err != nil {
+		return "", err
+	}
+	return cmp.Diff(c, d), nil
+}
+
+func comparableContent(obj runtime.Object) (map[string]any, error) {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	labels, _, _ := unstructured.NestedFieldNoCopy(content, "metadata", "labels")
+	annotations, _, _ := unstructured.NestedFieldNoCopy(content, "metadata", "annotations")
+
+	delete(content, "status")
+	delete(content, "metadata")
+	if labels != nil {
+		_ = unstructured.SetNestedField(content, labels, "metadata", "labels")
+	}
+	if annotations != nil {
+		_ = unstructured.SetNestedField(content, annotations, "metadata", "annotations")
+	}
+	return content, nil
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDiffObjects(t *testing.T) {
+	base := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test",
+			Namespace:       "default",
+			ResourceVersion: "1",
+			Labels:          map[string]string{"foo": "bar"},
+		},
+		Data: map[string]string{"key": "value"},
+	}
+
+	tests := []struct {
+		name     string
+		mutate   func(cm *corev1.ConfigMap)
+		wantDiff bool
+	}{
+		{
+			name:     "no change",
+			mutate:   func(cm *corev1.ConfigMap) {},
+			wantDiff: false,
+		},
+		{
+			name:     "server-managed metadata is ignored",
+			mutate:   func(cm *corev1.ConfigMap) { cm.ResourceVersion = "2"; cm.Generation = 3 },
+			wantDiff: false,
+		},
+		{
+			name:     "data changed",
+			mutate:   func(cm *corev1.ConfigMap) { cm.Data["key"] = "other" },
+			wantDiff: true,
+		},
+		{
+			name:     "label changed",
+			mutate:   func(cm *corev1.ConfigMap) { cm.Labels["foo"] = "baz" },
+			wantDiff: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := base.DeepCopy()
+			tt.mutate(desired)
+			diff, err := DiffObjects(base, desired)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantDiff, diff != "", diff)
+		})
+	}
+}
@@ -64,3 +64,22 @@ func GetNestedValue(m map[string]any, path ...string) (any, error) {
 	}
 	return GetNestedValue(subMap, path[1:]...)
 }
+
+// MergeMaps returns a deep merge of both maps. Values of `override` take precedence,
+// nested maps are merged recursively. Neither map is modified.
+func MergeMaps(base, override map[string]any) map[string]any {
+	merged := make(map[string]any, len(base)+len(override))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range override {
+		baseMap, baseOk := merged[k].(map[string]any)
+		overrideMap, overrideOk := v.(map[string]any)
+		if baseOk && overrideOk {
+			merged[k] = MergeMaps(baseMap, overrideMap)
+			continue
+		}
+		merged[k] = v
+	}
+	return merged
+}
@@ -159,3 +159,51 @@ func Test_GetNestedValue(t *testing.T) {
 		})
 	}
 }
+
+func Test_MergeMaps(t *testing.T) {
+	testCases := []struct {
+		desc     string
+		base     map[string]any
+		override map[string]any
+		expected map[string]any
+	}{
+		{
+			desc:     "should handle nil maps",
+			expected: map[string]any{},
+		},
+		{
+			desc:     "should keep values of base",
+			base:     map[string]any{"replicas": 1},
+			expected: map[string]any{"replicas": 1},
+		},
+		{
+			desc:     "should prefer values of override",
+			base:     map[string]any{"replicas": 1, "debug": true},
+			override: map[string]any{"replicas": 2},
+			expected: map[string]any{"replicas": 2, "debug": true},
+		},
+		{
+			desc: "should merge nested maps",
+			base: map[string]any{
+				"resources": map[string]any{"cpu": "100m", "memory": "128Mi"},
+			},
+			override: map[string]any{
+				"resources": map[string]any{"memory": "256Mi"},
+			},
+			expected: map[string]any{
+				"resources": map[string]any{"cpu": "100m", "memory": "256Mi"},
+			},
+		},
+		{
+			desc:     "should replace non-map values",
+			base:     map[string]any{"args": []any{"--a"}},
+			override: map[string]any{"args": []any{"--b"}},
+			expected: map[string]any{"args": []any{"--b"}},
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			assert.Equal(t, tC.expected, MergeMaps(tC.base, tC.override))
+		})
+	}
+}